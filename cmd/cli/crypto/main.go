@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/crypto"
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/env"
+)
+
+type config struct {
+	DatabaseURL          string `split_words:"true"`
+	EnvVarsSecret        string `split_words:"true"`
+	PrivRegistriesSecret string `split_words:"true"`
+	NotificationsSecret  string `split_words:"true"`
+	DomainsSecret        string `split_words:"true"`
+	EndpointsSecret      string `split_words:"true"`
+	SecretStoresSecret   string `split_words:"true"`
+}
+
+const defaultBatchSize = 100
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	args := os.Args[2:]
+
+	switch command {
+	case "generate-key":
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			panic(errors.Wrap(err, "fail to generate key"))
+		}
+		fmt.Println(key)
+	case "rotate":
+		allowLegacy := false
+		positional := []string{}
+		for _, arg := range args {
+			if arg == "--allow-legacy" {
+				allowLegacy = true
+				continue
+			}
+			positional = append(positional, arg)
+		}
+
+		if len(positional) > 1 {
+			fmt.Println("Invalid number of arguments for 'rotate' command")
+			printUsage()
+			os.Exit(1)
+		}
+
+		batchSize := defaultBatchSize
+		if len(positional) == 1 {
+			size, err := strconv.Atoi(positional[0])
+			if err != nil || size < 1 {
+				fmt.Println("Batch size must be a positive number")
+				printUsage()
+				os.Exit(1)
+			}
+			batchSize = size
+		}
+
+		var cfg config
+		err := env.LoadEnv(&cfg)
+		if err != nil {
+			panic(errors.Wrap(err, "fail to load environment variables"))
+		}
+
+		db, err := database.Connect(cfg.DatabaseURL)
+		if err != nil {
+			panic(errors.Wrap(err, "fail to connect to the database"))
+		}
+		defer db.Close()
+
+		tables := []encryptedTable{
+			{"env_vars", []string{"value"}, cfg.EnvVarsSecret},
+			{"variable_group_variables", []string{"value"}, cfg.EnvVarsSecret},
+			{"private_registries", []string{"credentials"}, cfg.PrivRegistriesSecret},
+			{"notification_channels", []string{"url", "secret"}, cfg.NotificationsSecret},
+			{"custom_domains", []string{"tls_key"}, cfg.DomainsSecret},
+			{"service_endpoints", []string{"credential"}, cfg.EndpointsSecret},
+			{"secret_stores", []string{"credentials"}, cfg.SecretStoresSecret},
+		}
+
+		failed := false
+		legacyLeft := 0
+		for _, table := range tables {
+			if table.keyRing == "" {
+				fmt.Printf("%s: skipped, no key ring configured\n", table.name)
+				continue
+			}
+
+			result, err := rotateTable(db, table, batchSize, allowLegacy)
+			if err != nil {
+				panic(errors.Wrapf(err, "fail to rotate %s", table.name))
+			}
+
+			fmt.Printf("%s: %d rows checked, %d rotated\n", table.name, result.checked, result.rotated)
+			if result.legacyLeft > 0 {
+				legacyLeft += result.legacyLeft
+				fmt.Printf("%s: %d values are still in the legacy format\n", table.name, result.legacyLeft)
+			}
+			for _, id := range result.tampered {
+				failed = true
+				fmt.Printf("%s: row %s could not be decrypted, it was tampered with or its key is missing\n", table.name, id)
+			}
+		}
+
+		if legacyLeft == 0 {
+			fmt.Println("No values in the legacy format remain, ALLOW_LEGACY_CIPHERTEXTS can be set to false")
+		} else if !allowLegacy {
+			fmt.Printf("%d values in the legacy format remain, run again with --allow-legacy to rotate them\n", legacyLeft)
+		}
+
+		if failed {
+			os.Exit(1)
+		}
+	default:
+		fmt.Println("Invalid command")
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Printf("Usage: %s <command> [arguments]\n", os.Args[0])
+	fmt.Println("Commands:")
+	fmt.Println("  generate-key           Generate a new encryption key")
+	fmt.Println("  rotate [--allow-legacy] [batch-size]")
+	fmt.Println("                         Re-encrypt every stored secret with the first key of its key ring,")
+	fmt.Println("                         --allow-legacy also reads values encrypted before tamper detection,")
+	fmt.Println("                         once none remain the api can run with ALLOW_LEGACY_CIPHERTEXTS=false")
+}
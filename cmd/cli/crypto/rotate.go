@@ -0,0 +1,120 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/crypto"
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+type encryptedTable struct {
+	name    string
+	columns []string
+	keyRing string
+}
+
+type rotateResult struct {
+	checked  int
+	rotated  int
+	tampered []uuid.UUID
+	// legacyLeft counts values still in the legacy format after the run
+	legacyLeft int
+}
+
+// rotateTable goes through the table in batches ordered by id, each batch is
+// updated in its own transaction so that a run can be stopped and resumed.
+// Rows that fail to decrypt are reported and left untouched, legacy values
+// are only read when allowLegacy is set and are counted otherwise.
+func rotateTable(db *database.DB, table encryptedTable, batchSize int, allowLegacy bool) (*rotateResult, error) {
+	result := &rotateResult{}
+	lastID := uuid.Nil
+	for {
+		var rows []map[string]interface{}
+		err := db.Table(table.name).
+			Select(strings.Join(append([]string{"id::text AS id"}, table.columns...), ", ")).
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(batchSize).
+			Find(&rows).Error
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to fetch batch")
+		}
+
+		if len(rows) == 0 {
+			return result, nil
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, row := range rows {
+				id, err := uuid.Parse(toString(row["id"]))
+				if err != nil {
+					return errors.Wrap(err, "fail to parse row id")
+				}
+				lastID = id
+				result.checked++
+
+				updates := map[string]interface{}{}
+				legacy := 0
+				for _, column := range table.columns {
+					value := toString(row[column])
+					if value == "" {
+						continue
+					}
+
+					if crypto.IsLegacy(value) {
+						legacy++
+					}
+
+					rotated, changed, err := crypto.Rotate(table.keyRing, value, allowLegacy)
+					if errors.Is(err, crypto.ErrLegacyFormat) {
+						continue
+					}
+
+					if err != nil {
+						result.tampered = append(result.tampered, id)
+						updates = nil
+						break
+					}
+
+					if changed {
+						updates[column] = rotated
+					}
+				}
+
+				if len(updates) == 0 || !allowLegacy {
+					result.legacyLeft += legacy
+				}
+
+				if len(updates) == 0 {
+					continue
+				}
+
+				err = tx.Table(table.name).Where("id = ?", id).UpdateColumns(updates).Error
+				if err != nil {
+					return errors.Wrapf(err, "fail to update row %s", id)
+				}
+				result.rotated++
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+
+	return ""
+}
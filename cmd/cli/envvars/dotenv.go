@@ -0,0 +1,79 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/joho/godotenv"
+
+	"github.com/ergomake/ergomake/internal/envvars"
+)
+
+// inScope returns the variables whose scope is exactly scope
+func inScope(vars []envvars.EnvVar, scope envvars.Scope) []envvars.EnvVar {
+	result := []envvars.EnvVar{}
+	for _, v := range vars {
+		if v.Scope.String() == scope.String() {
+			result = append(result, v)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+type change struct {
+	op    byte
+	name  string
+	value string
+}
+
+// diff returns what must change for current to have the values of wanted,
+// variables missing from wanted are only removed when prune is set
+func diff(current []envvars.EnvVar, wanted map[string]string, prune bool) []change {
+	currentValues := make(map[string]string, len(current))
+	for _, v := range current {
+		currentValues[v.Name] = v.Value
+	}
+
+	names := make([]string, 0, len(wanted))
+	for name := range wanted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	changes := []change{}
+	for _, name := range names {
+		value, ok := currentValues[name]
+		if !ok {
+			changes = append(changes, change{'+', name, wanted[name]})
+		} else if value != wanted[name] {
+			changes = append(changes, change{'~', name, wanted[name]})
+		}
+	}
+
+	if prune {
+		for _, v := range current {
+			if _, ok := wanted[v.Name]; !ok {
+				changes = append(changes, change{'-', v.Name, ""})
+			}
+		}
+	}
+
+	return changes
+}
+
+// toDotenv formats vars as a .env file, masking values unless reveal is set
+func toDotenv(vars []envvars.EnvVar, reveal bool) (string, error) {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		if !reveal {
+			v = envvars.Mask(v)
+		}
+		values[v.Name] = v.Value
+	}
+
+	return godotenv.Marshal(values)
+}
@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"net/http"
 	"os"
 
+	"github.com/joho/godotenv"
 	"github.com/pkg/errors"
-	"k8s.io/utils/pointer"
 
 	"github.com/ergomake/ergomake/internal/database"
 	"github.com/ergomake/ergomake/internal/env"
@@ -16,92 +18,185 @@ import (
 type config struct {
 	DatabaseURL   string `split_words:"true"`
 	EnvVarsSecret string `split_words:"true"`
+	// APIURL and APIToken make the CLI go through the API instead of the database
+	APIURL   string `envconfig:"ERGOMAKE_API_URL"`
+	APIToken string `envconfig:"ERGOMAKE_API_TOKEN"`
 }
 
 func main() {
-	var cfg config
-	err := env.LoadEnv(&cfg)
-	if err != nil {
-		panic(errors.Wrap(err, "fail to load environment variables"))
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
 	}
 
-	db, err := database.Connect(cfg.DatabaseURL)
-	if err != nil {
-		panic(errors.Wrap(err, "fail to connect to the database"))
+	command := os.Args[1]
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	flags.Usage = printUsage
+	branch := flags.String("branch", "", "only the variables scoped to this branch")
+	reveal := flags.Bool("reveal", false, "print values instead of masking them")
+	dryRun := flags.Bool("dry-run", false, "print what import would change without changing it")
+	prune := flags.Bool("prune", false, "make import delete variables missing from the file")
+	_ = flags.Parse(os.Args[2:])
+	args := flags.Args()
+
+	var scope envvars.Scope
+	if *branch != "" {
+		scope.Branch = branch
 	}
-	defer db.Close()
 
-	envVarProvider := envvars.NewDBEnvVarProvider(db, cfg.EnvVarsSecret)
+	nArgs := map[string]int{"list": 2, "get": 3, "set": 4, "upsert": 4, "delete": 3, "import": 3, "export": 2}
+	expected, ok := nArgs[command]
+	if !ok {
+		fmt.Println("Invalid command")
+		printUsage()
+		os.Exit(1)
+	}
 
-	if len(os.Args) < 2 {
+	if len(args) != expected {
+		fmt.Printf("Invalid number of arguments for '%s' command\n", command)
 		printUsage()
 		os.Exit(1)
 	}
 
-	command := os.Args[1]
-	args := os.Args[2:]
+	owner := args[0]
+	repo := args[1]
+
+	var cfg config
+	err := env.LoadEnv(&cfg)
+	if err != nil {
+		panic(errors.Wrap(err, "fail to load environment variables"))
+	}
+
+	var s store
+	if cfg.APIURL != "" {
+		s = newAPIStore(http.DefaultClient, cfg.APIURL, cfg.APIToken)
+	} else {
+		db, err := database.Connect(cfg.DatabaseURL)
+		if err != nil {
+			panic(errors.Wrap(err, "fail to connect to the database"))
+		}
+		defer db.Close()
+
+		s = envvars.NewDBEnvVarProvider(db, cfg.EnvVarsSecret)
+	}
+
+	ctx := context.Background()
 
 	switch command {
-	case "upsert":
-		if len(args) != 4 {
-			fmt.Println("Invalid number of arguments for 'upsert' command")
-			printUsage()
-			os.Exit(1)
+	case "list":
+		vars, err := s.ListByRepo(ctx, owner, repo)
+		if err != nil {
+			panic(errors.Wrap(err, "fail to list environment variables"))
 		}
-		owner := args[0]
-		repo := args[1]
-		name := args[2]
-		value := args[3]
-		var branch *string
-		if len(args) >= 4 {
-			branch = pointer.String(args[4])
+
+		fmt.Println("Environment variables:")
+		for _, v := range vars {
+			if scope.Branch != nil && (v.Branch == nil || *v.Branch != *scope.Branch) {
+				continue
+			}
+
+			if !*reveal {
+				v = envvars.Mask(v)
+			}
+			fmt.Printf("%s: %s\n", v.Key(), v.Value)
+		}
+	case "get":
+		vars, err := s.ListByRepo(ctx, owner, repo)
+		if err != nil {
+			panic(errors.Wrap(err, "fail to list environment variables"))
+		}
+
+		for _, v := range inScope(vars, scope) {
+			if v.Name != args[2] {
+				continue
+			}
+
+			if !*reveal {
+				v = envvars.Mask(v)
+			}
+			fmt.Println(v.Value)
+			return
 		}
-		err := envVarProvider.Upsert(context.Background(), owner, repo, name, value, branch)
+
+		fmt.Println("Environment variable not found")
+		os.Exit(1)
+	case "set", "upsert":
+		err := s.Upsert(ctx, owner, repo, args[2], args[3], scope)
 		if err != nil {
 			panic(errors.Wrap(err, "fail to upsert environment variable"))
 		}
 		fmt.Println("Environment variable upserted successfully")
 	case "delete":
-		if len(args) != 3 {
-			fmt.Println("Invalid number of arguments for 'delete' command")
-			printUsage()
-			os.Exit(1)
-		}
-		owner := args[0]
-		repo := args[1]
-		name := args[2]
-		err := envVarProvider.Delete(context.Background(), owner, repo, name, nil)
+		err := s.Delete(ctx, owner, repo, args[2], scope)
 		if err != nil {
 			panic(errors.Wrap(err, "fail to delete environment variable"))
 		}
 		fmt.Println("Environment variable deleted successfully")
-	case "list":
-		if len(args) != 2 {
-			fmt.Println("Invalid number of arguments for 'list' command")
-			printUsage()
-			os.Exit(1)
+	case "import":
+		var wanted map[string]string
+		if args[2] == "-" {
+			wanted, err = godotenv.Parse(os.Stdin)
+		} else {
+			wanted, err = godotenv.Read(args[2])
+		}
+		if err != nil {
+			panic(errors.Wrapf(err, "fail to read %s", args[2]))
+		}
+
+		vars, err := s.ListByRepo(ctx, owner, repo)
+		if err != nil {
+			panic(errors.Wrap(err, "fail to list environment variables"))
+		}
+
+		changes := diff(inScope(vars, scope), wanted, *prune)
+		for _, c := range changes {
+			fmt.Printf("%c %s%s\n", c.op, c.name, scope)
+		}
+
+		if *dryRun {
+			fmt.Printf("Dry run, %d changes were not applied\n", len(changes))
+			return
+		}
+
+		for _, c := range changes {
+			if c.op == '-' {
+				err = s.Delete(ctx, owner, repo, c.name, scope)
+			} else {
+				err = s.Upsert(ctx, owner, repo, c.name, c.value, scope)
+			}
+			if err != nil {
+				panic(errors.Wrapf(err, "fail to import environment variable %s", c.name))
+			}
 		}
-		owner := args[0]
-		repo := args[1]
-		vars, err := envVarProvider.ListByRepo(context.Background(), owner, repo)
+		fmt.Printf("%d changes applied successfully\n", len(changes))
+	case "export":
+		vars, err := s.ListByRepo(ctx, owner, repo)
 		if err != nil {
 			panic(errors.Wrap(err, "fail to list environment variables"))
 		}
-		fmt.Println("Environment variables:")
-		for _, v := range vars {
-			fmt.Printf("%s: %s\n", v.Name, v.Value)
+
+		content, err := toDotenv(inScope(vars, scope), *reveal)
+		if err != nil {
+			panic(errors.Wrap(err, "fail to format environment variables"))
 		}
-	default:
-		fmt.Println("Invalid command")
-		printUsage()
-		os.Exit(1)
+		fmt.Println(content)
 	}
 }
 
 func printUsage() {
-	fmt.Printf("Usage: %s <command> [arguments]\n", os.Args[0])
+	fmt.Printf("Usage: %s <command> [flags] <arguments>\n", os.Args[0])
 	fmt.Println("Commands:")
-	fmt.Println("  upsert <owner> <repo> <name> <value>   Upsert an environment variable")
-	fmt.Println("  delete <owner> <repo> <name>           Delete an environment variable")
-	fmt.Println("  list <owner> <repo>                    List environment variables by repository")
+	fmt.Println("  list <owner> <repo>                  List environment variables by repository")
+	fmt.Println("  get <owner> <repo> <name>            Print the value of an environment variable")
+	fmt.Println("  set <owner> <repo> <name> <value>    Upsert an environment variable")
+	fmt.Println("  delete <owner> <repo> <name>         Delete an environment variable")
+	fmt.Println("  import <owner> <repo> <file>         Upsert the environment variables of a .env file, - reads stdin")
+	fmt.Println("  export <owner> <repo>                Print environment variables as a .env file")
+	fmt.Println("Flags:")
+	fmt.Println("  --branch <branch>    Only the variables scoped to branch, every command but list uses unscoped variables otherwise")
+	fmt.Println("  --reveal             Print values instead of masking them")
+	fmt.Println("  --dry-run            Print what import would change without changing it")
+	fmt.Println("  --prune              Make import delete the variables missing from the file")
+	fmt.Println("Set ERGOMAKE_API_URL and ERGOMAKE_API_TOKEN to go through the API instead of the database.")
 }
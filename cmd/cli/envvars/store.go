@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/envvars"
+)
+
+// store is where the variables of repos are kept, either the database or the API
+type store interface {
+	ListByRepo(ctx context.Context, owner, repo string) ([]envvars.EnvVar, error)
+	Upsert(ctx context.Context, owner, repo, name, value string, scope envvars.Scope) error
+	Delete(ctx context.Context, owner, repo, name string, scope envvars.Scope) error
+}
+
+// apiStore goes through the variables endpoints of the API
+type apiStore struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+}
+
+func newAPIStore(httpClient *http.Client, baseURL, token string) *apiStore {
+	return &apiStore{httpClient, strings.TrimRight(baseURL, "/"), token}
+}
+
+func (s *apiStore) ListByRepo(ctx context.Context, owner, repo string) ([]envvars.EnvVar, error) {
+	var vars []envvars.EnvVar
+	err := s.do(ctx, http.MethodGet, s.variablesURL(owner, repo), nil, &vars)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list variables of %s/%s", owner, repo)
+	}
+
+	return vars, nil
+}
+
+func (s *apiStore) Upsert(ctx context.Context, owner, repo, name, value string, scope envvars.Scope) error {
+	body := map[string][]envvars.EnvVar{"upsert": {{Name: name, Value: value, Scope: scope}}}
+	err := s.do(ctx, http.MethodPatch, s.variablesURL(owner, repo), body, nil)
+	return errors.Wrapf(err, "fail to upsert variable %s%s of %s/%s", name, scope, owner, repo)
+}
+
+func (s *apiStore) Delete(ctx context.Context, owner, repo, name string, scope envvars.Scope) error {
+	body := map[string][]envvars.EnvVar{"remove": {{Name: name, Scope: scope}}}
+	err := s.do(ctx, http.MethodPatch, s.variablesURL(owner, repo), body, nil)
+	return errors.Wrapf(err, "fail to delete variable %s%s of %s/%s", name, scope, owner, repo)
+}
+
+func (s *apiStore) variablesURL(owner, repo string) string {
+	return fmt.Sprintf("%s/v2/owner/%s/repos/%s/variables", s.baseURL, url.PathEscape(owner), url.PathEscape(repo))
+}
+
+func (s *apiStore) do(ctx context.Context, method, url string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "fail to marshal request body")
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return errors.Wrap(err, "fail to create request")
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "fail to %s %s", method, url)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(err, "fail to read response body")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("%s %s responded %d: %s", method, url, res.StatusCode, strings.TrimSpace(string(resBody)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return errors.Wrap(json.Unmarshal(resBody, out), "fail to unmarshal response body")
+}
@@ -9,22 +9,35 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 
 	"github.com/ergomake/ergomake/internal/api"
+	"github.com/ergomake/ergomake/internal/apitokens"
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/buildpack"
 	"github.com/ergomake/ergomake/internal/cluster"
+	"github.com/ergomake/ergomake/internal/crypto"
 	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/domains"
 	"github.com/ergomake/ergomake/internal/elastic"
+	"github.com/ergomake/ergomake/internal/endpoints"
 	"github.com/ergomake/ergomake/internal/env"
 	"github.com/ergomake/ergomake/internal/environments"
 	"github.com/ergomake/ergomake/internal/envvars"
+	"github.com/ergomake/ergomake/internal/forkpolicy"
 	"github.com/ergomake/ergomake/internal/github/ghapp"
 	"github.com/ergomake/ergomake/internal/github/ghlauncher"
 	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/notifications"
 	"github.com/ergomake/ergomake/internal/payment"
 	"github.com/ergomake/ergomake/internal/permanentbranches"
+	"github.com/ergomake/ergomake/internal/prcomments"
+	"github.com/ergomake/ergomake/internal/previewaccess"
 	"github.com/ergomake/ergomake/internal/privregistry"
+	"github.com/ergomake/ergomake/internal/publicnet"
+	"github.com/ergomake/ergomake/internal/rbac"
+	"github.com/ergomake/ergomake/internal/secretstores"
 	"github.com/ergomake/ergomake/internal/servicelogs"
 	"github.com/ergomake/ergomake/internal/stale"
 	"github.com/ergomake/ergomake/internal/users"
+	"github.com/ergomake/ergomake/internal/variablegroups"
 	"github.com/ergomake/ergomake/internal/watcher"
 
 	kpackBuild "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -41,6 +54,11 @@ func main() {
 
 	log := logger.Setup()
 
+	crypto.SetAllowLegacy(cfg.AllowLegacyCiphertexts)
+	if cfg.AllowLegacyCiphertexts {
+		log.Warn().Msg("legacy ciphertexts are allowed, rotate them and set ALLOW_LEGACY_CIPHERTEXTS=false")
+	}
+
 	db, err := database.Connect(cfg.DatabaseURL)
 	if err != nil {
 		log.Fatal().AnErr("err", err).Msg("fail to connect to database")
@@ -63,23 +81,50 @@ func main() {
 
 	logStreamer := servicelogs.NewESLogStreamer(es, time.Second*5)
 
-	envVarsProvider := envvars.NewDBEnvVarProvider(db, cfg.EnvVarsSecret)
+	secretStoresProvider := secretstores.NewDBConfigProvider(db, cfg.SecretStoresSecret)
+	variableGroupsProvider := variablegroups.NewDBGroupsProvider(db, cfg.EnvVarsSecret)
+	envVarsProvider := envvars.NewExternalEnvVarsProvider(
+		envvars.NewGroupsEnvVarsProvider(envvars.NewDBEnvVarProvider(db, cfg.EnvVarsSecret), variableGroupsProvider),
+		secretStoresProvider,
+		secretstores.NewStoreFactory(publicnet.NewClient()),
+	)
 
 	paymentProvider := payment.NewStripePaymentProvider(db, cfg.StripeSecretKey, cfg.StripeStandardPlanProductID,
 		cfg.StripeProfessionalPlanProductID, cfg.Friends, cfg.BestFriends)
 
 	permanentBranchesProvider := permanentbranches.NewDBEnvironmentsProvider(db)
 
+	notificationChannelsProvider := notifications.NewDBChannelsProvider(db, cfg.NotificationsSecret)
+	notifier := notifications.NewNotifier(notificationChannelsProvider)
+	auditProvider := audit.NewDBAuditProvider(db)
+
 	environmentsProvider := environments.NewDBEnvironmentsProvider(
 		db,
 		paymentProvider,
 		cfg.EnvironmentsLimit,
 		permanentBranchesProvider,
 		clusterClient,
+		ghApp,
+		notifier,
+		auditProvider,
 	)
 
 	usersService := users.NewDBUsersService(db)
 	privRegistryProvider := privregistry.NewDBPrivRegistryProvider(db, cfg.PrivRegistriesSecret)
+	commentSettingsProvider := prcomments.NewDBSettingsProvider(db)
+	forkPolicyProvider := forkpolicy.NewDBPolicyProvider(db)
+	apiTokensProvider := apitokens.NewDBTokensProvider(db)
+	rbacProvider := rbac.NewDBRBACProvider(db)
+	previewAccessProvider := previewaccess.NewDBAccessProvider(db)
+	domainsProvider := domains.NewDBDomainsProvider(db, cfg.DomainsSecret)
+	endpointsProvider := endpoints.NewDBEndpointsProvider(
+		db,
+		cfg.EndpointsSecret,
+		cfg.StreamProxyHost,
+		cfg.StreamProxyPortStart,
+		cfg.StreamProxyPortEnd,
+	)
+	endpointsProxy := endpoints.NewIngressNginxProxy(clusterClient, cfg.IngressNamespace)
 
 	ghLauncher := ghlauncher.NewGHLauncher(
 		db,
@@ -87,7 +132,15 @@ func main() {
 		clusterClient,
 		envVarsProvider,
 		privRegistryProvider,
+		previewAccessProvider,
+		domainsProvider,
+		endpointsProvider,
+		endpointsProxy,
 		environmentsProvider,
+		commentSettingsProvider,
+		forkPolicyProvider,
+		notifier,
+		auditProvider,
 		cfg.DockerhubPullSecretName,
 		cfg.FrontendURL,
 	)
@@ -109,6 +162,17 @@ func main() {
 			usersService,
 			paymentProvider,
 			permanentBranchesProvider,
+			commentSettingsProvider,
+			notificationChannelsProvider,
+			apiTokensProvider,
+			rbacProvider,
+			auditProvider,
+			previewAccessProvider,
+			domainsProvider,
+			endpointsProvider,
+			secretStoresProvider,
+			variableGroupsProvider,
+			forkPolicyProvider,
 			&cfg,
 		)
 		api.Listen(":8080")
@@ -121,6 +185,8 @@ func main() {
 		stale := stale.NewServer(
 			clusterClient,
 			environmentsProvider,
+			ghApp,
+			notifier,
 			paymentProvider,
 			cfg.FrontendURL,
 			time.Hour,
@@ -152,7 +218,7 @@ func main() {
 	stopWatcher := watcher.WatchEnvironments(context.Background(), db, environmentsProvider, ghApp, ghLauncher)
 	defer stopWatcher()
 
-	clean, err := buildpack.WatchBuilds(clusterClient, db, ghApp, cfg.FrontendURL)
+	clean, err := buildpack.WatchBuilds(clusterClient, db, ghApp, commentSettingsProvider, notifier, cfg.FrontendURL)
 	if err != nil {
 		log.Fatal().AnErr("err", err).Msg("fail to watch builds")
 	}
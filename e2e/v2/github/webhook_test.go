@@ -24,14 +24,25 @@ import (
 	"github.com/ergomake/ergomake/internal/cluster"
 	"github.com/ergomake/ergomake/internal/database"
 	"github.com/ergomake/ergomake/internal/github/ghapp"
+	apitokensMocks "github.com/ergomake/ergomake/mocks/apitokens"
+	auditMocks "github.com/ergomake/ergomake/mocks/audit"
+	domainsMocks "github.com/ergomake/ergomake/mocks/domains"
+	endpointsMocks "github.com/ergomake/ergomake/mocks/endpoints"
 	environmentsMocks "github.com/ergomake/ergomake/mocks/environments"
 	envvarsMocks "github.com/ergomake/ergomake/mocks/envvars"
+	forkpolicyMocks "github.com/ergomake/ergomake/mocks/forkpolicy"
 	ghlauncherMocks "github.com/ergomake/ergomake/mocks/github/ghlauncher"
+	notificationsMocks "github.com/ergomake/ergomake/mocks/notifications"
 	paymentMocks "github.com/ergomake/ergomake/mocks/payment"
 	permanentbranchesMocks "github.com/ergomake/ergomake/mocks/permanentbranches"
+	prcommentsMocks "github.com/ergomake/ergomake/mocks/prcomments"
+	previewaccessMocks "github.com/ergomake/ergomake/mocks/previewaccess"
 	privregistryMocks "github.com/ergomake/ergomake/mocks/privregistry"
+	rbacMocks "github.com/ergomake/ergomake/mocks/rbac"
+	secretstoresMocks "github.com/ergomake/ergomake/mocks/secretstores"
 	servicelogsMocks "github.com/ergomake/ergomake/mocks/servicelogs"
 	usersMocks "github.com/ergomake/ergomake/mocks/users"
+	variablegroupsMocks "github.com/ergomake/ergomake/mocks/variablegroups"
 )
 
 func findFileUpwards(fileName string) (string, error) {
@@ -558,6 +569,17 @@ func TestV2GithubWebhook(t *testing.T) {
 				usersMocks.NewService(t),
 				paymentMocks.NewPaymentProvider(t),
 				permanentbranchesMocks.NewPermanentBranchesProvider(t),
+				prcommentsMocks.NewSettingsProvider(t),
+				notificationsMocks.NewChannelsProvider(t),
+				apitokensMocks.NewTokensProvider(t),
+				rbacMocks.NewRBACProvider(t),
+				auditMocks.NewAuditProvider(t),
+				previewaccessMocks.NewAccessProvider(t),
+				domainsMocks.NewDomainsProvider(t),
+				endpointsMocks.NewEndpointsProvider(t),
+				secretstoresMocks.NewConfigProvider(t),
+				variablegroupsMocks.NewGroupsProvider(t),
+				forkpolicyMocks.NewPolicyProvider(t),
 				&cfg,
 			)
 
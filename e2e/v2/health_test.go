@@ -11,15 +11,26 @@ import (
 	"github.com/ergomake/ergomake/e2e/testutils"
 	"github.com/ergomake/ergomake/internal/api"
 	"github.com/ergomake/ergomake/internal/cluster"
+	apitokensMocks "github.com/ergomake/ergomake/mocks/apitokens"
+	auditMocks "github.com/ergomake/ergomake/mocks/audit"
+	domainsMocks "github.com/ergomake/ergomake/mocks/domains"
+	endpointsMocks "github.com/ergomake/ergomake/mocks/endpoints"
 	environmentsMocks "github.com/ergomake/ergomake/mocks/environments"
 	envvarsMocks "github.com/ergomake/ergomake/mocks/envvars"
+	forkpolicyMocks "github.com/ergomake/ergomake/mocks/forkpolicy"
 	ghAppMocks "github.com/ergomake/ergomake/mocks/github/ghapp"
 	ghlauncherMocks "github.com/ergomake/ergomake/mocks/github/ghlauncher"
+	notificationsMocks "github.com/ergomake/ergomake/mocks/notifications"
 	paymentMocks "github.com/ergomake/ergomake/mocks/payment"
 	permanentbranchesMocks "github.com/ergomake/ergomake/mocks/permanentbranches"
+	prcommentsMocks "github.com/ergomake/ergomake/mocks/prcomments"
+	previewaccessMocks "github.com/ergomake/ergomake/mocks/previewaccess"
 	privregistryMocks "github.com/ergomake/ergomake/mocks/privregistry"
+	rbacMocks "github.com/ergomake/ergomake/mocks/rbac"
+	secretstoresMocks "github.com/ergomake/ergomake/mocks/secretstores"
 	servicelogsMocks "github.com/ergomake/ergomake/mocks/servicelogs"
 	usersMocks "github.com/ergomake/ergomake/mocks/users"
+	variablegroupsMocks "github.com/ergomake/ergomake/mocks/variablegroups"
 )
 
 func TestV2Health(t *testing.T) {
@@ -53,6 +64,17 @@ func TestV2Health(t *testing.T) {
 				usersMocks.NewService(t),
 				paymentMocks.NewPaymentProvider(t),
 				permanentbranchesMocks.NewPermanentBranchesProvider(t),
+				prcommentsMocks.NewSettingsProvider(t),
+				notificationsMocks.NewChannelsProvider(t),
+				apitokensMocks.NewTokensProvider(t),
+				rbacMocks.NewRBACProvider(t),
+				auditMocks.NewAuditProvider(t),
+				previewaccessMocks.NewAccessProvider(t),
+				domainsMocks.NewDomainsProvider(t),
+				endpointsMocks.NewEndpointsProvider(t),
+				secretstoresMocks.NewConfigProvider(t),
+				variablegroupsMocks.NewGroupsProvider(t),
+				forkpolicyMocks.NewPolicyProvider(t),
 				&api.Config{},
 			)
 			server := httptest.NewServer(apiServer)
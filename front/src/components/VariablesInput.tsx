@@ -19,7 +19,7 @@ interface Props {
 }
 function VariablesInput(props: Props) {
   const repo = useRepo(props.owner, props.repo)
-  const [res, onUpdate] = useVariables(props.owner, props.repo)
+  const [res, onUpdate, conflict] = useVariables(props.owner, props.repo)
 
   const [variables, setVariables] = useState<Variable[]>([])
   useEffect(() => {
@@ -305,7 +305,14 @@ function VariablesInput(props: Props) {
 
       <div className="h-full overflow-y-auto">{body}</div>
       <div className="flex bg-gray-200 dark:bg-neutral-800 dark:text-neutral-300 items-center justify-between py-4 px-4 sm:px-6 lg:px-8">
-        <span>Save to apply environment variables.</span>
+        {conflict ? (
+          <span className="text-red-600 dark:text-red-400">
+            Someone else changed these variables, they were reloaded. Apply
+            your changes again and save.
+          </span>
+        ) : (
+          <span>Save to apply environment variables.</span>
+        )}
         <Button loading={loading} disabled={loading} onClick={onSave}>
           Save
         </Button>
@@ -10,6 +10,7 @@ export type EnvironmentStatus =
   | 'degraded'
   | 'limited'
   | 'stale'
+  | 'awaiting-approval'
 
 export type EnvironmentService = {
   id: string
@@ -39,7 +40,7 @@ export type Environment = {
 }
 
 export const hasLogs = (env: Environment): boolean => {
-  if (env.status === 'limited') {
+  if (env.status === 'limited' || env.status === 'awaiting-approval') {
     return false
   }
 
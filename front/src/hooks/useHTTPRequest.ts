@@ -2,6 +2,7 @@ import { useCallback, useEffect, useMemo, useState } from 'react'
 
 export type HTTPError =
   | { _tag: 'authentication' }
+  | { _tag: 'precondition-failed' }
   | { _tag: 'unexpected'; err: Error }
 
 export type HTTPResponseLoading = { _tag: 'loading' }
@@ -9,6 +10,7 @@ export type HTTPResponseSuccess<T> = {
   _tag: 'success'
   body: T
   refreshing: boolean
+  etag?: string
 }
 export type HTTPResponseError = { _tag: 'error'; err: HTTPError }
 export type HTTPResponse<T> =
@@ -126,7 +128,12 @@ export const useOptionalHTTPRequest = <T>(
           return
         }
 
-        setState({ _tag: 'success', body, refreshing: false })
+        setState({
+          _tag: 'success',
+          body,
+          refreshing: false,
+          etag: res.headers.get('ETag') ?? undefined,
+        })
       })
       .catch((err) => {
         if (abortController.signal.aborted) {
@@ -170,14 +177,14 @@ export const useHTTPRequest = <T>(url: string): UseHTTPRequest<T> => {
 
   return useMemo(
     () => [
-      andThen(res, ({ body }) => {
+      andThen(res, ({ body, etag }) => {
         if (body === null) {
           const err = new Error('Not Found')
           err.name = '404'
           return { _tag: 'error', err: { _tag: 'unexpected', err } }
         }
 
-        return { _tag: 'success', body, refreshing: false }
+        return { _tag: 'success', body, refreshing: false, etag }
       }),
       refetch,
     ],
@@ -188,7 +195,7 @@ export const useHTTPRequest = <T>(url: string): UseHTTPRequest<T> => {
 export type HTTPMutationResponse<T> = HTTPResponse<T> | { _tag: 'pristine' }
 export type UseHTTPMutation<P, R = P> = [
   HTTPMutationResponse<R>,
-  (data: P) => void
+  (data: P, headers?: Record<string, string>) => void
 ]
 export const useHTTPMutation = <P, R = P>(
   url: string
@@ -198,7 +205,7 @@ export const useHTTPMutation = <P, R = P>(
   })
 
   const makeRequest = useCallback(
-    (data: P) => {
+    (data: P, headers: Record<string, string> = {}) => {
       setState((s) =>
         s._tag === 'success' ? { ...s, refreshing: true } : { _tag: 'loading' }
       )
@@ -206,6 +213,7 @@ export const useHTTPMutation = <P, R = P>(
       fetch(url, {
         method: 'POST',
         headers: {
+          ...headers,
           'Content-Type': 'application/json',
         },
         body: JSON.stringify(data),
@@ -218,12 +226,22 @@ export const useHTTPMutation = <P, R = P>(
             return
           }
 
+          if (res.status === 412) {
+            setState({ _tag: 'error', err: { _tag: 'precondition-failed' } })
+            return
+          }
+
           if (!res.ok) {
             throw new Error(res.statusText)
           }
 
           const response: R = await res.json()
-          setState({ _tag: 'success', body: response, refreshing: false })
+          setState({
+            _tag: 'success',
+            body: response,
+            refreshing: false,
+            etag: res.headers.get('ETag') ?? undefined,
+          })
         })
         .catch((err) => {
           setState({ _tag: 'error', err: { _tag: 'unexpected', err } })
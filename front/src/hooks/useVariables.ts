@@ -1,9 +1,11 @@
-import { useMemo } from 'react'
+import { useCallback, useEffect, useMemo } from 'react'
 
 import {
   HTTPResponse,
   andThen,
+  isError,
   isLoading,
+  isSuccess,
   useHTTPMutation,
   useHTTPRequest,
 } from './useHTTPRequest'
@@ -14,23 +16,52 @@ export type Variable = {
   branch: string | null
 }
 
-type UseVariables = [HTTPResponse<Variable[]>, (variables: Variable[]) => void]
+// the last element tells whether the variables were changed by someone else
+// since they were loaded, in which case saving was refused and they got reloaded
+type UseVariables = [
+  HTTPResponse<Variable[]>,
+  (variables: Variable[]) => void,
+  boolean
+]
 
 export const useVariables = (owner: string, repo: string): UseVariables => {
   const url = `${process.env.REACT_APP_ERGOMAKE_API}/v2/owner/${owner}/repos/${repo}/variables`
 
-  const [initial] = useHTTPRequest<Variable[]>(url)
-  const [vars, update] = useHTTPMutation<Variable[]>(url)
+  const [initial, refetch] = useHTTPRequest<Variable[]>(url)
+  const [vars, mutate] = useHTTPMutation<Variable[]>(url)
+
+  const conflict = isError(vars) && vars.err._tag === 'precondition-failed'
+  useEffect(() => {
+    if (conflict) {
+      refetch()
+    }
+  }, [conflict, refetch])
+
+  // saving sends the version the variables were read at, so that changes made
+  // in between are not overwritten
+  const etag = isSuccess(vars)
+    ? vars.etag
+    : isSuccess(initial)
+    ? initial.etag
+    : undefined
+  const update = useCallback(
+    (variables: Variable[]) => mutate(variables, { 'If-Match': etag ?? '' }),
+    [mutate, etag]
+  )
 
   return useMemo((): UseVariables => {
-    if (vars._tag === 'pristine') {
-      return [initial, update]
+    if (vars._tag === 'pristine' || conflict) {
+      return [initial, update, conflict]
     }
 
     if (isLoading(vars)) {
-      return [andThen(initial, (i) => ({ ...i, refreshing: true })), update]
+      return [
+        andThen(initial, (i) => ({ ...i, refreshing: true })),
+        update,
+        false,
+      ]
     }
 
-    return [vars, update]
-  }, [initial, vars, update])
+    return [vars, update, false]
+  }, [initial, vars, update, conflict])
 }
@@ -26,6 +26,7 @@ export const EnvironmentStatusStyle: Record<EnvironmentStatus, string> = {
   degraded: 'text-red-400 bg-red-400/20',
   limited: 'text-yellow-400 bg-yellow-400/20',
   stale: 'text-gray-500 bg-gray-100/30',
+  'awaiting-approval': 'text-yellow-400 bg-yellow-400/20',
 }
 
 const EnvironmentStatusText: Record<EnvironmentStatus, string> = {
@@ -35,6 +36,7 @@ const EnvironmentStatusText: Record<EnvironmentStatus, string> = {
   degraded: 'Failed',
   limited: 'Above limits',
   stale: 'Sleeping',
+  'awaiting-approval': 'Awaiting approval',
 }
 
 type TabName = 'branches' | 'envVars' | 'permanentBranches'
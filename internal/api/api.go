@@ -1,29 +1,52 @@
 package api
 
 import (
+	"net"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	apitokensApi "github.com/ergomake/ergomake/internal/api/apitokens"
+	auditApi "github.com/ergomake/ergomake/internal/api/audit"
 	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/api/comments"
+	domainsApi "github.com/ergomake/ergomake/internal/api/domains"
+	endpointsApi "github.com/ergomake/ergomake/internal/api/endpoints"
 	environmentsApi "github.com/ergomake/ergomake/internal/api/environments"
+	forkpolicyApi "github.com/ergomake/ergomake/internal/api/forkpolicy"
 	"github.com/ergomake/ergomake/internal/api/github"
+	notificationsApi "github.com/ergomake/ergomake/internal/api/notifications"
 	permanentbranchesApi "github.com/ergomake/ergomake/internal/api/permanentbranches"
+	previewaccessApi "github.com/ergomake/ergomake/internal/api/previewaccess"
 	"github.com/ergomake/ergomake/internal/api/registries"
+	"github.com/ergomake/ergomake/internal/api/roles"
+	secretstoresApi "github.com/ergomake/ergomake/internal/api/secretstores"
 	"github.com/ergomake/ergomake/internal/api/stripe"
+	variablegroupsApi "github.com/ergomake/ergomake/internal/api/variablegroups"
 	"github.com/ergomake/ergomake/internal/api/variables"
+	"github.com/ergomake/ergomake/internal/apitokens"
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/cluster"
 	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/domains"
+	"github.com/ergomake/ergomake/internal/endpoints"
 	"github.com/ergomake/ergomake/internal/environments"
 	"github.com/ergomake/ergomake/internal/envvars"
+	"github.com/ergomake/ergomake/internal/forkpolicy"
 	"github.com/ergomake/ergomake/internal/github/ghapp"
 	"github.com/ergomake/ergomake/internal/github/ghlauncher"
 	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/notifications"
 	"github.com/ergomake/ergomake/internal/payment"
 	"github.com/ergomake/ergomake/internal/permanentbranches"
+	"github.com/ergomake/ergomake/internal/prcomments"
+	"github.com/ergomake/ergomake/internal/previewaccess"
 	"github.com/ergomake/ergomake/internal/privregistry"
+	"github.com/ergomake/ergomake/internal/rbac"
+	"github.com/ergomake/ergomake/internal/secretstores"
 	"github.com/ergomake/ergomake/internal/servicelogs"
 	"github.com/ergomake/ergomake/internal/users"
+	"github.com/ergomake/ergomake/internal/variablegroups"
 )
 
 type Config struct {
@@ -43,6 +66,15 @@ type Config struct {
 	FrontendURL                     string   `split_words:"true"`
 	EnvVarsSecret                   string   `split_words:"true"`
 	PrivRegistriesSecret            string   `split_words:"true"`
+	NotificationsSecret             string   `split_words:"true"`
+	PreviewAuthURL                  string   `split_words:"true"`
+	PreviewAuthSigninURL            string   `split_words:"true"`
+	DomainsSecret                   string   `split_words:"true"`
+	EndpointsSecret                 string   `split_words:"true"`
+	StreamProxyHost                 string   `split_words:"true"`
+	StreamProxyPortStart            int      `split_words:"true" default:"30000"`
+	StreamProxyPortEnd              int      `split_words:"true" default:"30999"`
+	SecretStoresSecret              string   `split_words:"true"`
 	EnvironmentsLimit               int      `split_words:"true"`
 	StripeSecretKey                 string   `split_words:"true"`
 	StripeWebhookSecret             string   `split_words:"true"`
@@ -53,6 +85,7 @@ type Config struct {
 	Friends                         []string `split_words:"true"`
 	BestFriends                     []string `split_words:"true"`
 	DockerhubPullSecretName         string   `split_words:"true"`
+	AllowLegacyCiphertexts          bool     `split_words:"true" default:"true"`
 }
 
 type server struct {
@@ -63,7 +96,8 @@ func corsMiddleware(allowOrigin string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", allowOrigin)
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Authorization, Content-Type")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Authorization, Content-Type, If-Match")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 
 		if c.Request.Method == "OPTIONS" {
@@ -87,6 +121,17 @@ func NewServer(
 	usersService users.Service,
 	paymentProvider payment.PaymentProvider,
 	permanentBranchesProvider permanentbranches.PermanentBranchesProvider,
+	commentSettingsProvider prcomments.SettingsProvider,
+	notificationChannelsProvider notifications.ChannelsProvider,
+	apiTokensProvider apitokens.TokensProvider,
+	rbacProvider rbac.RBACProvider,
+	auditProvider audit.AuditProvider,
+	previewAccessProvider previewaccess.AccessProvider,
+	domainsProvider domains.DomainsProvider,
+	endpointsProvider endpoints.EndpointsProvider,
+	secretStoresProvider secretstores.ConfigProvider,
+	variableGroupsProvider variablegroups.GroupsProvider,
+	forkPolicyProvider forkpolicy.PolicyProvider,
 	cfg *Config,
 ) *server {
 	router := gin.New()
@@ -95,7 +140,9 @@ func NewServer(
 
 	router.Use(gin.Recovery())
 	logger.Middleware(router)
-	router.Use(auth.ExtractAuthDataMiddleware(cfg.JWTSecret))
+	router.Use(auth.ExtractAuthDataMiddleware(cfg.JWTSecret, apiTokensProvider))
+
+	authorizer := rbac.NewAuthorizer(rbacProvider, ghApp)
 
 	v2 := router.Group("/v2")
 	v2.GET("/health", func(c *gin.Context) {
@@ -111,6 +158,9 @@ func NewServer(
 		privRegistryProvider,
 		environmentsProvider,
 		paymentProvider,
+		forkPolicyProvider,
+		auditProvider,
+		authorizer,
 		cfg.GithubWebhookSecret,
 		cfg.FrontendURL,
 		cfg.DockerhubPullSecretName,
@@ -135,13 +185,21 @@ func NewServer(
 	)
 	authRouter.AddRoutes(v2.Group("/auth"))
 
-	registriesRouter := registries.NewRegistriesRouter(privRegistryProvider)
+	registriesRouter := registries.NewRegistriesRouter(privRegistryProvider, authorizer, auditProvider)
 	registriesRouter.AddRoutes(v2)
 
-	environmentsRouter := environmentsApi.NewEnvironmentsRouter(db, logStreamer, clusterClient, cfg.JWTSecret)
+	environmentsRouter := environmentsApi.NewEnvironmentsRouter(
+		db,
+		logStreamer,
+		clusterClient,
+		envVarsProvider,
+		environmentsProvider,
+		authorizer,
+		cfg.JWTSecret,
+	)
 	environmentsRouter.AddRoutes(v2.Group("/environments"))
 
-	variablesRouter := variables.NewVariablesRouter(envVarsProvider)
+	variablesRouter := variables.NewVariablesRouter(envVarsProvider, variableGroupsProvider, authorizer, auditProvider)
 	variablesRouter.AddRoutes(v2)
 
 	permanentbranchesRouter := permanentbranchesApi.NewPermanentBranchesRouter(
@@ -149,9 +207,61 @@ func NewServer(
 		ghLauncher,
 		permanentBranchesProvider,
 		environmentsProvider,
+		authorizer,
+		auditProvider,
 	)
 	permanentbranchesRouter.AddRoutes(v2)
 
+	commentsRouter := comments.NewCommentsRouter(commentSettingsProvider, authorizer)
+	commentsRouter.AddRoutes(v2)
+
+	forkPolicyRouter := forkpolicyApi.NewForkPolicyRouter(forkPolicyProvider, authorizer, auditProvider)
+	forkPolicyRouter.AddRoutes(v2)
+
+	notificationsRouter := notificationsApi.NewNotificationsRouter(notificationChannelsProvider, authorizer)
+	notificationsRouter.AddRoutes(v2)
+
+	apiTokensRouter := apitokensApi.NewAPITokensRouter(apiTokensProvider, authorizer, auditProvider)
+	apiTokensRouter.AddRoutes(v2)
+
+	rolesRouter := roles.NewRolesRouter(rbacProvider, authorizer, auditProvider)
+	rolesRouter.AddRoutes(v2)
+
+	auditRouter := auditApi.NewAuditRouter(auditProvider, authorizer)
+	auditRouter.AddRoutes(v2)
+
+	previewAccessRouter := previewaccessApi.NewPreviewAccessRouter(
+		db,
+		previewAccessProvider,
+		environmentsProvider,
+		clusterClient,
+		authorizer,
+		auditProvider,
+		cfg.JWTSecret,
+		cfg.PreviewAuthURL,
+		cfg.PreviewAuthSigninURL,
+	)
+	previewAccessRouter.AddRoutes(v2)
+
+	domainsRouter := domainsApi.NewDomainsRouter(domainsProvider, authorizer, auditProvider, net.DefaultResolver)
+	domainsRouter.AddRoutes(v2)
+
+	endpointsRouter := endpointsApi.NewEndpointsRouter(db, endpointsProvider, authorizer)
+	endpointsRouter.AddRoutes(v2)
+
+	secretStoresRouter := secretstoresApi.NewSecretStoresRouter(secretStoresProvider, authorizer, auditProvider)
+	secretStoresRouter.AddRoutes(v2)
+
+	variableGroupsRouter := variablegroupsApi.NewVariableGroupsRouter(
+		variableGroupsProvider,
+		permanentBranchesProvider,
+		ghApp,
+		ghLauncher,
+		authorizer,
+		auditProvider,
+	)
+	variableGroupsRouter.AddRoutes(v2)
+
 	return &server{router}
 }
 
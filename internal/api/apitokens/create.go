@@ -0,0 +1,76 @@
+package apitokens
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/apitokens"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/github/ghoauth"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+type createTokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type createTokenResponse struct {
+	*apitokens.Token
+	// Value is only returned once, when the token is created
+	Value string `json:"value"`
+}
+
+func (ar *apiTokensRouter) create(c *gin.Context) {
+	owner := c.Param("owner")
+
+	authData, ok := auth.GetAuthData(c)
+	if !ok || authData.GithubToken == nil {
+		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		return
+	}
+
+	client := ghoauth.FromToken(authData.GithubToken)
+	createdBy, err := ghoauth.GetLogin(c, client, authData.GithubToken.AccessToken)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msg("fail to get authenticated user")
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	var body createTokenRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	if body.Name == "" || len(body.Name) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-name"})
+		return
+	}
+
+	if len(body.Scopes) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-scopes"})
+		return
+	}
+
+	for _, scope := range body.Scopes {
+		if !apitokens.IsValidScope(scope) {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-scopes", "scope": scope})
+			return
+		}
+	}
+
+	token, value, err := ar.apiTokensProvider.Create(c, owner, body.Name, createdBy, body.Scopes)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to create api token for owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, ar.auditProvider, owner, "", audit.ActionAPITokenCreate, token.ID.String(), gin.H{"name": token.Name, "scopes": token.Scopes})
+
+	c.JSON(http.StatusCreated, createTokenResponse{token, value})
+}
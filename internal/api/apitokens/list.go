@@ -0,0 +1,22 @@
+package apitokens
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+func (ar *apiTokensRouter) list(c *gin.Context) {
+	owner := c.Param("owner")
+
+	tokens, err := ar.apiTokensProvider.List(c, owner)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list api tokens of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
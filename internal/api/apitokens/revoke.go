@@ -0,0 +1,40 @@
+package apitokens
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/apitokens"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+func (ar *apiTokensRouter) revoke(c *gin.Context) {
+	owner := c.Param("owner")
+
+	tokenID, err := uuid.Parse(c.Param("tokenID"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	err = ar.apiTokensProvider.Revoke(c, owner, tokenID)
+	if errors.Is(err, apitokens.ErrTokenNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to revoke api token %s", tokenID)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, ar.auditProvider, owner, "", audit.ActionAPITokenRevoke, tokenID.String(), nil)
+
+	c.Status(http.StatusNoContent)
+}
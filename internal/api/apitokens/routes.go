@@ -0,0 +1,33 @@
+package apitokens
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/apitokens"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type apiTokensRouter struct {
+	apiTokensProvider apitokens.TokensProvider
+	authorizer        rbac.Authorizer
+	auditProvider     audit.AuditProvider
+}
+
+func NewAPITokensRouter(
+	apiTokensProvider apitokens.TokensProvider,
+	authorizer rbac.Authorizer,
+	auditProvider audit.AuditProvider,
+) *apiTokensRouter {
+	return &apiTokensRouter{apiTokensProvider, authorizer, auditProvider}
+}
+
+// AddRoutes adds the routes to manage API tokens, they can't be called with an API token
+func (ar *apiTokensRouter) AddRoutes(router *gin.RouterGroup) {
+	requireAdmin := auth.RequireRole(ar.authorizer, rbac.RoleAdmin)
+
+	router.GET("/owner/:owner/api-tokens", requireAdmin, ar.list)
+	router.POST("/owner/:owner/api-tokens", requireAdmin, ar.create)
+	router.DELETE("/owner/:owner/api-tokens/:tokenID", requireAdmin, ar.revoke)
+}
@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+// export streams every entry matching the filter as newline delimited JSON,
+// going through all the pages so compliance reviews don't have to
+func (ar *auditRouter) export(c *gin.Context) {
+	owner := c.Param("owner")
+
+	filter, ok := parseFilter(c)
+	if !ok {
+		return
+	}
+
+	page, err := ar.auditProvider.List(c, owner, filter)
+	if errors.Is(err, audit.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-cursor"})
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to export audit log of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", "attachment; filename=\"audit-"+owner+".ndjson\"")
+	c.Status(http.StatusOK)
+
+	enc := json.NewEncoder(c.Writer)
+	for {
+		for _, entry := range page.Entries {
+			if err := enc.Encode(entry); err != nil {
+				logger.Ctx(c).Err(err).Msg("fail to write audit log entry to export")
+				return
+			}
+		}
+		c.Writer.Flush()
+
+		if page.NextCursor == "" {
+			return
+		}
+
+		filter.Cursor = page.NextCursor
+		page, err = ar.auditProvider.List(c, owner, filter)
+		if err != nil {
+			// headers were already sent, all we can do is stop the stream
+			logger.Ctx(c).Err(err).Msgf("fail to export audit log of owner %s", owner)
+			return
+		}
+	}
+}
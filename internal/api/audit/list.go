@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+// parseFilter reads the audit log filter from the query string, writing
+// an error response and returning false when it is malformed
+func parseFilter(c *gin.Context) (audit.Filter, bool) {
+	filter := audit.Filter{
+		Repo:   c.Query("repo"),
+		Action: c.Query("action"),
+		Actor:  c.Query("actor"),
+		Cursor: c.Query("cursor"),
+	}
+
+	for param, dst := range map[string]**time.Time{"since": &filter.Since, "until": &filter.Until} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-" + param})
+			return filter, false
+		}
+		*dst = &t
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-limit"})
+			return filter, false
+		}
+		filter.Limit = n
+	}
+
+	return filter, true
+}
+
+func (ar *auditRouter) list(c *gin.Context) {
+	owner := c.Param("owner")
+
+	filter, ok := parseFilter(c)
+	if !ok {
+		return
+	}
+
+	page, err := ar.auditProvider.List(c, owner, filter)
+	if errors.Is(err, audit.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-cursor"})
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list audit log of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
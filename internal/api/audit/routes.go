@@ -0,0 +1,25 @@
+package audit
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type auditRouter struct {
+	auditProvider audit.AuditProvider
+	authorizer    rbac.Authorizer
+}
+
+func NewAuditRouter(auditProvider audit.AuditProvider, authorizer rbac.Authorizer) *auditRouter {
+	return &auditRouter{auditProvider, authorizer}
+}
+
+func (ar *auditRouter) AddRoutes(router *gin.RouterGroup) {
+	requireAdmin := auth.RequireRole(ar.authorizer, rbac.RoleAdmin)
+
+	router.GET("/owner/:owner/audit", requireAdmin, ar.list)
+	router.GET("/owner/:owner/audit/export", requireAdmin, ar.export)
+}
@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/ergomake/ergomake/internal/github/ghoauth"
+)
+
+// GetActor returns who is making the request, to be used in audit log entries
+func GetActor(ctx context.Context, authData *AuthData) string {
+	if authData.APIToken != nil {
+		return "token:" + authData.APIToken.Name
+	}
+
+	client := ghoauth.FromToken(authData.GithubToken)
+	login, err := ghoauth.GetLogin(ctx, client, authData.GithubToken.AccessToken)
+	if err != nil {
+		return "unknown"
+	}
+
+	return login
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+// Record adds an entry of the caller doing action on target to the audit log,
+// failing to do so doesn't fail the request. repo and diff are optional.
+func Record(c *gin.Context, auditProvider audit.AuditProvider, owner, repo, action, target string, diff interface{}) {
+	authData, ok := GetAuthData(c)
+	if !ok {
+		return
+	}
+
+	entry := audit.Entry{
+		Actor:  GetActor(c, authData),
+		Owner:  owner,
+		Repo:   repo,
+		Action: action,
+		Target: target,
+	}
+	if diff != nil {
+		entry.Diff = audit.NewDiff(diff)
+	}
+
+	err := auditProvider.Record(c, entry)
+	if err != nil {
+		logger.Ctx(c).Err(err).Str("action", action).Msg("fail to record audit log entry")
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 
+	"github.com/ergomake/ergomake/internal/apitokens"
 	"github.com/ergomake/ergomake/internal/github/ghapp"
 	"github.com/ergomake/ergomake/internal/logger"
 )
@@ -23,6 +24,8 @@ const AuthTokenCookieName = "auth_token"
 type AuthData struct {
 	jwt.StandardClaims
 	GithubToken *oauth2.Token `json:"githubToken"`
+	// APIToken is set instead of GithubToken when the request was authenticated with an API token
+	APIToken *apitokens.Token `json:"-"`
 }
 
 func (ar *authRouter) callback(c *gin.Context) {
@@ -2,13 +2,37 @@ package auth
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/apitokens"
+	"github.com/ergomake/ergomake/internal/logger"
 )
 
-func ExtractAuthDataMiddleware(jwtSecret string) gin.HandlerFunc {
+const scopeCheckedKey = "apiTokenScopeChecked"
+
+func ExtractAuthDataMiddleware(jwtSecret string, apiTokensProvider apitokens.TokensProvider) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if bearer, ok := getBearerToken(c); ok {
+			token, err := apiTokensProvider.Authenticate(c, bearer)
+			if err != nil {
+				if !errors.Is(err, apitokens.ErrTokenNotFound) {
+					logger.Ctx(c).Err(err).Msg("fail to authenticate api token")
+				}
+
+				c.Next()
+				return
+			}
+
+			c.Set("customClaims", &AuthData{APIToken: token})
+			c.Next()
+			return
+		}
+
 		authToken, err := c.Cookie(AuthTokenCookieName)
 		if err != nil {
 			c.Next()
@@ -28,8 +52,9 @@ func ExtractAuthDataMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
+		// other JWTs signed with the same secret must not pass as dashboard sessions
 		claims, ok := token.Claims.(*AuthData)
-		if !ok {
+		if !ok || claims.GithubToken == nil {
 			c.Next()
 			return
 		}
@@ -39,6 +64,43 @@ func ExtractAuthDataMiddleware(jwtSecret string) gin.HandlerFunc {
 	}
 }
 
+func getBearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return "", false
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(header, "Bearer ")), true
+}
+
+// RequireScope makes a route available to API tokens that have scope,
+// requests authenticated with a session cookie are not affected by it
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		v, ok := c.Get("customClaims")
+		if !ok {
+			c.Next()
+			return
+		}
+
+		claims, ok := v.(*AuthData)
+		if !ok || claims.APIToken == nil {
+			c.Next()
+			return
+		}
+
+		if !claims.APIToken.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"reason": "missing-scope", "scope": scope})
+			return
+		}
+
+		c.Set(scopeCheckedKey, true)
+		c.Next()
+	}
+}
+
+// GetAuthData returns the authentication data of the request, API tokens are
+// only accepted by routes that went through RequireScope
 func GetAuthData(c *gin.Context) (*AuthData, bool) {
 	v, ok := c.Get("customClaims")
 	if !ok {
@@ -50,5 +112,9 @@ func GetAuthData(c *gin.Context) (*AuthData, bool) {
 		return nil, false
 	}
 
+	if claims.APIToken != nil && !c.GetBool(scopeCheckedKey) {
+		return nil, false
+	}
+
 	return claims, true
 }
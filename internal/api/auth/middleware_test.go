@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ergomake/ergomake/internal/apitokens"
+	apitokensMocks "github.com/ergomake/ergomake/mocks/apitokens"
+)
+
+func TestExtractAuthDataMiddleware_APIToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tt := []struct {
+		name       string
+		header     string
+		route      string
+		token      *apitokens.Token
+		authErr    error
+		wantStatus int
+	}{
+		{
+			name:       "token with scope",
+			header:     "Bearer valid",
+			route:      "/scoped",
+			token:      &apitokens.Token{Owner: "owner", Scopes: []string{apitokens.ScopeEnvironmentsRead}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "token without scope",
+			header:     "Bearer valid",
+			route:      "/scoped",
+			token:      &apitokens.Token{Owner: "owner", Scopes: []string{apitokens.ScopeVariablesManage}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "token on route without scope",
+			header:     "Bearer valid",
+			route:      "/unscoped",
+			token:      &apitokens.Token{Owner: "owner", Scopes: apitokens.Scopes},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "revoked token",
+			header:     "Bearer revoked",
+			route:      "/scoped",
+			authErr:    apitokens.ErrTokenNotFound,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no credentials",
+			route:      "/scoped",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := apitokensMocks.NewTokensProvider(t)
+			if tc.header != "" {
+				provider.EXPECT().Authenticate(mock.Anything, tc.header[len("Bearer "):]).Return(tc.token, tc.authErr)
+			}
+
+			handler := func(c *gin.Context) {
+				authData, ok := GetAuthData(c)
+				if !ok {
+					c.Status(http.StatusUnauthorized)
+					return
+				}
+
+				isAuthorized, _ := IsAuthorized(c, "owner", authData)
+				assert.True(t, isAuthorized)
+				c.Status(http.StatusOK)
+			}
+
+			router := gin.New()
+			router.Use(ExtractAuthDataMiddleware("secret", provider))
+			router.GET("/scoped", RequireScope(apitokens.ScopeEnvironmentsRead), handler)
+			router.GET("/unscoped", handler)
+
+			req := httptest.NewRequest(http.MethodGet, tc.route, nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestExtractAuthDataMiddleware_CookieWithoutGithubToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// any other JWT signed with the same secret, such as a preview session
+	cookie, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{Subject: "octocat"}).
+		SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(ExtractAuthDataMiddleware("secret", apitokensMocks.NewTokensProvider(t)))
+	router.GET("/", func(c *gin.Context) {
+		_, ok := GetAuthData(c)
+		assert.False(t, ok)
+		c.Status(http.StatusUnauthorized)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: AuthTokenCookieName, Value: cookie})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/github/ghoauth"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+// HasRole checks if the caller has at least role on owner, it expects IsAuthorized to
+// have been checked already. API tokens have the current role of whoever created
+// them, so that tokens of demoted or removed members lose their access. GetRole
+// expects members, so the membership of creators is checked first.
+func HasRole(
+	ctx context.Context,
+	authorizer rbac.Authorizer,
+	owner, repo string,
+	authData *AuthData,
+	role rbac.Role,
+) (bool, error) {
+	var login string
+	if authData.APIToken != nil {
+		login = authData.APIToken.CreatedBy
+		if login == "" {
+			return false, nil
+		}
+
+		isMember, err := authorizer.IsMember(ctx, owner, login)
+		if err != nil {
+			return false, errors.Wrapf(err, "fail to check membership of token creator %s", login)
+		}
+
+		if !isMember {
+			return false, nil
+		}
+	} else {
+		client := ghoauth.FromToken(authData.GithubToken)
+		var err error
+		login, err = ghoauth.GetLogin(ctx, client, authData.GithubToken.AccessToken)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	userRole, err := authorizer.GetRole(ctx, owner, repo, login)
+	if err != nil {
+		return false, errors.Wrapf(err, "fail to get role of %s on %s", login, owner)
+	}
+
+	return userRole.Allows(role), nil
+}
+
+// Authorize writes an error response and returns false when the caller isn't
+// authorized on owner or doesn't have at least role on it
+func Authorize(c *gin.Context, authorizer rbac.Authorizer, owner, repo string, role rbac.Role) bool {
+	authData, ok := GetAuthData(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		return false
+	}
+
+	isAuthorized, err := IsAuthorized(c, owner, authData)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msg("fail to check for authorization")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return false
+	}
+
+	if !isAuthorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return false
+	}
+
+	hasRole, err := HasRole(c, authorizer, owner, repo, authData, role)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msg("fail to check for role")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return false
+	}
+
+	if !hasRole {
+		c.AbortWithStatusJSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return false
+	}
+
+	return true
+}
+
+// RequireRole only lets through callers with at least role on the :owner param,
+// and on the :repo param of routes that have one
+func RequireRole(authorizer rbac.Authorizer, role rbac.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		owner := c.Param("owner")
+		if owner == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+			return
+		}
+
+		if Authorize(c, authorizer, owner, c.Param("repo"), role) {
+			c.Next()
+		}
+	}
+}
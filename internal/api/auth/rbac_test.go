@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ergomake/ergomake/internal/apitokens"
+	"github.com/ergomake/ergomake/internal/rbac"
+	apitokensMocks "github.com/ergomake/ergomake/mocks/apitokens"
+	ghAppMocks "github.com/ergomake/ergomake/mocks/github/ghapp"
+	rbacMocks "github.com/ergomake/ergomake/mocks/rbac"
+)
+
+func TestRequireRole_APIToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// owners that never configured roles make every member an admin, which is
+	// what tokens of removed creators would fall back to without the membership check
+	settings := rbac.DefaultSettings()
+
+	tt := []struct {
+		name             string
+		token            *apitokens.Token
+		checksMembership bool
+		isMember         bool
+		assignments      []rbac.Assignment
+		wantStatus       int
+	}{
+		{
+			name:             "creator is still an admin",
+			token:            &apitokens.Token{Owner: "owner", CreatedBy: "alice"},
+			checksMembership: true,
+			isMember:         true,
+			wantStatus:       http.StatusOK,
+		},
+		{
+			name:             "creator was demoted",
+			token:            &apitokens.Token{Owner: "owner", CreatedBy: "alice"},
+			checksMembership: true,
+			isMember:         true,
+			assignments: []rbac.Assignment{
+				{Kind: rbac.AssignmentKindUser, Subject: "alice", Role: rbac.RoleViewer},
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:             "creator was removed",
+			token:            &apitokens.Token{Owner: "owner", CreatedBy: "alice"},
+			checksMembership: true,
+			isMember:         false,
+			wantStatus:       http.StatusForbidden,
+		},
+		{
+			name:       "token without creator",
+			token:      &apitokens.Token{Owner: "owner"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "token of another owner",
+			token:      &apitokens.Token{Owner: "other", CreatedBy: "alice"},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.token.Scopes = apitokens.Scopes
+
+			provider := apitokensMocks.NewTokensProvider(t)
+			provider.EXPECT().Authenticate(mock.Anything, "valid").Return(tc.token, nil)
+
+			ghApp := ghAppMocks.NewGHAppClient(t)
+			rbacProvider := rbacMocks.NewRBACProvider(t)
+			if tc.checksMembership {
+				ghApp.EXPECT().IsOrgMember(mock.Anything, "owner", "alice").Return(tc.isMember, nil)
+			}
+			if tc.isMember {
+				rbacProvider.EXPECT().ListAssignments(mock.Anything, "owner").Return(tc.assignments, nil)
+				rbacProvider.EXPECT().GetSettings(mock.Anything, "owner").Return(&settings, nil)
+			}
+
+			router := gin.New()
+			router.Use(ExtractAuthDataMiddleware("secret", provider))
+			router.GET(
+				"/owner/:owner/repos/:repo",
+				RequireScope(apitokens.ScopeEnvironmentsRead),
+				RequireRole(rbac.NewAuthorizer(rbacProvider, ghApp), rbac.RoleAdmin),
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/owner/owner/repos/repo", nil)
+			req.Header.Set("Authorization", "Bearer valid")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
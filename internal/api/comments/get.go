@@ -0,0 +1,23 @@
+package comments
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+func (cr *commentsRouter) get(c *gin.Context) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	settings, err := cr.commentSettingsProvider.Get(c, owner, repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get comment settings for repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,23 @@
+package comments
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/prcomments"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type commentsRouter struct {
+	commentSettingsProvider prcomments.SettingsProvider
+	authorizer              rbac.Authorizer
+}
+
+func NewCommentsRouter(commentSettingsProvider prcomments.SettingsProvider, authorizer rbac.Authorizer) *commentsRouter {
+	return &commentsRouter{commentSettingsProvider, authorizer}
+}
+
+func (cr *commentsRouter) AddRoutes(router *gin.RouterGroup) {
+	router.GET("/owner/:owner/repos/:repo/comment-settings", auth.RequireRole(cr.authorizer, rbac.RoleViewer), cr.get)
+	router.POST("/owner/:owner/repos/:repo/comment-settings", auth.RequireRole(cr.authorizer, rbac.RoleAdmin), cr.upsert)
+}
@@ -0,0 +1,37 @@
+package comments
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/prcomments"
+)
+
+func (cr *commentsRouter) upsert(c *gin.Context) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	var body prcomments.Settings
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	if body.Template != "" {
+		if err := prcomments.Validate(body.Template); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-template", "message": err.Error()})
+			return
+		}
+	}
+
+	err := cr.commentSettingsProvider.Upsert(c, owner, repo, body)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to upsert comment settings for repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, body)
+}
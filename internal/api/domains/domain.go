@@ -0,0 +1,242 @@
+package domains
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/domains"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+type challenge struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type domainResponse struct {
+	*domains.Domain
+	HasTLSCertificate bool `json:"hasTlsCertificate"`
+	// Challenge is the DNS record that proves the owner controls the base domain
+	Challenge challenge `json:"challenge"`
+}
+
+func newDomainResponse(domain *domains.Domain) domainResponse {
+	return domainResponse{
+		Domain:            domain,
+		HasTLSCertificate: domain.TLSCert != "",
+		Challenge: challenge{
+			Type:  "TXT",
+			Name:  domain.ChallengeRecord(),
+			Value: domain.VerificationToken,
+		},
+	}
+}
+
+type upsertDomainRequest struct {
+	BaseDomain    string          `json:"baseDomain"`
+	URLTemplate   string          `json:"urlTemplate"`
+	TLSMode       domains.TLSMode `json:"tlsMode"`
+	ClusterIssuer string          `json:"clusterIssuer"`
+	// TLSCert and TLSKey can be left empty to keep the ones already uploaded
+	TLSCert string `json:"tlsCert"`
+	TLSKey  string `json:"tlsKey"`
+}
+
+func (dr *domainsRouter) get(c *gin.Context) {
+	owner := c.Param("owner")
+
+	domain, err := dr.domainsProvider.Get(c, owner)
+	if errors.Is(err, domains.ErrDomainNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get custom domain of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, newDomainResponse(domain))
+}
+
+func (dr *domainsRouter) upsert(c *gin.Context) {
+	owner := c.Param("owner")
+
+	var body upsertDomainRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	domain := domains.Domain{
+		Owner:         owner,
+		BaseDomain:    strings.TrimSuffix(strings.ToLower(strings.TrimSpace(body.BaseDomain)), "."),
+		URLTemplate:   body.URLTemplate,
+		TLSMode:       body.TLSMode,
+		ClusterIssuer: body.ClusterIssuer,
+		TLSCert:       body.TLSCert,
+		TLSKey:        body.TLSKey,
+	}
+
+	if !domains.IsValidBaseDomain(domain.BaseDomain) {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-base-domain"})
+		return
+	}
+
+	if domain.URLTemplate == "" {
+		domain.URLTemplate = domains.DefaultURLTemplate
+	}
+
+	if err := domains.ValidateURLTemplate(domain.URLTemplate); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-url-template", "message": err.Error()})
+		return
+	}
+
+	if domain.TLSMode == "" {
+		domain.TLSMode = domains.TLSModeNone
+	}
+
+	if !domain.TLSMode.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-tls-mode"})
+		return
+	}
+
+	if domain.TLSMode == domains.TLSModeCertManager && domain.ClusterIssuer == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-cluster-issuer"})
+		return
+	}
+
+	if domain.TLSMode == domains.TLSModeSecret {
+		if domain.TLSCert == "" && domain.TLSKey == "" {
+			current, err := dr.domainsProvider.Get(c, owner)
+			if err != nil && !errors.Is(err, domains.ErrDomainNotFound) {
+				logger.Ctx(c).Err(err).Msgf("fail to get custom domain of owner %s", owner)
+				c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+				return
+			}
+
+			if current != nil {
+				domain.TLSCert = current.TLSCert
+				domain.TLSKey = current.TLSKey
+			}
+		}
+
+		if reason := validateWildcardCertificate(domain.BaseDomain, domain.TLSCert, domain.TLSKey); reason != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": reason})
+			return
+		}
+	} else {
+		domain.TLSCert = ""
+		domain.TLSKey = ""
+	}
+
+	saved, err := dr.domainsProvider.Upsert(c, domain)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to upsert custom domain of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, dr.auditProvider, owner, "", audit.ActionDomainUpdate, saved.BaseDomain, gin.H{
+		"urlTemplate":   saved.URLTemplate,
+		"tlsMode":       saved.TLSMode,
+		"clusterIssuer": saved.ClusterIssuer,
+	})
+
+	c.JSON(http.StatusOK, newDomainResponse(saved))
+}
+
+func (dr *domainsRouter) delete(c *gin.Context) {
+	owner := c.Param("owner")
+
+	err := dr.domainsProvider.Delete(c, owner)
+	if errors.Is(err, domains.ErrDomainNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to delete custom domain of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, dr.auditProvider, owner, "", audit.ActionDomainDelete, owner, nil)
+
+	c.Status(http.StatusNoContent)
+}
+
+func (dr *domainsRouter) verify(c *gin.Context) {
+	owner := c.Param("owner")
+
+	domain, err := dr.domainsProvider.Get(c, owner)
+	if errors.Is(err, domains.ErrDomainNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get custom domain of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	verified, err := domains.CheckOwnership(c, dr.resolver, domain)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to check ownership of domain %s", domain.BaseDomain)
+		c.JSON(http.StatusBadGateway, gin.H{"reason": "dns-lookup-failed"})
+		return
+	}
+
+	if !verified {
+		c.JSON(http.StatusConflict, gin.H{"reason": "challenge-record-not-found", "challenge": newDomainResponse(domain).Challenge})
+		return
+	}
+
+	err = dr.domainsProvider.MarkVerified(c, owner)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to mark domain %s as verified", domain.BaseDomain)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, dr.auditProvider, owner, "", audit.ActionDomainVerify, domain.BaseDomain, nil)
+
+	domain, err = dr.domainsProvider.Get(c, owner)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get custom domain of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, newDomainResponse(domain))
+}
+
+// validateWildcardCertificate returns the reason why cert and key can't serve
+// the previews under baseDomain, or empty when they can
+func validateWildcardCertificate(baseDomain, cert, key string) string {
+	pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	if err != nil {
+		return "invalid-tls-certificate"
+	}
+
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return "invalid-tls-certificate"
+	}
+
+	if leaf.VerifyHostname("preview."+baseDomain) != nil {
+		return "tls-certificate-does-not-cover-domain"
+	}
+
+	return ""
+}
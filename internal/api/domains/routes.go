@@ -0,0 +1,35 @@
+package domains
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/domains"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type domainsRouter struct {
+	domainsProvider domains.DomainsProvider
+	authorizer      rbac.Authorizer
+	auditProvider   audit.AuditProvider
+	resolver        domains.TXTResolver
+}
+
+func NewDomainsRouter(
+	domainsProvider domains.DomainsProvider,
+	authorizer rbac.Authorizer,
+	auditProvider audit.AuditProvider,
+	resolver domains.TXTResolver,
+) *domainsRouter {
+	return &domainsRouter{domainsProvider, authorizer, auditProvider, resolver}
+}
+
+func (dr *domainsRouter) AddRoutes(router *gin.RouterGroup) {
+	requireAdmin := auth.RequireRole(dr.authorizer, rbac.RoleAdmin)
+
+	router.GET("/owner/:owner/domain", requireAdmin, dr.get)
+	router.POST("/owner/:owner/domain", requireAdmin, dr.upsert)
+	router.DELETE("/owner/:owner/domain", requireAdmin, dr.delete)
+	router.POST("/owner/:owner/domain/verify", requireAdmin, dr.verify)
+}
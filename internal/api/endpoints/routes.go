@@ -0,0 +1,84 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/endpoints"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type endpointsRouter struct {
+	db                *database.DB
+	endpointsProvider endpoints.EndpointsProvider
+	authorizer        rbac.Authorizer
+}
+
+func NewEndpointsRouter(
+	db *database.DB,
+	endpointsProvider endpoints.EndpointsProvider,
+	authorizer rbac.Authorizer,
+) *endpointsRouter {
+	return &endpointsRouter{db, endpointsProvider, authorizer}
+}
+
+func (er *endpointsRouter) AddRoutes(router *gin.RouterGroup) {
+	router.GET("/environments/:envID/endpoints", er.list)
+}
+
+// list returns the grpc and stream endpoints of an environment along with
+// their credentials, so it is restricted to developers of the repo
+func (er *endpointsRouter) list(c *gin.Context) {
+	envID, err := uuid.Parse(c.Param("envID"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	env, err := er.db.FindEnvironmentByID(envID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Str("envID", envID.String()).Msg("fail to find environment by ID")
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	if !auth.Authorize(c, er.authorizer, env.Owner, env.Repo, rbac.RoleDeveloper) {
+		return
+	}
+
+	envEndpoints, err := er.endpointsProvider.ListByEnvironment(c, env.ID)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list endpoints of environment %s", env.ID)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	type endpointResponse struct {
+		endpoints.Endpoint
+		Address          string `json:"address"`
+		CredentialEnvVar string `json:"credentialEnvVar"`
+	}
+
+	res := make([]endpointResponse, 0, len(envEndpoints))
+	for _, endpoint := range envEndpoints {
+		res = append(res, endpointResponse{
+			Endpoint:         endpoint,
+			Address:          endpoint.Address(),
+			CredentialEnvVar: endpoint.CredentialEnvVar(),
+		})
+	}
+
+	c.JSON(http.StatusOK, res)
+}
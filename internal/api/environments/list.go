@@ -9,15 +9,10 @@ import (
 	"github.com/ergomake/ergomake/internal/api/auth"
 	"github.com/ergomake/ergomake/internal/database"
 	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/rbac"
 )
 
 func (er *environmentsRouter) list(c *gin.Context) {
-	authData, ok := auth.GetAuthData(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
-		return
-	}
-
 	owner := c.Query("owner")
 	repo := c.Query("repo")
 	if owner == "" || repo == "" {
@@ -25,15 +20,7 @@ func (er *environmentsRouter) list(c *gin.Context) {
 		return
 	}
 
-	isAuthorized, err := auth.IsAuthorized(c, owner, authData)
-	if err != nil {
-		logger.Ctx(c).Err(err).Msg("fail to create registry")
-		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-		return
-	}
-
-	if !isAuthorized {
-		c.JSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
+	if !auth.Authorize(c, er.authorizer, owner, repo, rbac.RoleViewer) {
 		return
 	}
 
@@ -1,27 +1,25 @@
 package environments
 
 import (
-	"errors"
+	"context"
 	"io"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
 	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/envvars"
 	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/rbac"
 	"github.com/ergomake/ergomake/internal/servicelogs"
 )
 
 func (er *environmentsRouter) logs(c *gin.Context, build bool) {
-	authData, ok := auth.GetAuthData(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
-		return
-	}
-
 	paramEnvID := c.Param("envID")
 	envID, err := uuid.Parse(paramEnvID)
 	if err != nil {
@@ -42,21 +40,20 @@ func (er *environmentsRouter) logs(c *gin.Context, build bool) {
 		return
 	}
 
-	isAuthorized, err := auth.IsAuthorized(c, env.Owner, authData)
-	if err != nil {
-		logger.Ctx(c).Err(err).Msg("fail to check if caller is authorized")
-		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	if !auth.Authorize(c, er.authorizer, env.Owner, env.Repo, rbac.RoleViewer) {
 		return
 	}
 
-	if !isAuthorized {
-		c.JSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
+	services, err := er.db.FindServicesByEnvironment(env.ID)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to find services for environment %s", env.ID)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
 
-	services, err := er.db.FindServicesByEnvironment(env.ID)
+	redactor, err := er.makeRedactor(c, &env, services)
 	if err != nil {
-		logger.Ctx(c).Err(err).Msgf("fail to find services for environment %s", env.ID)
+		logger.Ctx(c).Err(err).Msgf("fail to make log redactor for environment %s", env.ID)
 		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
@@ -90,7 +87,7 @@ func (er *environmentsRouter) logs(c *gin.Context, build bool) {
 		select {
 		case logs := <-logChan:
 			for _, log := range logs {
-				c.SSEvent("log", log)
+				c.SSEvent("log", redactor.Redact(log))
 			}
 		case <-time.After(5 * time.Second):
 			// send something to prevent timeout
@@ -114,3 +111,25 @@ func (er *environmentsRouter) buildLogs(c *gin.Context) {
 func (er *environmentsRouter) liveLogs(c *gin.Context) {
 	er.logs(c, false)
 }
+
+// makeRedactor redacts the secrets the services of env get when deployed
+func (er *environmentsRouter) makeRedactor(
+	ctx context.Context,
+	env *database.Environment,
+	services []database.Service,
+) (*servicelogs.Redactor, error) {
+	environmentType := envvars.EnvironmentTypePermanentBranch
+	if env.PullRequest.Valid {
+		environmentType = envvars.EnvironmentTypePullRequest
+	}
+
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		names = append(names, service.Name)
+	}
+
+	return servicelogs.NewEnvRedactor(ctx, er.envVarsProvider, env.Owner, env.Repo, envvars.Target{
+		Branch:          env.Branch.String,
+		EnvironmentType: environmentType,
+	}, names)
+}
@@ -3,30 +3,52 @@ package environments
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/apitokens"
 	"github.com/ergomake/ergomake/internal/cluster"
 	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/envvars"
+	"github.com/ergomake/ergomake/internal/rbac"
 	"github.com/ergomake/ergomake/internal/servicelogs"
 )
 
 type environmentsRouter struct {
-	db            *database.DB
-	logStreamer   servicelogs.LogStreamer
-	clusterClient cluster.Client
-	jwtSecret     string
+	db                   *database.DB
+	logStreamer          servicelogs.LogStreamer
+	clusterClient        cluster.Client
+	envVarsProvider      envvars.EnvVarsProvider
+	environmentsProvider environments.EnvironmentsProvider
+	authorizer           rbac.Authorizer
+	jwtSecret            string
 }
 
 func NewEnvironmentsRouter(
 	db *database.DB,
 	logStreamer servicelogs.LogStreamer,
 	clusterClient cluster.Client,
+	envVarsProvider envvars.EnvVarsProvider,
+	environmentsProvider environments.EnvironmentsProvider,
+	authorizer rbac.Authorizer,
 	jwtSecret string,
 ) *environmentsRouter {
-	return &environmentsRouter{db, logStreamer, clusterClient, jwtSecret}
+	return &environmentsRouter{
+		db,
+		logStreamer,
+		clusterClient,
+		envVarsProvider,
+		environmentsProvider,
+		authorizer,
+		jwtSecret,
+	}
 }
 
 func (er *environmentsRouter) AddRoutes(router *gin.RouterGroup) {
-	router.GET("/", er.list)
-	router.GET("/:envID/logs/build", er.buildLogs)
-	router.GET("/:envID/logs/live", er.liveLogs)
+	readEnvironments := auth.RequireScope(apitokens.ScopeEnvironmentsRead)
+
+	router.GET("/", readEnvironments, er.list)
+	router.GET("/:envID/logs/build", readEnvironments, er.buildLogs)
+	router.GET("/:envID/logs/live", readEnvironments, er.liveLogs)
 	router.GET("/:envID/public", er.getPublic)
+	router.DELETE("/:envID", auth.RequireScope(apitokens.ScopeEnvironmentsTerminate), er.terminate)
 }
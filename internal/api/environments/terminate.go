@@ -0,0 +1,68 @@
+package environments
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+func (er *environmentsRouter) terminate(c *gin.Context) {
+	authData, ok := auth.GetAuthData(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		return
+	}
+
+	envID, err := uuid.Parse(c.Param("envID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	env, err := er.db.FindEnvironmentByID(envID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			return
+		}
+
+		logger.Ctx(c).Err(err).Str("envID", envID.String()).
+			Msg("fail to find environment by ID")
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	if !auth.Authorize(c, er.authorizer, env.Owner, env.Repo, rbac.RoleDeveloper) {
+		return
+	}
+
+	var prNumber *int
+	if env.PullRequest.Valid {
+		pr := int(env.PullRequest.Int32)
+		prNumber = &pr
+	}
+
+	err = er.environmentsProvider.TerminateEnvironment(c, environments.TerminateEnvironmentRequest{
+		Owner:    env.Owner,
+		Repo:     env.Repo,
+		Branch:   env.Branch.String,
+		PrNumber: prNumber,
+		Actor:    auth.GetActor(c, authData),
+	})
+	if err != nil {
+		logger.Ctx(c).Err(err).Str("envID", envID.String()).
+			Msg("fail to terminate environment")
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
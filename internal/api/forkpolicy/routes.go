@@ -0,0 +1,29 @@
+package forkpolicy
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/forkpolicy"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type forkPolicyRouter struct {
+	policyProvider forkpolicy.PolicyProvider
+	authorizer     rbac.Authorizer
+	auditProvider  audit.AuditProvider
+}
+
+func NewForkPolicyRouter(
+	policyProvider forkpolicy.PolicyProvider,
+	authorizer rbac.Authorizer,
+	auditProvider audit.AuditProvider,
+) *forkPolicyRouter {
+	return &forkPolicyRouter{policyProvider, authorizer, auditProvider}
+}
+
+func (fpr *forkPolicyRouter) AddRoutes(router *gin.RouterGroup) {
+	router.GET("/owner/:owner/repos/:repo/fork-policy", auth.RequireRole(fpr.authorizer, rbac.RoleViewer), fpr.get)
+	router.POST("/owner/:owner/repos/:repo/fork-policy", auth.RequireRole(fpr.authorizer, rbac.RoleAdmin), fpr.upsert)
+}
@@ -0,0 +1,65 @@
+package forkpolicy
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/forkpolicy"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+type upsertRequest struct {
+	Secrets         forkpolicy.SecretsPolicy `json:"secrets"`
+	RequireApproval bool                     `json:"requireApproval"`
+}
+
+func (fpr *forkPolicyRouter) get(c *gin.Context) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	settings, err := fpr.policyProvider.GetSettings(c, owner, repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get fork policy of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (fpr *forkPolicyRouter) upsert(c *gin.Context) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	var body upsertRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	if !body.Secrets.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-secrets-policy"})
+		return
+	}
+
+	settings := forkpolicy.Settings{
+		Owner:           owner,
+		Repo:            repo,
+		Secrets:         body.Secrets,
+		RequireApproval: body.RequireApproval,
+	}
+	err := fpr.policyProvider.UpsertSettings(c, settings)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to upsert fork policy of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, fpr.auditProvider, owner, repo, audit.ActionForkPolicyUpdate, fmt.Sprintf("%s/%s", owner, repo), gin.H{"secrets": settings.Secrets, "requireApproval": settings.RequireApproval})
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,155 @@
+package github
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/github/ghapp"
+	"github.com/ergomake/ergomake/internal/github/ghlauncher"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+func (r *githubRouter) handleCheckRunEvent(githubDelivery string, event *github.CheckRunEvent) {
+	action := event.GetAction()
+	owner := event.GetRepo().GetOwner().GetLogin()
+	repo := event.GetRepo()
+	repoName := repo.GetName()
+	checkRun := event.GetCheckRun()
+	requestedAction := ""
+	if event.GetRequestedAction() != nil {
+		requestedAction = event.GetRequestedAction().Identifier
+	}
+	author := event.GetSender().GetLogin()
+
+	logCtx := logger.With(logger.Get()).
+		Str("githubDelivery", githubDelivery).
+		Str("action", action).
+		Str("requestedAction", requestedAction).
+		Str("owner", owner).
+		Str("repo", repoName).
+		Str("author", author).
+		Str("SHA", checkRun.GetHeadSHA()).
+		Int64("checkRunID", checkRun.GetID()).
+		Str("event", "check_run").
+		Logger()
+	log := &logCtx
+	ctx := log.WithContext(context.Background())
+
+	if _, blocked := ownersBlockList[owner]; blocked {
+		log.Warn().Msg("event ignored because owner is in block list")
+		return
+	}
+
+	if checkRun.GetName() != ghapp.CheckName {
+		return
+	}
+
+	switch action {
+	case "rerequested":
+		requestedAction = ghlauncher.CheckRunRerunAction
+	case "requested_action":
+	default:
+		return
+	}
+
+	log.Info().Msg("got a check run event from github")
+
+	envID, err := uuid.Parse(checkRun.GetExternalID())
+	if err != nil {
+		log.Warn().AnErr("err", err).Msg("check run external id is not an environment id")
+		return
+	}
+
+	env, err := r.environmentsProvider.GetEnvironment(ctx, envID)
+	if err != nil {
+		log.Err(errors.Wrap(err, "fail to find environment of check run")).Msg("fail to handle check run event")
+		return
+	}
+
+	if env.Owner != owner || env.Repo != repoName {
+		log.Warn().Str("env", env.ID.String()).Msg("check run environment does not belong to repository")
+		return
+	}
+
+	var prNumber *int
+	if env.PullRequest.Valid {
+		prNumber = github.Int(int(env.PullRequest.Int32))
+	}
+
+	terminateEnv := environments.TerminateEnvironmentRequest{
+		Owner:    owner,
+		Repo:     repoName,
+		Branch:   env.Branch.String,
+		PrNumber: prNumber,
+		Actor:    author,
+	}
+
+	switch requestedAction {
+	case ghlauncher.CheckRunRerunAction:
+		// check runs don't tell who opened their pull requests
+		prAuthor := ""
+		if prNumber != nil {
+			pr, err := r.ghApp.GetPullRequest(ctx, owner, repoName, *prNumber)
+			if err != nil {
+				log.Err(err).Msg("fail to get pull request of check run")
+				return
+			}
+
+			prAuthor = pr.GetUser().GetLogin()
+		}
+
+		err := r.terminateEnvironment(ctx, terminateEnv)
+		if err != nil {
+			log.Err(err).Msg("fail to terminate environment")
+		}
+
+		launchEnv := ghlauncher.LaunchEnvironmentRequest{
+			Owner:       owner,
+			BranchOwner: env.BranchOwner,
+			Repo:        repoName,
+			Branch:      env.Branch.String,
+			SHA:         checkRun.GetHeadSHA(),
+			PrNumber:    prNumber,
+			Author:      author,
+			PrAuthor:    prAuthor,
+			IsPrivate:   repo.GetPrivate(),
+		}
+
+		err = r.launchEnvironment(ctx, launchEnv)
+		if err != nil {
+			log.Err(err).Msg("fail to launch environment")
+		}
+	case ghlauncher.CheckRunDestroyAction:
+		err := r.terminateEnvironment(ctx, terminateEnv)
+		if err != nil {
+			log.Err(err).Msg("fail to terminate environment")
+			return
+		}
+
+		_, err = r.ghApp.UpdateCheckRun(ctx, owner, repoName, checkRun.GetID(), github.UpdateCheckRunOptions{
+			Name:        ghapp.CheckName,
+			Status:      github.String("completed"),
+			Conclusion:  github.String("neutral"),
+			CompletedAt: &github.Timestamp{Time: time.Now()},
+			Output: &github.CheckRunOutput{
+				Title:   github.String("Environment destroyed"),
+				Summary: github.String("This environment was destroyed by @" + author + "."),
+			},
+			Actions: []*github.CheckRunAction{
+				{
+					Label:       "Re-run",
+					Description: "Build and deploy this environment again",
+					Identifier:  ghlauncher.CheckRunRerunAction,
+				},
+			},
+		})
+		if err != nil {
+			log.Err(err).Msg("fail to update check run after destroying environment")
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package github
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-github/v52/github"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/github/ghapp"
+	"github.com/ergomake/ergomake/internal/github/ghlauncher"
+	environmentsMocks "github.com/ergomake/ergomake/mocks/environments"
+	ghAppMocks "github.com/ergomake/ergomake/mocks/github/ghapp"
+	ghlauncherMocks "github.com/ergomake/ergomake/mocks/github/ghlauncher"
+)
+
+func TestGithubRouter_handleCheckRunEvent(t *testing.T) {
+	envID := uuid.New()
+	env := &database.Environment{
+		ID:          envID,
+		Owner:       "acme",
+		BranchOwner: "mallory",
+		Repo:        "api",
+		Branch:      sql.NullString{String: "fix", Valid: true},
+		PullRequest: sql.NullInt32{Int32: 7, Valid: true},
+		Author:      "mallory",
+	}
+
+	pr := &github.PullRequest{Number: github.Int(7), User: &github.User{Login: github.String("mallory")}}
+
+	makeEvent := func(action, requestedAction, name, externalID string) *github.CheckRunEvent {
+		event := &github.CheckRunEvent{
+			Action: github.String(action),
+			Repo: &github.Repository{
+				Name:  github.String("api"),
+				Owner: &github.User{Login: github.String("acme")},
+			},
+			CheckRun: &github.CheckRun{
+				ID:         github.Int64(42),
+				Name:       github.String(name),
+				HeadSHA:    github.String("abc"),
+				ExternalID: github.String(externalID),
+			},
+			Sender: &github.User{Login: github.String("maintainer")},
+		}
+		if requestedAction != "" {
+			event.RequestedAction = &github.RequestedAction{Identifier: requestedAction}
+		}
+
+		return event
+	}
+
+	terminateReq := environments.TerminateEnvironmentRequest{
+		Owner:    "acme",
+		Repo:     "api",
+		Branch:   "fix",
+		PrNumber: github.Int(7),
+		Actor:    "maintainer",
+	}
+
+	tt := []struct {
+		name  string
+		event *github.CheckRunEvent
+		setup func(*environmentsMocks.EnvironmentsProvider, *ghAppMocks.GHAppClient, *ghlauncherMocks.GHLauncher)
+	}{
+		{
+			name:  "rerun",
+			event: makeEvent("rerequested", "", ghapp.CheckName, envID.String()),
+			setup: func(ep *environmentsMocks.EnvironmentsProvider, ghApp *ghAppMocks.GHAppClient, l *ghlauncherMocks.GHLauncher) {
+				ep.EXPECT().GetEnvironment(mock.Anything, envID).Return(env, nil)
+				ghApp.EXPECT().GetPullRequest(mock.Anything, "acme", "api", 7).Return(pr, nil)
+				ep.EXPECT().TerminateEnvironment(mock.Anything, terminateReq).Return(nil)
+				l.EXPECT().LaunchEnvironment(mock.Anything, ghlauncher.LaunchEnvironmentRequest{
+					Owner:       "acme",
+					BranchOwner: "mallory",
+					Repo:        "api",
+					Branch:      "fix",
+					SHA:         "abc",
+					PrNumber:    github.Int(7),
+					Author:      "maintainer",
+					PrAuthor:    "mallory",
+				}).Return(nil)
+			},
+		},
+		{
+			name:  "rerun requested action",
+			event: makeEvent("requested_action", ghlauncher.CheckRunRerunAction, ghapp.CheckName, envID.String()),
+			setup: func(ep *environmentsMocks.EnvironmentsProvider, ghApp *ghAppMocks.GHAppClient, l *ghlauncherMocks.GHLauncher) {
+				ep.EXPECT().GetEnvironment(mock.Anything, envID).Return(env, nil)
+				ghApp.EXPECT().GetPullRequest(mock.Anything, "acme", "api", 7).Return(pr, nil)
+				ep.EXPECT().TerminateEnvironment(mock.Anything, terminateReq).Return(nil)
+				l.EXPECT().LaunchEnvironment(mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:  "destroy",
+			event: makeEvent("requested_action", ghlauncher.CheckRunDestroyAction, ghapp.CheckName, envID.String()),
+			setup: func(ep *environmentsMocks.EnvironmentsProvider, ghApp *ghAppMocks.GHAppClient, _ *ghlauncherMocks.GHLauncher) {
+				ep.EXPECT().GetEnvironment(mock.Anything, envID).Return(env, nil)
+				ep.EXPECT().TerminateEnvironment(mock.Anything, terminateReq).Return(nil)
+				ghApp.EXPECT().UpdateCheckRun(mock.Anything, "acme", "api", int64(42), mock.MatchedBy(
+					func(opts github.UpdateCheckRunOptions) bool {
+						return opts.GetConclusion() == "neutral" && opts.Output.GetTitle() == "Environment destroyed"
+					},
+				)).Return(nil, nil)
+			},
+		},
+		{
+			name:  "check run of another app",
+			event: makeEvent("rerequested", "", "CI", envID.String()),
+		},
+		{
+			name:  "created",
+			event: makeEvent("created", "", ghapp.CheckName, envID.String()),
+		},
+		{
+			name:  "external id is not an environment",
+			event: makeEvent("rerequested", "", ghapp.CheckName, "not-an-id"),
+		},
+		{
+			name:  "environment of another repo",
+			event: makeEvent("rerequested", "", ghapp.CheckName, envID.String()),
+			setup: func(ep *environmentsMocks.EnvironmentsProvider, _ *ghAppMocks.GHAppClient, _ *ghlauncherMocks.GHLauncher) {
+				other := *env
+				other.Repo = "web"
+				ep.EXPECT().GetEnvironment(mock.Anything, envID).Return(&other, nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			environmentsProvider := environmentsMocks.NewEnvironmentsProvider(t)
+			ghApp := ghAppMocks.NewGHAppClient(t)
+			launcher := ghlauncherMocks.NewGHLauncher(t)
+			if tc.setup != nil {
+				tc.setup(environmentsProvider, ghApp, launcher)
+			}
+
+			r := &githubRouter{ghLauncher: launcher, ghApp: ghApp, environmentsProvider: environmentsProvider}
+			r.handleCheckRunEvent("delivery", tc.event)
+		})
+	}
+}
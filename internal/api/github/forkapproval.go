@@ -0,0 +1,133 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v52/github"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/forkpolicy"
+	"github.com/ergomake/ergomake/internal/github/ghlauncher"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+// approveFork records sender approving approvedSHA and launches the environment
+// of pr again, so that it gets secrets or is built when it awaits approval.
+// pr must be up to date, approvals of commits that are no longer its head are
+// ignored since the code that would be launched isn't the one sender reviewed.
+// Approvals of pull requests that aren't from forks and of senders who can't
+// push to the repo are ignored too.
+func (r *githubRouter) approveFork(
+	ctx context.Context,
+	repo *github.Repository,
+	pr *github.PullRequest,
+	approvedSHA string,
+	sender string,
+) error {
+	log := logger.Ctx(ctx)
+
+	owner := repo.GetOwner().GetLogin()
+	repoName := repo.GetName()
+	branchOwner := pr.GetHead().GetRepo().GetOwner().GetLogin()
+	if !forkpolicy.IsFork(owner, branchOwner) || pr.GetState() != "open" {
+		return nil
+	}
+
+	headSHA := pr.GetHead().GetSHA()
+	if !forkpolicy.ApprovesSHA(approvedSHA, headSHA) {
+		log.Warn().Str("approvedSHA", approvedSHA).Str("headSHA", headSHA).
+			Msg("approval ignored because it isn't of the head commit of the pull request")
+		return nil
+	}
+
+	permission, err := r.ghApp.GetRepoPermissionLevel(ctx, owner, repoName, sender)
+	if err != nil {
+		return errors.Wrap(err, "fail to get permission level of approver")
+	}
+
+	if !forkpolicy.CanApprove(permission) {
+		log.Warn().Str("permission", permission).Msg("approval ignored because sender can't push to the repo")
+		return nil
+	}
+
+	approval := forkpolicy.Approval{
+		Owner:      owner,
+		Repo:       repoName,
+		PrNumber:   pr.GetNumber(),
+		SHA:        headSHA,
+		ApprovedBy: sender,
+	}
+	err = r.forkPolicyProvider.Approve(ctx, approval)
+	if err != nil {
+		return errors.Wrap(err, "fail to record approval")
+	}
+
+	err = r.auditProvider.Record(ctx, audit.Entry{
+		Actor:  sender,
+		Owner:  owner,
+		Repo:   repoName,
+		Action: audit.ActionForkApprove,
+		Target: fmt.Sprintf("%s/%s#%d", owner, repoName, approval.PrNumber),
+		Diff:   audit.NewDiff(map[string]string{"sha": approval.SHA}),
+	})
+	if err != nil {
+		log.Err(err).Msg("fail to record fork approval in audit log")
+	}
+
+	settings, err := r.forkPolicyProvider.GetSettings(ctx, owner, repoName)
+	if err != nil {
+		return errors.Wrap(err, "fail to get fork policy")
+	}
+
+	// without these settings the environment doesn't depend on approvals
+	if settings.Secrets != forkpolicy.SecretsApproved && !settings.RequireApproval {
+		return nil
+	}
+
+	prNumber := pr.GetNumber()
+	branch := pr.GetHead().GetRef()
+	err = r.terminateEnvironment(ctx, environments.TerminateEnvironmentRequest{
+		Owner:    owner,
+		Repo:     repoName,
+		Branch:   branch,
+		PrNumber: &prNumber,
+		Actor:    sender,
+	})
+	if err != nil {
+		log.Err(err).Msg("fail to terminate environment")
+	}
+
+	err = r.launchEnvironment(ctx, ghlauncher.LaunchEnvironmentRequest{
+		Owner:       owner,
+		BranchOwner: branchOwner,
+		Repo:        repoName,
+		Branch:      branch,
+		SHA:         approval.SHA,
+		PrNumber:    &prNumber,
+		Author:      sender,
+		PrAuthor:    pr.GetUser().GetLogin(),
+		IsPrivate:   repo.GetPrivate(),
+	})
+
+	return errors.Wrap(err, "fail to launch approved environment")
+}
+
+// revokeForkApproval removes the approval label of a fork pull request that got
+// new commits, approvals are of a single commit so the label would be stale
+func (r *githubRouter) revokeForkApproval(ctx context.Context, repo *github.Repository, pr *github.PullRequest) error {
+	owner := repo.GetOwner().GetLogin()
+	if !forkpolicy.IsFork(owner, pr.GetHead().GetRepo().GetOwner().GetLogin()) {
+		return nil
+	}
+
+	for _, label := range pr.Labels {
+		if label.GetName() == forkpolicy.ApprovalLabel {
+			return r.ghApp.RemoveLabel(ctx, owner, repo.GetName(), pr.GetNumber(), forkpolicy.ApprovalLabel)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v52/github"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/forkpolicy"
+	"github.com/ergomake/ergomake/internal/github/ghlauncher"
+	auditMocks "github.com/ergomake/ergomake/mocks/audit"
+	environmentsMocks "github.com/ergomake/ergomake/mocks/environments"
+	forkpolicyMocks "github.com/ergomake/ergomake/mocks/forkpolicy"
+	ghAppMocks "github.com/ergomake/ergomake/mocks/github/ghapp"
+	ghlauncherMocks "github.com/ergomake/ergomake/mocks/github/ghlauncher"
+)
+
+func TestGithubRouter_forkApprovals(t *testing.T) {
+	reviewedSHA := "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b"
+	pushedSHA := "9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b2a1f0e"
+
+	repo := &github.Repository{
+		Name:  github.String("api"),
+		Owner: &github.User{Login: github.String("acme")},
+	}
+	makePR := func(sha string) *github.PullRequest {
+		return &github.PullRequest{
+			Number: github.Int(7),
+			State:  github.String("open"),
+			User:   &github.User{Login: github.String("mallory")},
+			Head: &github.PullRequestBranch{
+				Ref:  github.String("fix"),
+				SHA:  github.String(sha),
+				Repo: &github.Repository{Owner: &github.User{Login: github.String("mallory")}},
+			},
+		}
+	}
+	comment := func(body string) func(r *githubRouter) {
+		return func(r *githubRouter) {
+			r.handleIssueCommentEvent("delivery", &github.IssueCommentEvent{
+				Action:  github.String("created"),
+				Repo:    repo,
+				Issue:   &github.Issue{Number: github.Int(7), PullRequestLinks: &github.PullRequestLinks{}},
+				Comment: &github.IssueComment{Body: github.String(body)},
+				Sender:  &github.User{Login: github.String("maintainer")},
+			})
+		}
+	}
+	label := func(r *githubRouter) {
+		r.handlePullRequestEvent("delivery", &github.PullRequestEvent{
+			Action:      github.String("labeled"),
+			Number:      github.Int(7),
+			Repo:        repo,
+			PullRequest: makePR(reviewedSHA),
+			Label:       &github.Label{Name: github.String(forkpolicy.ApprovalLabel)},
+			Sender:      &github.User{Login: github.String("maintainer")},
+		})
+	}
+
+	type mocks struct {
+		ghApp                *ghAppMocks.GHAppClient
+		forkPolicyProvider   *forkpolicyMocks.PolicyProvider
+		auditProvider        *auditMocks.AuditProvider
+		environmentsProvider *environmentsMocks.EnvironmentsProvider
+		launcher             *ghlauncherMocks.GHLauncher
+	}
+	expectApproval := func(m mocks) {
+		m.ghApp.EXPECT().GetRepoPermissionLevel(mock.Anything, "acme", "api", "maintainer").Return("write", nil)
+		m.forkPolicyProvider.EXPECT().Approve(mock.Anything, forkpolicy.Approval{
+			Owner:      "acme",
+			Repo:       "api",
+			PrNumber:   7,
+			SHA:        reviewedSHA,
+			ApprovedBy: "maintainer",
+		}).Return(nil)
+		m.auditProvider.EXPECT().Record(mock.Anything, mock.Anything).Return(nil)
+		m.forkPolicyProvider.EXPECT().GetSettings(mock.Anything, "acme", "api").
+			Return(&forkpolicy.Settings{Owner: "acme", Repo: "api", RequireApproval: true}, nil)
+		m.environmentsProvider.EXPECT().TerminateEnvironment(mock.Anything, environments.TerminateEnvironmentRequest{
+			Owner:    "acme",
+			Repo:     "api",
+			Branch:   "fix",
+			PrNumber: github.Int(7),
+			Actor:    "maintainer",
+		}).Return(nil)
+		m.launcher.EXPECT().LaunchEnvironment(mock.Anything, ghlauncher.LaunchEnvironmentRequest{
+			Owner:       "acme",
+			BranchOwner: "mallory",
+			Repo:        "api",
+			Branch:      "fix",
+			SHA:         reviewedSHA,
+			PrNumber:    github.Int(7),
+			Author:      "maintainer",
+			PrAuthor:    "mallory",
+		}).Return(nil)
+	}
+
+	tt := []struct {
+		name  string
+		event func(r *githubRouter)
+		setup func(m mocks)
+	}{
+		{
+			name:  "comment approving the head",
+			event: comment("/ergomake approve 1a2b3c4"),
+			setup: func(m mocks) {
+				m.ghApp.EXPECT().GetPullRequest(mock.Anything, "acme", "api", 7).Return(makePR(reviewedSHA), nil)
+				expectApproval(m)
+			},
+		},
+		{
+			name:  "comment approving a commit that is no longer the head",
+			event: comment("/ergomake approve 1a2b3c4"),
+			setup: func(m mocks) {
+				m.ghApp.EXPECT().GetPullRequest(mock.Anything, "acme", "api", 7).Return(makePR(pushedSHA), nil)
+			},
+		},
+		{
+			name:  "comment without sha",
+			event: comment("/ergomake approve"),
+		},
+		{
+			name:  "label of the head",
+			event: label,
+			setup: func(m mocks) {
+				m.ghApp.EXPECT().GetPullRequest(mock.Anything, "acme", "api", 7).Return(makePR(reviewedSHA), nil)
+				expectApproval(m)
+			},
+		},
+		{
+			name:  "label of a commit that is no longer the head",
+			event: label,
+			setup: func(m mocks) {
+				m.ghApp.EXPECT().GetPullRequest(mock.Anything, "acme", "api", 7).Return(makePR(pushedSHA), nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			m := mocks{
+				ghApp:                ghAppMocks.NewGHAppClient(t),
+				forkPolicyProvider:   forkpolicyMocks.NewPolicyProvider(t),
+				auditProvider:        auditMocks.NewAuditProvider(t),
+				environmentsProvider: environmentsMocks.NewEnvironmentsProvider(t),
+				launcher:             ghlauncherMocks.NewGHLauncher(t),
+			}
+			if tc.setup != nil {
+				tc.setup(m)
+			}
+
+			r := &githubRouter{
+				ghLauncher:           m.launcher,
+				ghApp:                m.ghApp,
+				environmentsProvider: m.environmentsProvider,
+				forkPolicyProvider:   m.forkPolicyProvider,
+				auditProvider:        m.auditProvider,
+			}
+			tc.event(r)
+		})
+	}
+}
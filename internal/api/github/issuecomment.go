@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v52/github"
+
+	"github.com/ergomake/ergomake/internal/forkpolicy"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+func (r *githubRouter) handleIssueCommentEvent(githubDelivery string, event *github.IssueCommentEvent) {
+	action := event.GetAction()
+	owner := event.GetRepo().GetOwner().GetLogin()
+	repoName := event.GetRepo().GetName()
+	prNumber := event.GetIssue().GetNumber()
+	author := event.GetSender().GetLogin()
+
+	approvedSHA, isApproval := forkpolicy.ParseApprovalCommand(event.GetComment().GetBody())
+	if action != "created" || !event.GetIssue().IsPullRequest() || !isApproval {
+		return
+	}
+
+	logCtx := logger.With(logger.Get()).
+		Str("githubDelivery", githubDelivery).
+		Str("action", action).
+		Str("owner", owner).
+		Str("repo", repoName).
+		Int("prNumber", prNumber).
+		Str("author", author).
+		Str("approvedSHA", approvedSHA).
+		Str("event", "issue_comment").
+		Logger()
+	log := &logCtx
+	ctx := log.WithContext(context.Background())
+
+	if _, blocked := ownersBlockList[owner]; blocked {
+		log.Warn().Msg("event ignored because owner is in block list")
+		return
+	}
+
+	log.Info().Msg("got an approval comment from github")
+
+	pr, err := r.ghApp.GetPullRequest(ctx, owner, repoName, prNumber)
+	if err != nil {
+		log.Err(err).Msg("fail to get pull request of approval comment")
+		return
+	}
+
+	err = r.approveFork(ctx, event.GetRepo(), pr, approvedSHA, author)
+	if err != nil {
+		log.Err(err).Msg("fail to approve fork pull request")
+	}
+}
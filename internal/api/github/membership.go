@@ -0,0 +1,44 @@
+package github
+
+import (
+	"github.com/google/go-github/v52/github"
+
+	"github.com/ergomake/ergomake/internal/github/ghoauth"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+func (r *githubRouter) handleOrganizationEvent(githubDelivery string, event *github.OrganizationEvent) {
+	org := event.GetOrganization().GetLogin()
+	login := event.GetMembership().GetUser().GetLogin()
+	if org == "" || login == "" {
+		return
+	}
+
+	logger.Get().Info().
+		Str("githubDelivery", githubDelivery).
+		Str("event", "organization").
+		Str("action", event.GetAction()).
+		Str("owner", org).
+		Str("user", login).
+		Msg("invalidating cached org membership")
+
+	ghoauth.InvalidateMembership(org, login)
+}
+
+func (r *githubRouter) handleMembershipEvent(githubDelivery string, event *github.MembershipEvent) {
+	org := event.GetOrg().GetLogin()
+	login := event.GetMember().GetLogin()
+	if org == "" || login == "" {
+		return
+	}
+
+	logger.Get().Info().
+		Str("githubDelivery", githubDelivery).
+		Str("event", "membership").
+		Str("action", event.GetAction()).
+		Str("owner", org).
+		Str("user", login).
+		Msg("invalidating cached org membership")
+
+	ghoauth.InvalidateMembership(org, login)
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/gin-gonic/gin"
 
-	"github.com/ergomake/ergomake/internal/api/auth"
 	"github.com/ergomake/ergomake/internal/database"
 	"github.com/ergomake/ergomake/internal/logger"
 )
@@ -14,26 +13,6 @@ import (
 func (ghr *githubRouter) listReposForOwner(c *gin.Context) {
 	owner := c.Param("owner")
 
-	authData, ok := auth.GetAuthData(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
-		return
-	}
-
-	isAuthorized, err := auth.IsAuthorized(c, owner, authData)
-	if err != nil {
-		logger.Ctx(c).Err(err).
-			Str("owner", owner).
-			Msg("fail to check if user is authorized to list owner repos")
-		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-		return
-	}
-
-	if !isAuthorized {
-		c.JSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
-		return
-	}
-
 	environments, err := ghr.db.FindEnvironmentsByOwner(owner, database.FindEnvironmentsOptions{IncludeDeleted: true})
 	if err != nil {
 		logger.Ctx(c).Err(err).
@@ -6,6 +6,7 @@ import (
 	"github.com/google/go-github/v52/github"
 
 	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/forkpolicy"
 	"github.com/ergomake/ergomake/internal/github/ghlauncher"
 	"github.com/ergomake/ergomake/internal/logger"
 )
@@ -45,11 +46,19 @@ func (r *githubRouter) handlePullRequestEvent(githubDelivery string, event *gith
 		Repo:     repoName,
 		Branch:   branch,
 		PrNumber: github.Int(prNumber),
+		Actor:    author,
 	}
 
 	log.Info().Msg("got a pull request event from github")
 	switch action {
 	case "opened", "reopened", "synchronize":
+		if action == "synchronize" {
+			err := r.revokeForkApproval(ctx, repo, event.GetPullRequest())
+			if err != nil {
+				log.Err(err).Msg("fail to remove approval label")
+			}
+		}
+
 		err := r.terminateEnvironment(ctx, terminateEnv)
 		if err != nil {
 			log.Err(err).Msg("fail to terminate environment")
@@ -63,6 +72,7 @@ func (r *githubRouter) handlePullRequestEvent(githubDelivery string, event *gith
 			SHA:         sha,
 			PrNumber:    &prNumber,
 			Author:      author,
+			PrAuthor:    event.GetPullRequest().GetUser().GetLogin(),
 			IsPrivate:   repo.GetPrivate(),
 		}
 
@@ -70,6 +80,23 @@ func (r *githubRouter) handlePullRequestEvent(githubDelivery string, event *gith
 		if err != nil {
 			log.Err(err).Msg("fail to launch environment")
 		}
+	case "labeled":
+		if event.GetLabel().GetName() != forkpolicy.ApprovalLabel {
+			return
+		}
+
+		// the payload has the head of when the label was added, which is what the
+		// maintainer approved, the head may have changed since then
+		pr, err := r.ghApp.GetPullRequest(ctx, owner, repoName, prNumber)
+		if err != nil {
+			log.Err(err).Msg("fail to get pull request of approval label")
+			return
+		}
+
+		err = r.approveFork(ctx, repo, pr, sha, author)
+		if err != nil {
+			log.Err(err).Msg("fail to approve fork pull request")
+		}
 	case "closed":
 		err := r.terminateEnvironment(ctx, terminateEnv)
 		if err != nil {
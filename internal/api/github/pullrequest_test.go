@@ -0,0 +1,68 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v52/github"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/github/ghlauncher"
+	environmentsMocks "github.com/ergomake/ergomake/mocks/environments"
+	ghlauncherMocks "github.com/ergomake/ergomake/mocks/github/ghlauncher"
+)
+
+func TestGithubRouter_handlePullRequestEvent(t *testing.T) {
+	tt := []struct {
+		name   string
+		action string
+		sender string
+	}{
+		{name: "opened by its author", action: "opened", sender: "mallory"},
+		{name: "reopened by a maintainer", action: "reopened", sender: "maintainer"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			environmentsProvider := environmentsMocks.NewEnvironmentsProvider(t)
+			environmentsProvider.EXPECT().TerminateEnvironment(mock.Anything, environments.TerminateEnvironmentRequest{
+				Owner:    "acme",
+				Repo:     "api",
+				Branch:   "fix",
+				PrNumber: github.Int(7),
+				Actor:    tc.sender,
+			}).Return(nil)
+
+			launcher := ghlauncherMocks.NewGHLauncher(t)
+			launcher.EXPECT().LaunchEnvironment(mock.Anything, ghlauncher.LaunchEnvironmentRequest{
+				Owner:       "acme",
+				BranchOwner: "mallory",
+				Repo:        "api",
+				Branch:      "fix",
+				SHA:         "abc",
+				PrNumber:    github.Int(7),
+				Author:      tc.sender,
+				PrAuthor:    "mallory",
+			}).Return(nil)
+
+			r := &githubRouter{ghLauncher: launcher, environmentsProvider: environmentsProvider}
+			r.handlePullRequestEvent("delivery", &github.PullRequestEvent{
+				Action: github.String(tc.action),
+				Repo: &github.Repository{
+					Name:  github.String("api"),
+					Owner: &github.User{Login: github.String("acme")},
+				},
+				PullRequest: &github.PullRequest{
+					Number: github.Int(7),
+					User:   &github.User{Login: github.String("mallory")},
+					Head: &github.PullRequestBranch{
+						Ref:  github.String("fix"),
+						SHA:  github.String("abc"),
+						Repo: &github.Repository{Owner: &github.User{Login: github.String("mallory")}},
+					},
+				},
+				Sender: &github.User{Login: github.String(tc.sender)},
+			})
+		})
+	}
+}
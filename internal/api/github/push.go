@@ -54,6 +54,7 @@ func (r *githubRouter) handlePushEvent(githubDelivery string, event *github.Push
 		Repo:     repoName,
 		Branch:   branch,
 		PrNumber: nil,
+		Actor:    author,
 	}
 	err = r.environmentsProvider.TerminateEnvironment(ctx, terminateEnv)
 	if err != nil {
@@ -3,14 +3,19 @@ package github
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/apitokens"
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/cluster"
 	"github.com/ergomake/ergomake/internal/database"
 	"github.com/ergomake/ergomake/internal/environments"
 	"github.com/ergomake/ergomake/internal/envvars"
+	"github.com/ergomake/ergomake/internal/forkpolicy"
 	"github.com/ergomake/ergomake/internal/github/ghapp"
 	"github.com/ergomake/ergomake/internal/github/ghlauncher"
 	"github.com/ergomake/ergomake/internal/payment"
 	"github.com/ergomake/ergomake/internal/privregistry"
+	"github.com/ergomake/ergomake/internal/rbac"
 )
 
 type githubRouter struct {
@@ -22,6 +27,9 @@ type githubRouter struct {
 	privRegistryProvider    privregistry.PrivRegistryProvider
 	environmentsProvider    environments.EnvironmentsProvider
 	paymentProvider         payment.PaymentProvider
+	forkPolicyProvider      forkpolicy.PolicyProvider
+	auditProvider           audit.AuditProvider
+	authorizer              rbac.Authorizer
 	webhookSecret           string
 	frontendURL             string
 	dockerhubPullSecretName string
@@ -36,6 +44,9 @@ func NewGithubRouter(
 	privRegistryProvider privregistry.PrivRegistryProvider,
 	environmentsProvider environments.EnvironmentsProvider,
 	paymentProvider payment.PaymentProvider,
+	forkPolicyProvider forkpolicy.PolicyProvider,
+	auditProvider audit.AuditProvider,
+	authorizer rbac.Authorizer,
 	webhookSecret string,
 	frontendURL string,
 	dockerhubPullSecretName string,
@@ -49,6 +60,9 @@ func NewGithubRouter(
 		privRegistryProvider,
 		environmentsProvider,
 		paymentProvider,
+		forkPolicyProvider,
+		auditProvider,
+		authorizer,
 		webhookSecret,
 		frontendURL,
 		dockerhubPullSecretName,
@@ -59,6 +73,15 @@ func (ghr *githubRouter) AddRoutes(router *gin.RouterGroup) {
 	router.POST("/webhook", ghr.webhook)
 	router.POST("/marketplace/webhook", ghr.marketplaceWebhook)
 	router.GET("/user/organizations", ghr.listUserOrganizations)
-	router.GET("/owner/:owner/repos", ghr.listReposForOwner)
-	router.POST("/owner/:owner/repos/:repo/configure", ghr.configureRepo)
+	router.GET(
+		"/owner/:owner/repos",
+		auth.RequireScope(apitokens.ScopeEnvironmentsRead),
+		auth.RequireRole(ghr.authorizer, rbac.RoleViewer),
+		ghr.listReposForOwner,
+	)
+	router.POST(
+		"/owner/:owner/repos/:repo/configure",
+		auth.RequireRole(ghr.authorizer, rbac.RoleAdmin),
+		ghr.configureRepo,
+	)
 }
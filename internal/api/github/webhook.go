@@ -60,6 +60,14 @@ func (r *githubRouter) webhook(c *gin.Context) {
 			r.handlePushEvent(githubDelivery, event)
 		case *github.PullRequestEvent:
 			r.handlePullRequestEvent(githubDelivery, event)
+		case *github.IssueCommentEvent:
+			r.handleIssueCommentEvent(githubDelivery, event)
+		case *github.CheckRunEvent:
+			r.handleCheckRunEvent(githubDelivery, event)
+		case *github.OrganizationEvent:
+			r.handleOrganizationEvent(githubDelivery, event)
+		case *github.MembershipEvent:
+			r.handleMembershipEvent(githubDelivery, event)
 		}
 	}()
 }
@@ -0,0 +1,51 @@
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/notifications"
+)
+
+type createChannelRequest struct {
+	Kind   string `json:"kind"`
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+func (nr *notificationsRouter) create(c *gin.Context) {
+	owner := c.Param("owner")
+
+	var body createChannelRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	if body.Kind != notifications.ChannelKindSlack && body.Kind != notifications.ChannelKindWebhook {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-kind"})
+		return
+	}
+
+	err := notifications.ValidateChannelURL(body.Kind, body.URL)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-url"})
+		return
+	}
+
+	channel, err := nr.channelsProvider.Create(c, notifications.Channel{
+		Owner:  owner,
+		Kind:   body.Kind,
+		URL:    body.URL,
+		Secret: body.Secret,
+	})
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to create notification channel for owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusCreated, channel)
+}
@@ -0,0 +1,36 @@
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/notifications"
+)
+
+func (nr *notificationsRouter) delete(c *gin.Context) {
+	owner := c.Param("owner")
+
+	channelID, err := uuid.Parse(c.Param("channelID"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	err = nr.channelsProvider.Delete(c, owner, channelID)
+	if errors.Is(err, notifications.ErrChannelNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to delete notification channel %s", channelID)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
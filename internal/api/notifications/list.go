@@ -0,0 +1,22 @@
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+func (nr *notificationsRouter) list(c *gin.Context) {
+	owner := c.Param("owner")
+
+	channels, err := nr.channelsProvider.List(c, owner)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list notification channels of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, channels)
+}
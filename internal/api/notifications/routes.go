@@ -0,0 +1,27 @@
+package notifications
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/notifications"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type notificationsRouter struct {
+	channelsProvider notifications.ChannelsProvider
+	authorizer       rbac.Authorizer
+}
+
+func NewNotificationsRouter(channelsProvider notifications.ChannelsProvider, authorizer rbac.Authorizer) *notificationsRouter {
+	return &notificationsRouter{channelsProvider, authorizer}
+}
+
+func (nr *notificationsRouter) AddRoutes(router *gin.RouterGroup) {
+	requireAdmin := auth.RequireRole(nr.authorizer, rbac.RoleAdmin)
+
+	router.GET("/owner/:owner/notification-channels", requireAdmin, nr.list)
+	router.POST("/owner/:owner/notification-channels", requireAdmin, nr.create)
+	router.DELETE("/owner/:owner/notification-channels/:channelID", requireAdmin, nr.delete)
+	router.GET("/owner/:owner/notification-channels/:channelID/deliveries", requireAdmin, nr.listDeliveries)
+}
@@ -3,10 +3,14 @@ package permanentbranches
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/apitokens"
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/environments"
 	"github.com/ergomake/ergomake/internal/github/ghapp"
 	"github.com/ergomake/ergomake/internal/github/ghlauncher"
 	"github.com/ergomake/ergomake/internal/permanentbranches"
+	"github.com/ergomake/ergomake/internal/rbac"
 )
 
 type permanentBranchesRouter struct {
@@ -14,6 +18,8 @@ type permanentBranchesRouter struct {
 	ghLaunccher               ghlauncher.GHLauncher
 	permanentbranchesProvider permanentbranches.PermanentBranchesProvider
 	environmentsProvider      environments.EnvironmentsProvider
+	authorizer                rbac.Authorizer
+	auditProvider             audit.AuditProvider
 }
 
 func NewPermanentBranchesRouter(
@@ -21,11 +27,30 @@ func NewPermanentBranchesRouter(
 	ghLaunccher ghlauncher.GHLauncher,
 	permanentbranchesProvider permanentbranches.PermanentBranchesProvider,
 	environmentsProvider environments.EnvironmentsProvider,
+	authorizer rbac.Authorizer,
+	auditProvider audit.AuditProvider,
 ) *permanentBranchesRouter {
-	return &permanentBranchesRouter{ghApp, ghLaunccher, permanentbranchesProvider, environmentsProvider}
+	return &permanentBranchesRouter{
+		ghApp,
+		ghLaunccher,
+		permanentbranchesProvider,
+		environmentsProvider,
+		authorizer,
+		auditProvider,
+	}
 }
 
 func (er *permanentBranchesRouter) AddRoutes(router *gin.RouterGroup) {
-	router.GET("/owner/:owner/repos/:repo/permanent-branches", er.list)
-	router.POST("/owner/:owner/repos/:repo/permanent-branches", er.upsert)
+	router.GET(
+		"/owner/:owner/repos/:repo/permanent-branches",
+		auth.RequireScope(apitokens.ScopeEnvironmentsRead),
+		auth.RequireRole(er.authorizer, rbac.RoleViewer),
+		er.list,
+	)
+	router.POST(
+		"/owner/:owner/repos/:repo/permanent-branches",
+		auth.RequireScope(apitokens.ScopeEnvironmentsLaunch),
+		auth.RequireRole(er.authorizer, rbac.RoleDeveloper),
+		er.upsert,
+	)
 }
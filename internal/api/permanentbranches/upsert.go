@@ -3,12 +3,14 @@ package permanentbranches
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"sync"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/environments"
 	"github.com/ergomake/ergomake/internal/github/ghapp"
 	"github.com/ergomake/ergomake/internal/github/ghlauncher"
@@ -29,33 +31,23 @@ func (pbr *permanentBranchesRouter) upsert(c *gin.Context) {
 
 	owner := c.Param("owner")
 	repoStr := c.Param("repo")
-	if owner == "" || repoStr == "" {
-		c.JSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
-		return
-	}
-
-	isAuthorized, err := auth.IsAuthorized(c, owner, authData)
-	if err != nil {
-		logger.Ctx(c).Err(err).Msg("fail to check for authorization")
-		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-		return
-	}
 
-	if !isAuthorized {
-		c.JSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
-		return
-	}
-
-	client := ghoauth.FromToken(authData.GithubToken)
-	user, _, err := client.GetUser(c)
-	if err != nil {
-		logger.Ctx(c).Err(err).
-			Msg("fail to get authenticated user")
-		c.JSON(
-			http.StatusInternalServerError,
-			http.StatusText(http.StatusInternalServerError),
-		)
-		return
+	var author string
+	if authData.APIToken != nil {
+		author = authData.APIToken.Name
+	} else {
+		client := ghoauth.FromToken(authData.GithubToken)
+		user, _, err := client.GetUser(c)
+		if err != nil {
+			logger.Ctx(c).Err(err).
+				Msg("fail to get authenticated user")
+			c.JSON(
+				http.StatusInternalServerError,
+				http.StatusText(http.StatusInternalServerError),
+			)
+			return
+		}
+		author = user.GetLogin()
 	}
 
 	var body upsertPermanentBranches
@@ -71,6 +63,12 @@ func (pbr *permanentBranchesRouter) upsert(c *gin.Context) {
 		return
 	}
 
+	actor := auth.GetActor(c, authData)
+	if len(branches.Added) > 0 || len(branches.Removed) > 0 {
+		diff := audit.Changes{Added: branches.Added, Removed: branches.Removed}
+		auth.Record(c, pbr.auditProvider, owner, repoStr, audit.ActionPermanentBranchesUpdate, fmt.Sprintf("%s/%s", owner, repoStr), diff)
+	}
+
 	go func() {
 		logCtx := logger.With(logger.Get()).
 			Str("owner", owner).
@@ -90,6 +88,7 @@ func (pbr *permanentBranchesRouter) upsert(c *gin.Context) {
 					Repo:     repoStr,
 					Branch:   branch,
 					PrNumber: nil,
+					Actor:    actor,
 				}
 				err := pbr.environmentsProvider.TerminateEnvironment(ctx, req)
 				if err != nil {
@@ -137,7 +136,7 @@ func (pbr *permanentBranchesRouter) upsert(c *gin.Context) {
 					Repo:        repoStr,
 					Branch:      branchStr,
 					SHA:         sha,
-					Author:      user.GetLogin(),
+					Author:      author,
 					IsPrivate:   isPrivate,
 				}
 				err = pbr.ghLaunccher.LaunchEnvironment(ctx, req)
@@ -0,0 +1,70 @@
+package previewaccess
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/cluster"
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/previewaccess"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type previewAccessRouter struct {
+	db                   *database.DB
+	accessProvider       previewaccess.AccessProvider
+	environmentsProvider environments.EnvironmentsProvider
+	clusterClient        cluster.Client
+	authorizer           rbac.Authorizer
+	auditProvider        audit.AuditProvider
+	jwtSecret            string
+	authURL              string
+	signinURL            string
+}
+
+func NewPreviewAccessRouter(
+	db *database.DB,
+	accessProvider previewaccess.AccessProvider,
+	environmentsProvider environments.EnvironmentsProvider,
+	clusterClient cluster.Client,
+	authorizer rbac.Authorizer,
+	auditProvider audit.AuditProvider,
+	jwtSecret string,
+	authURL string,
+	signinURL string,
+) *previewAccessRouter {
+	return &previewAccessRouter{
+		db,
+		accessProvider,
+		environmentsProvider,
+		clusterClient,
+		authorizer,
+		auditProvider,
+		jwtSecret,
+		authURL,
+		signinURL,
+	}
+}
+
+func (par *previewAccessRouter) AddRoutes(router *gin.RouterGroup) {
+	requireAdmin := auth.RequireRole(par.authorizer, rbac.RoleAdmin)
+
+	router.GET("/owner/:owner/repos/:repo/preview-access", requireAdmin, par.getSettings)
+	router.POST("/owner/:owner/repos/:repo/preview-access", requireAdmin, par.upsertSettings)
+	router.GET("/owner/:owner/repos/:repo/preview-access/bypass-tokens", requireAdmin, par.listBypassTokens)
+	router.POST("/owner/:owner/repos/:repo/preview-access/bypass-tokens", requireAdmin, par.createBypassToken)
+	router.DELETE(
+		"/owner/:owner/repos/:repo/preview-access/bypass-tokens/:tokenID",
+		requireAdmin,
+		par.revokeBypassToken,
+	)
+	router.GET("/environments/:envID/share-links", par.listShareLinks)
+	router.POST("/environments/:envID/share-links", par.createShareLink)
+	router.DELETE("/environments/:envID/share-links/:linkID", par.revokeShareLink)
+
+	// these are called by ingress-nginx and by browsers of people opening protected previews
+	router.GET("/preview-auth/verify", par.verify)
+	router.GET("/preview-auth/signin", par.signin)
+}
@@ -0,0 +1,173 @@
+package previewaccess
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/previewaccess"
+)
+
+type settingsResponse struct {
+	Mode        previewaccess.Mode `json:"mode"`
+	Username    string             `json:"username"`
+	HasPassword bool               `json:"hasPassword"`
+}
+
+type upsertSettingsRequest struct {
+	Mode     previewaccess.Mode `json:"mode"`
+	Username string             `json:"username"`
+	// Password is optional when basic auth already has one
+	Password string `json:"password"`
+}
+
+func (par *previewAccessRouter) getSettings(c *gin.Context) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	settings, err := par.accessProvider.GetSettings(c, owner, repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get preview access settings of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, settingsResponse{settings.Mode, settings.Username, settings.PasswordHash != ""})
+}
+
+func (par *previewAccessRouter) upsertSettings(c *gin.Context) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	var body upsertSettingsRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	if !body.Mode.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-mode"})
+		return
+	}
+
+	if body.Mode != previewaccess.ModePublic && par.authURL == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "preview-auth-not-configured"})
+		return
+	}
+
+	current, err := par.accessProvider.GetSettings(c, owner, repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get preview access settings of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	settings := previewaccess.Settings{
+		Owner:        owner,
+		Repo:         repo,
+		Mode:         body.Mode,
+		Username:     body.Username,
+		PasswordHash: current.PasswordHash,
+	}
+
+	if body.Password != "" {
+		settings.PasswordHash, err = previewaccess.HashPassword(body.Password)
+		if err != nil {
+			logger.Ctx(c).Err(err).Msg("fail to hash preview basic auth password")
+			c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	if settings.Mode == previewaccess.ModeBasicAuth {
+		if settings.Username == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-username"})
+			return
+		}
+
+		if settings.PasswordHash == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-password"})
+			return
+		}
+	}
+
+	err = par.accessProvider.UpsertSettings(c, settings)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to upsert preview access settings of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, par.auditProvider, owner, repo, audit.ActionPreviewAccessUpdate, fmt.Sprintf("%s/%s", owner, repo), gin.H{
+		"mode":            settings.Mode,
+		"username":        settings.Username,
+		"passwordChanged": body.Password != "",
+	})
+
+	// running previews must not stay public until their next deploy
+	err = par.protectRunningPreviews(c, owner, repo, settings.Mode)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to update ingresses of running previews of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, gin.H{"reason": "fail-to-update-running-previews"})
+		return
+	}
+
+	c.JSON(http.StatusOK, settingsResponse{settings.Mode, settings.Username, settings.PasswordHash != ""})
+}
+
+// protectRunningPreviews changes the ingresses of every preview of the repo to
+// match mode, it goes through all of them even when some fail. Services can have
+// more than one ingress, such as the one of their gRPC routes, so they are found
+// by the service label instead of by name.
+func (par *previewAccessRouter) protectRunningPreviews(
+	ctx context.Context,
+	owner, repo string,
+	mode previewaccess.Mode,
+) error {
+	envs, err := par.environmentsProvider.ListEnvironmentsByRepo(ctx, owner, repo)
+	if err != nil {
+		return errors.Wrap(err, "fail to list environments of repo")
+	}
+
+	var errs []error
+	for _, env := range envs {
+		namespace := env.ID.String()
+		for _, svc := range env.Services {
+			if svc.Url == "" && len(svc.Hosts) == 0 {
+				continue
+			}
+
+			ingresses, err := par.clusterClient.ListIngresses(ctx, namespace, map[string]string{
+				"preview.ergomake.dev/service": svc.Name,
+			})
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "fail to list ingresses of service %s of env %s", svc.Name, namespace))
+				continue
+			}
+
+			for _, ingress := range ingresses {
+				previewaccess.ApplyToIngress(ingress, mode, par.authURL, par.signinURL)
+
+				err = par.clusterClient.UpdateIngress(ctx, ingress)
+				if err != nil {
+					errs = append(errs, errors.Wrapf(
+						err,
+						"fail to update ingress %s of service %s of env %s",
+						ingress.GetName(),
+						svc.Name,
+						namespace,
+					))
+				}
+			}
+		}
+	}
+
+	return stderrors.Join(errs...)
+}
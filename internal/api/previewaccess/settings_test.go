@@ -0,0 +1,79 @@
+package previewaccess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/previewaccess"
+	clusterMocks "github.com/ergomake/ergomake/mocks/cluster"
+	environmentsMocks "github.com/ergomake/ergomake/mocks/environments"
+)
+
+func TestPreviewAccessRouter_protectRunningPreviews(t *testing.T) {
+	env := &database.Environment{
+		ID: uuid.New(),
+		Services: []database.Service{
+			{Name: "api", Url: "api-acme-app-7.env.ergomake.test", Hosts: pq.StringArray{"grpc-acme-app-7.env.ergomake.test"}},
+			{Name: "worker"},
+		},
+	}
+	namespace := env.ID.String()
+
+	environmentsProvider := environmentsMocks.NewEnvironmentsProvider(t)
+	environmentsProvider.EXPECT().ListEnvironmentsByRepo(mock.Anything, "acme", "app").
+		Return([]*database.Environment{env}, nil)
+
+	labels := map[string]string{"preview.ergomake.dev/service": "api"}
+	httpIngress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace, Labels: labels, Annotations: labels},
+	}
+	grpcIngress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-grpc",
+			Namespace: namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				"preview.ergomake.dev/service":                 "api",
+				"nginx.ingress.kubernetes.io/backend-protocol": "GRPC",
+			},
+		},
+	}
+
+	clusterClient := clusterMocks.NewClient(t)
+	clusterClient.EXPECT().ListIngresses(mock.Anything, namespace, labels).
+		Return([]*networkingv1.Ingress{httpIngress, grpcIngress}, nil)
+
+	updated := map[string]*networkingv1.Ingress{}
+	clusterClient.EXPECT().UpdateIngress(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, ingress *networkingv1.Ingress) {
+			updated[ingress.GetName()] = ingress
+		}).
+		Return(nil)
+
+	par := &previewAccessRouter{
+		environmentsProvider: environmentsProvider,
+		clusterClient:        clusterClient,
+		authURL:              "https://api.ergomake.test/v2/preview-auth/verify",
+		signinURL:            "https://api.ergomake.test/v2/preview-auth/signin",
+	}
+
+	err := par.protectRunningPreviews(context.Background(), "acme", "app", previewaccess.ModeMembers)
+	require.NoError(t, err)
+
+	require.Len(t, updated, 2)
+	for _, name := range []string{"api", "api-grpc"} {
+		annotations := updated[name].GetAnnotations()
+		assert.Equal(t, par.authURL, annotations["nginx.ingress.kubernetes.io/auth-url"], name)
+		assert.Equal(t, par.signinURL, annotations["nginx.ingress.kubernetes.io/auth-signin"], name)
+	}
+	assert.Equal(t, "GRPC", updated["api-grpc"].GetAnnotations()["nginx.ingress.kubernetes.io/backend-protocol"])
+}
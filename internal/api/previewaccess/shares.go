@@ -0,0 +1,182 @@
+package previewaccess
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/previewaccess"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type createShareLinkRequest struct {
+	Name string `json:"name"`
+	// ExpiresAt defaults to previewaccess.DefaultShareDuration from now
+	ExpiresAt *time.Time `json:"expiresAt"`
+	MaxUses   *int       `json:"maxUses"`
+}
+
+type createShareLinkResponse struct {
+	*previewaccess.ShareLink
+	// Token and URLs are only returned once, when the link is created
+	Token string   `json:"token"`
+	URLs  []string `json:"urls"`
+}
+
+// authorizeEnvironment writes an error response and returns false when the
+// requester isn't a developer of the repo of the :envID param
+func (par *previewAccessRouter) authorizeEnvironment(c *gin.Context) (*auth.AuthData, *database.Environment, bool) {
+	authData, ok := auth.GetAuthData(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		return nil, nil, false
+	}
+
+	envID, err := uuid.Parse(c.Param("envID"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return nil, nil, false
+	}
+
+	env, err := par.db.FindEnvironmentByID(envID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return nil, nil, false
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Str("envID", envID.String()).Msg("fail to find environment by ID")
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return nil, nil, false
+	}
+
+	if !auth.Authorize(c, par.authorizer, env.Owner, env.Repo, rbac.RoleDeveloper) {
+		return nil, nil, false
+	}
+
+	return authData, &env, true
+}
+
+func (par *previewAccessRouter) listShareLinks(c *gin.Context) {
+	_, env, ok := par.authorizeEnvironment(c)
+	if !ok {
+		return
+	}
+
+	links, err := par.accessProvider.ListShareLinks(c, env.ID)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list share links of environment %s", env.ID)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+func (par *previewAccessRouter) createShareLink(c *gin.Context) {
+	authData, env, ok := par.authorizeEnvironment(c)
+	if !ok {
+		return
+	}
+
+	var body createShareLinkRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	if body.Name == "" || len(body.Name) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-name"})
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(previewaccess.DefaultShareDuration)
+	if body.ExpiresAt != nil {
+		expiresAt = *body.ExpiresAt
+	}
+
+	if !expiresAt.After(now) || expiresAt.After(now.Add(previewaccess.MaxShareDuration)) {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-expires-at"})
+		return
+	}
+
+	if body.MaxUses != nil && *body.MaxUses <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-max-uses"})
+		return
+	}
+
+	actor := auth.GetActor(c, authData)
+	link, err := par.accessProvider.CreateShareLink(c, previewaccess.ShareLink{
+		EnvironmentID: env.ID,
+		Owner:         env.Owner,
+		Repo:          env.Repo,
+		Name:          body.Name,
+		CreatedBy:     actor,
+		ExpiresAt:     expiresAt,
+		MaxUses:       body.MaxUses,
+	})
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to create share link for environment %s", env.ID)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, par.auditProvider, env.Owner, env.Repo, audit.ActionShareLinkCreate, link.ID.String(), gin.H{
+		"environmentId": env.ID,
+		"name":          link.Name,
+		"expiresAt":     link.ExpiresAt,
+		"maxUses":       link.MaxUses,
+	})
+
+	token := previewaccess.ShareToken(par.jwtSecret, link.ID)
+	urls := make([]string, 0)
+	for _, svc := range env.Services {
+		if svc.Url == "" {
+			continue
+		}
+
+		urls = append(urls, fmt.Sprintf("https://%s/?%s=%s", svc.Url, previewaccess.ShareQueryParam, url.QueryEscape(token)))
+	}
+
+	c.JSON(http.StatusCreated, createShareLinkResponse{link, token, urls})
+}
+
+func (par *previewAccessRouter) revokeShareLink(c *gin.Context) {
+	_, env, ok := par.authorizeEnvironment(c)
+	if !ok {
+		return
+	}
+
+	linkID, err := uuid.Parse(c.Param("linkID"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	err = par.accessProvider.RevokeShareLink(c, env.ID, linkID)
+	if errors.Is(err, previewaccess.ErrShareLinkNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to revoke share link %s", linkID)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, par.auditProvider, env.Owner, env.Repo, audit.ActionShareLinkRevoke, linkID.String(), nil)
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,113 @@
+package previewaccess
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/github/ghoauth"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/previewaccess"
+)
+
+// signin is where ingress-nginx sends people without a session on previews that only
+// members can see. It goes through the regular GitHub login when needed and sends
+// them back to the preview with a session scoped to its host. Requests that bring
+// the session are denied after it becomes a cookie, they come back here and are
+// sent to the preview without it.
+func (par *previewAccessRouter) signin(c *gin.Context) {
+	rd := c.Query("rd")
+	redirectURL, err := url.Parse(rd)
+	if err != nil || redirectURL.Hostname() == "" ||
+		(redirectURL.Scheme != "https" && redirectURL.Scheme != "http") {
+		c.String(http.StatusBadRequest, "invalid rd")
+		return
+	}
+
+	// only redirect back to previews we know about
+	host := redirectURL.Hostname()
+	env, err := par.environmentsProvider.GetEnvironmentFromHost(c, host)
+	if errors.Is(err, environments.ErrEnvironmentNotFound) {
+		c.String(http.StatusNotFound, "preview not found")
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Str("host", host).Msg("fail to get environment from host")
+		c.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	// the session became a cookie when the preview got it
+	query := redirectURL.Query()
+	if query.Has(previewaccess.SessionQueryParam) {
+		query.Del(previewaccess.SessionQueryParam)
+		redirectURL.RawQuery = query.Encode()
+		c.Redirect(http.StatusTemporaryRedirect, redirectURL.String())
+		return
+	}
+
+	settings, err := par.accessProvider.GetSettings(c, env.Owner, env.Repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Str("host", host).Msg("fail to get preview access settings")
+		c.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	if settings.Mode != previewaccess.ModeMembers {
+		c.Redirect(http.StatusTemporaryRedirect, redirectURL.String())
+		return
+	}
+
+	authData, ok := auth.GetAuthData(c)
+	if !ok {
+		loginPath := strings.TrimSuffix(c.FullPath(), "/preview-auth/signin") + "/auth/login"
+		back := fmt.Sprintf("%s?rd=%s", par.signinURL, url.QueryEscape(rd))
+		c.Redirect(http.StatusTemporaryRedirect, fmt.Sprintf("%s?redirectUrl=%s", loginPath, url.QueryEscape(back)))
+		return
+	}
+
+	isAuthorized, err := auth.IsAuthorized(c, env.Owner, authData)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msg("fail to check for authorization")
+		c.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	if !isAuthorized {
+		c.String(http.StatusForbidden, "This preview is only available to members of %s.", env.Owner)
+		return
+	}
+
+	client := ghoauth.FromToken(authData.GithubToken)
+	login, err := ghoauth.GetLogin(c, client, authData.GithubToken.AccessToken)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msg("fail to get authenticated user")
+		c.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	session, err := previewaccess.NewSession(
+		par.jwtSecret,
+		host,
+		login,
+		time.Now().Add(previewaccess.SessionDuration),
+	)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msg("fail to create preview session")
+		c.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	query.Set(previewaccess.SessionQueryParam, session)
+	redirectURL.RawQuery = query.Encode()
+
+	c.Redirect(http.StatusTemporaryRedirect, redirectURL.String())
+}
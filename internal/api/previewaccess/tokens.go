@@ -0,0 +1,93 @@
+package previewaccess
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/previewaccess"
+)
+
+type createBypassTokenRequest struct {
+	Name string `json:"name"`
+}
+
+type createBypassTokenResponse struct {
+	*previewaccess.BypassToken
+	// Value is only returned once, when the token is created
+	Value  string `json:"value"`
+	Header string `json:"header"`
+}
+
+func (par *previewAccessRouter) listBypassTokens(c *gin.Context) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	tokens, err := par.accessProvider.ListBypassTokens(c, owner, repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list bypass tokens of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (par *previewAccessRouter) createBypassToken(c *gin.Context) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	var body createBypassTokenRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	if body.Name == "" || len(body.Name) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-name"})
+		return
+	}
+
+	token, value, err := par.accessProvider.CreateBypassToken(c, owner, repo, body.Name)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to create bypass token for repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, par.auditProvider, owner, repo, audit.ActionBypassTokenCreate, token.ID.String(), gin.H{"name": token.Name})
+
+	c.JSON(http.StatusCreated, createBypassTokenResponse{token, value, previewaccess.BypassTokenHeader})
+}
+
+func (par *previewAccessRouter) revokeBypassToken(c *gin.Context) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	tokenID, err := uuid.Parse(c.Param("tokenID"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	err = par.accessProvider.RevokeBypassToken(c, owner, repo, tokenID)
+	if errors.Is(err, previewaccess.ErrBypassTokenNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to revoke bypass token %s", tokenID)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, par.auditProvider, owner, repo, audit.ActionBypassTokenRevoke, tokenID.String(), nil)
+
+	c.Status(http.StatusNoContent)
+}
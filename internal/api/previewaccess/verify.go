@@ -0,0 +1,203 @@
+package previewaccess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/previewaccess"
+)
+
+// verify is the ingress-nginx external auth endpoint of protected previews. It answers
+// 2xx to let the request through, 401 to ask for credentials and 403 to deny it.
+func (par *previewAccessRouter) verify(c *gin.Context) {
+	originalURL, err := url.Parse(c.GetHeader("X-Original-URL"))
+	if err != nil || originalURL.Hostname() == "" {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	host := originalURL.Hostname()
+	env, err := par.environmentsProvider.GetEnvironmentFromHost(c, host)
+	if errors.Is(err, environments.ErrEnvironmentNotFound) {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Str("host", host).Msg("fail to get environment from host")
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	settings, err := par.accessProvider.GetSettings(c, env.Owner, env.Repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Str("host", host).Msg("fail to get preview access settings")
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	if settings.Mode == previewaccess.ModePublic {
+		c.Status(http.StatusOK)
+		return
+	}
+
+	if value := c.GetHeader(previewaccess.BypassTokenHeader); value != "" {
+		_, err := par.accessProvider.AuthenticateBypassToken(c, env.Owner, env.Repo, value)
+		if err == nil {
+			c.Status(http.StatusOK)
+			return
+		}
+
+		if !errors.Is(err, previewaccess.ErrBypassTokenNotFound) {
+			logger.Ctx(c).Err(err).Str("host", host).Msg("fail to authenticate bypass token")
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if session, err := c.Cookie(previewaccess.SessionCookieName); err == nil {
+		ok, err := par.checkSession(c, env, settings.Mode, host, session)
+		if err != nil {
+			logger.Ctx(c).Err(err).Str("host", host).Msg("fail to check preview session")
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		if ok {
+			c.Status(http.StatusOK)
+			return
+		}
+	}
+
+	// share links work whatever the protection is, a use is only counted when
+	// there is no session yet, so reloading the shared URL doesn't spend uses
+	if token := originalURL.Query().Get(previewaccess.ShareQueryParam); token != "" {
+		ok, err := par.redeemShareLink(c, env, host, token)
+		if err != nil {
+			logger.Ctx(c).Err(err).Str("host", host).Msg("fail to redeem share link")
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		if ok {
+			c.Status(http.StatusOK)
+			return
+		}
+	}
+
+	switch settings.Mode {
+	case previewaccess.ModeBasicAuth:
+		username, password, ok := c.Request.BasicAuth()
+		if ok && settings.CheckBasicAuth(username, password) {
+			c.Status(http.StatusOK)
+			return
+		}
+
+		c.Header("WWW-Authenticate", fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", host))
+		c.Status(http.StatusUnauthorized)
+	case previewaccess.ModeMembers:
+		// first request after signing in, the session comes in the URL and becomes a
+		// cookie. The request is still denied so that the session doesn't reach the
+		// preview, its logs or the Referer of its pages, nginx sends the browser back
+		// to signin, which removes the session from the URL.
+		session := originalURL.Query().Get(previewaccess.SessionQueryParam)
+		if _, err := previewaccess.ParseSession(par.jwtSecret, host, session); session != "" && err == nil {
+			maxAge := int(previewaccess.SessionDuration.Seconds())
+			c.SetCookie(previewaccess.SessionCookieName, session, maxAge, "/", "", true, true)
+		}
+
+		c.Status(http.StatusUnauthorized)
+	default:
+		c.Status(http.StatusForbidden)
+	}
+}
+
+// redeemShareLink counts a use of the share link of token and sets a session cookie
+// that lasts until the link expires. It returns false when the token is not valid for env.
+func (par *previewAccessRouter) redeemShareLink(
+	c *gin.Context,
+	env *database.Environment,
+	host string,
+	token string,
+) (bool, error) {
+	id, err := previewaccess.ParseShareToken(par.jwtSecret, token)
+	if err != nil {
+		return false, nil
+	}
+
+	link, err := par.accessProvider.GetShareLink(c, id)
+	if errors.Is(err, previewaccess.ErrShareLinkNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrap(err, "fail to get share link")
+	}
+
+	// checked before redeeming so links of other environments don't lose uses
+	if link.EnvironmentID != env.ID {
+		return false, nil
+	}
+
+	link, err = par.accessProvider.RedeemShareLink(c, id)
+	if errors.Is(err, previewaccess.ErrShareLinkNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrap(err, "fail to redeem share link")
+	}
+
+	now := time.Now()
+	expiresAt := link.SessionExpiry(now)
+	session, err := previewaccess.NewSession(par.jwtSecret, host, previewaccess.ShareSubject(link.ID), expiresAt)
+	if err != nil {
+		return false, errors.Wrap(err, "fail to create preview session")
+	}
+
+	maxAge := int(expiresAt.Sub(now).Seconds())
+	c.SetCookie(previewaccess.SessionCookieName, session, maxAge, "/", "", true, true)
+
+	return true, nil
+}
+
+// checkSession tells whether a session cookie lets its holder in. Sessions of share
+// links are valid in every protected mode while their link is, sessions of members
+// only when the previews are restricted to members.
+func (par *previewAccessRouter) checkSession(
+	ctx context.Context,
+	env *database.Environment,
+	mode previewaccess.Mode,
+	host string,
+	session string,
+) (bool, error) {
+	subject, err := previewaccess.ParseSession(par.jwtSecret, host, session)
+	if err != nil {
+		return false, nil
+	}
+
+	linkID, isShare := previewaccess.ShareLinkIDFromSubject(subject)
+	if !isShare {
+		return mode == previewaccess.ModeMembers, nil
+	}
+
+	link, err := par.accessProvider.GetShareLink(ctx, linkID)
+	if errors.Is(err, previewaccess.ErrShareLinkNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrap(err, "fail to get share link")
+	}
+
+	return link.EnvironmentID == env.ID && !link.IsExpired(time.Now()), nil
+}
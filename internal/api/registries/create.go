@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/logger"
 )
 
@@ -16,17 +17,7 @@ type createRegistry struct {
 }
 
 func (rr *registriesRouter) create(c *gin.Context) {
-	authData, ok := auth.GetAuthData(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
-		return
-	}
-
 	owner := c.Param("owner")
-	if owner == "" {
-		c.JSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
-		return
-	}
 
 	var body createRegistry
 	if err := c.ShouldBindJSON(&body); err != nil {
@@ -34,24 +25,14 @@ func (rr *registriesRouter) create(c *gin.Context) {
 		return
 	}
 
-	isAuthorized, err := auth.IsAuthorized(c, owner, authData)
+	err := rr.privRegistryProvider.StoreRegistry(c, owner, body.URL, body.Provider, body.Credentials)
 	if err != nil {
 		logger.Ctx(c).Err(err).Msg("fail to create registry")
 		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
 
-	if !isAuthorized {
-		c.JSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
-		return
-	}
-
-	err = rr.privRegistryProvider.StoreRegistry(c, owner, body.URL, body.Provider, body.Credentials)
-	if err != nil {
-		logger.Ctx(c).Err(err).Msg("fail to create registry")
-		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-		return
-	}
+	auth.Record(c, rr.auditProvider, owner, "", audit.ActionRegistryCreate, body.URL, map[string]string{"provider": body.Provider})
 
 	c.JSON(http.StatusCreated, nil)
 }
@@ -7,32 +7,15 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/logger"
 )
 
 func (rr *registriesRouter) del(c *gin.Context) {
-	authData, ok := auth.GetAuthData(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
-		return
-	}
-
 	owner := c.Param("owner")
 	registryID, err := uuid.Parse(c.Param("registryID"))
-	if owner == "" || err != nil {
-		c.JSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
-		return
-	}
-
-	isAuthorized, err := auth.IsAuthorized(c, owner, authData)
 	if err != nil {
-		logger.Ctx(c).Err(err).Msg("fail to create registry")
-		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-		return
-	}
-
-	if !isAuthorized {
-		c.JSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		c.JSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
 		return
 	}
 
@@ -43,5 +26,7 @@ func (rr *registriesRouter) del(c *gin.Context) {
 		return
 	}
 
+	auth.Record(c, rr.auditProvider, owner, "", audit.ActionRegistryDelete, registryID.String(), nil)
+
 	c.JSON(http.StatusCreated, nil)
 }
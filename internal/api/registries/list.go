@@ -5,34 +5,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 
-	"github.com/ergomake/ergomake/internal/api/auth"
 	"github.com/ergomake/ergomake/internal/logger"
 )
 
 func (rr *registriesRouter) list(c *gin.Context) {
-	authData, ok := auth.GetAuthData(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
-		return
-	}
-
 	owner := c.Param("owner")
-	if owner == "" {
-		c.JSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
-		return
-	}
-
-	isAuthorized, err := auth.IsAuthorized(c, owner, authData)
-	if err != nil {
-		logger.Ctx(c).Err(err).Msg("fail to list registries")
-		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-		return
-	}
-
-	if !isAuthorized {
-		c.JSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
-		return
-	}
 
 	creds, err := rr.privRegistryProvider.ListCredsByOwner(c, owner, true)
 	if err != nil {
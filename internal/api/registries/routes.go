@@ -3,19 +3,30 @@ package registries
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/privregistry"
+	"github.com/ergomake/ergomake/internal/rbac"
 )
 
 type registriesRouter struct {
 	privRegistryProvider privregistry.PrivRegistryProvider
+	authorizer           rbac.Authorizer
+	auditProvider        audit.AuditProvider
 }
 
-func NewRegistriesRouter(privRegistryProvider privregistry.PrivRegistryProvider) *registriesRouter {
-	return &registriesRouter{privRegistryProvider}
+func NewRegistriesRouter(
+	privRegistryProvider privregistry.PrivRegistryProvider,
+	authorizer rbac.Authorizer,
+	auditProvider audit.AuditProvider,
+) *registriesRouter {
+	return &registriesRouter{privRegistryProvider, authorizer, auditProvider}
 }
 
 func (rr *registriesRouter) AddRoutes(router *gin.RouterGroup) {
-	router.POST("/owner/:owner/registries", rr.create)
-	router.GET("/owner/:owner/registries", rr.list)
-	router.DELETE("/owner/:owner/registries/:registryID", rr.del)
+	requireAdmin := auth.RequireRole(rr.authorizer, rbac.RoleAdmin)
+
+	router.POST("/owner/:owner/registries", requireAdmin, rr.create)
+	router.GET("/owner/:owner/registries", auth.RequireRole(rr.authorizer, rbac.RoleViewer), rr.list)
+	router.DELETE("/owner/:owner/registries/:registryID", requireAdmin, rr.del)
 }
@@ -0,0 +1,40 @@
+package roles
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+func (rr *rolesRouter) del(c *gin.Context) {
+	owner := c.Param("owner")
+
+	assignmentID, err := uuid.Parse(c.Param("assignmentID"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	err = rr.rbacProvider.DeleteAssignment(c, owner, assignmentID)
+	if errors.Is(err, rbac.ErrAssignmentNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to delete role assignment %s", assignmentID)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, rr.auditProvider, owner, "", audit.ActionRoleUnassign, assignmentID.String(), nil)
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,57 @@
+package roles
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/github/ghoauth"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+// getOwnRole returns the role of the requester, it is what the dashboard uses to hide
+// actions the requester is not allowed to perform. The optional repo query param is
+// considered when the owner maps roles from repository permissions.
+func (rr *rolesRouter) getOwnRole(c *gin.Context) {
+	authData, ok := auth.GetAuthData(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		return
+	}
+
+	owner := c.Param("owner")
+	if owner == "" {
+		c.JSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	isAuthorized, err := auth.IsAuthorized(c, owner, authData)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msg("fail to check for authorization")
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	if !isAuthorized {
+		c.JSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	client := ghoauth.FromToken(authData.GithubToken)
+	login, err := ghoauth.GetLogin(c, client, authData.GithubToken.AccessToken)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msg("fail to get authenticated user")
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	role, err := rr.authorizer.GetRole(c, owner, c.Query("repo"), login)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get role of %s on %s", login, owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"login": login, "role": role})
+}
@@ -0,0 +1,22 @@
+package roles
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+func (rr *rolesRouter) list(c *gin.Context) {
+	owner := c.Param("owner")
+
+	assignments, err := rr.rbacProvider.ListAssignments(c, owner)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list role assignments of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
@@ -0,0 +1,34 @@
+package roles
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type rolesRouter struct {
+	rbacProvider  rbac.RBACProvider
+	authorizer    rbac.Authorizer
+	auditProvider audit.AuditProvider
+}
+
+func NewRolesRouter(
+	rbacProvider rbac.RBACProvider,
+	authorizer rbac.Authorizer,
+	auditProvider audit.AuditProvider,
+) *rolesRouter {
+	return &rolesRouter{rbacProvider, authorizer, auditProvider}
+}
+
+func (rr *rolesRouter) AddRoutes(router *gin.RouterGroup) {
+	requireAdmin := auth.RequireRole(rr.authorizer, rbac.RoleAdmin)
+
+	router.GET("/owner/:owner/role", rr.getOwnRole)
+	router.GET("/owner/:owner/roles", requireAdmin, rr.list)
+	router.POST("/owner/:owner/roles", requireAdmin, rr.upsert)
+	router.DELETE("/owner/:owner/roles/:assignmentID", requireAdmin, rr.del)
+	router.GET("/owner/:owner/role-settings", requireAdmin, rr.getSettings)
+	router.POST("/owner/:owner/role-settings", requireAdmin, rr.upsertSettings)
+}
@@ -0,0 +1,51 @@
+package roles
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+func (rr *rolesRouter) getSettings(c *gin.Context) {
+	owner := c.Param("owner")
+
+	settings, err := rr.rbacProvider.GetSettings(c, owner)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get rbac settings of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (rr *rolesRouter) upsertSettings(c *gin.Context) {
+	owner := c.Param("owner")
+
+	var body rbac.Settings
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	if !body.DefaultRole.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-role"})
+		return
+	}
+
+	err := rr.rbacProvider.UpsertSettings(c, owner, body)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to upsert rbac settings of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, rr.auditProvider, owner, "", audit.ActionRoleSettingsUpdate, owner, body)
+
+	c.JSON(http.StatusOK, body)
+}
@@ -0,0 +1,59 @@
+package roles
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/rbac"
+)
+
+type upsertAssignmentRequest struct {
+	Kind    string    `json:"kind"`
+	Subject string    `json:"subject"`
+	Role    rbac.Role `json:"role"`
+}
+
+func (rr *rolesRouter) upsert(c *gin.Context) {
+	owner := c.Param("owner")
+
+	var body upsertAssignmentRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	if body.Kind != rbac.AssignmentKindUser && body.Kind != rbac.AssignmentKindTeam {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-kind"})
+		return
+	}
+
+	if body.Subject == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-subject"})
+		return
+	}
+
+	if !body.Role.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-role"})
+		return
+	}
+
+	assignment, err := rr.rbacProvider.UpsertAssignment(c, rbac.Assignment{
+		Owner:   owner,
+		Kind:    body.Kind,
+		Subject: body.Subject,
+		Role:    body.Role,
+	})
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to upsert role assignment for owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, rr.auditProvider, owner, "", audit.ActionRoleAssign, body.Kind+":"+body.Subject, gin.H{"role": body.Role})
+
+	c.JSON(http.StatusOK, assignment)
+}
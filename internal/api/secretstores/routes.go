@@ -0,0 +1,32 @@
+package secretstores
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/rbac"
+	"github.com/ergomake/ergomake/internal/secretstores"
+)
+
+type secretStoresRouter struct {
+	configProvider secretstores.ConfigProvider
+	authorizer     rbac.Authorizer
+	auditProvider  audit.AuditProvider
+}
+
+func NewSecretStoresRouter(
+	configProvider secretstores.ConfigProvider,
+	authorizer rbac.Authorizer,
+	auditProvider audit.AuditProvider,
+) *secretStoresRouter {
+	return &secretStoresRouter{configProvider, authorizer, auditProvider}
+}
+
+func (sr *secretStoresRouter) AddRoutes(router *gin.RouterGroup) {
+	requireAdmin := auth.RequireRole(sr.authorizer, rbac.RoleAdmin)
+
+	router.GET("/owner/:owner/secret-store", requireAdmin, sr.get)
+	router.POST("/owner/:owner/secret-store", requireAdmin, sr.upsert)
+	router.DELETE("/owner/:owner/secret-store", requireAdmin, sr.delete)
+}
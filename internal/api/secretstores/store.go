@@ -0,0 +1,153 @@
+package secretstores
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/publicnet"
+	"github.com/ergomake/ergomake/internal/secretstores"
+)
+
+type upsertSecretStoreRequest struct {
+	Kind    secretstores.Kind `json:"kind"`
+	Address string            `json:"address"`
+	Region  string            `json:"region"`
+	// Credentials can be left empty to keep the current ones when kind, address
+	// and region don't change
+	Credentials string `json:"credentials"`
+	Path        string `json:"path"`
+}
+
+func (sr *secretStoresRouter) get(c *gin.Context) {
+	owner := c.Param("owner")
+
+	config, err := sr.configProvider.Get(c, owner)
+	if errors.Is(err, secretstores.ErrConfigNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get secret store of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+func (sr *secretStoresRouter) upsert(c *gin.Context) {
+	owner := c.Param("owner")
+
+	var body upsertSecretStoreRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	config := secretstores.Config{
+		Owner:       owner,
+		Kind:        body.Kind,
+		Address:     strings.TrimSpace(body.Address),
+		Region:      strings.TrimSpace(body.Region),
+		Credentials: body.Credentials,
+		Path:        strings.Trim(strings.TrimSpace(body.Path), "/"),
+	}
+
+	if reason := validateConfig(&config); reason != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": reason})
+		return
+	}
+
+	if config.Credentials == "" {
+		current, err := sr.configProvider.Get(c, owner)
+		if err != nil && !errors.Is(err, secretstores.ErrConfigNotFound) {
+			logger.Ctx(c).Err(err).Msgf("fail to get secret store of owner %s", owner)
+			c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		// credentials are only kept for the store they were given for, otherwise
+		// changing the address would send them to another server
+		if current == nil || current.Kind != config.Kind ||
+			current.Address != config.Address || current.Region != config.Region {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "missing-credentials"})
+			return
+		}
+		config.Credentials = current.Credentials
+	}
+
+	if config.Kind == secretstores.KindAWSSecretsManager && !strings.Contains(config.Credentials, ":") {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-credentials"})
+		return
+	}
+
+	saved, err := sr.configProvider.Upsert(c, config)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to upsert secret store of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, sr.auditProvider, owner, "", audit.ActionSecretStoreUpdate, string(saved.Kind), gin.H{
+		"address": saved.Address,
+		"region":  saved.Region,
+		"path":    saved.Path,
+	})
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// validateConfig returns the reason why config is invalid, or an empty string
+func validateConfig(config *secretstores.Config) string {
+	switch config.Kind {
+	case secretstores.KindVault:
+		err := secretstores.ValidateVaultAddress(config.Address)
+		if errors.Is(err, publicnet.ErrForbiddenAddress) {
+			return "forbidden-address"
+		}
+		if err != nil {
+			return "invalid-address"
+		}
+		config.Region = ""
+	case secretstores.KindAWSSecretsManager:
+		if config.Region == "" {
+			return "invalid-region"
+		}
+		config.Address = ""
+	default:
+		return "invalid-kind"
+	}
+
+	if _, err := config.DefaultsPath("repo", "branch"); err != nil {
+		return "invalid-path"
+	}
+
+	return ""
+}
+
+func (sr *secretStoresRouter) delete(c *gin.Context) {
+	owner := c.Param("owner")
+
+	err := sr.configProvider.Delete(c, owner)
+	if errors.Is(err, secretstores.ErrConfigNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to delete secret store of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, sr.auditProvider, owner, "", audit.ActionSecretStoreDelete, owner, nil)
+
+	c.Status(http.StatusNoContent)
+}
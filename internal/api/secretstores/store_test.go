@@ -0,0 +1,72 @@
+package secretstores
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ergomake/ergomake/internal/secretstores"
+	secretstoresMocks "github.com/ergomake/ergomake/mocks/secretstores"
+)
+
+func TestSecretStoresRouter_upsertKeepsCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	current := &secretstores.Config{
+		Owner:       "acme",
+		Kind:        secretstores.KindVault,
+		Address:     "https://vault.acme.dev",
+		Credentials: "token",
+	}
+
+	tt := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "same address",
+			body:       `{"kind":"vault","address":"https://vault.acme.dev","path":"kv/app"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "changed address",
+			body:       `{"kind":"vault","address":"https://vault.attacker.dev"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "changed kind",
+			body:       `{"kind":"aws-secrets-manager","region":"us-east-1"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			configProvider := secretstoresMocks.NewConfigProvider(t)
+			configProvider.EXPECT().Get(mock.Anything, "acme").Return(current, nil)
+			if tc.wantStatus == http.StatusOK {
+				configProvider.EXPECT().
+					Upsert(mock.Anything, mock.MatchedBy(func(config secretstores.Config) bool {
+						return config.Credentials == current.Credentials
+					})).
+					Return(current, nil)
+			}
+
+			sr := &secretStoresRouter{configProvider: configProvider}
+			router := gin.New()
+			router.POST("/owner/:owner/secret-store", sr.upsert)
+
+			req := httptest.NewRequest(http.MethodPost, "/owner/acme/secret-store", bytes.NewBufferString(tc.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package variablegroups
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/variablegroups"
+)
+
+type upsertGroupRequest struct {
+	Variables []variablegroups.Variable `json:"variables"`
+	// Redeploy relaunches the permanent branches of the repos the group is attached to
+	Redeploy bool `json:"redeploy"`
+}
+
+type upsertGroupResponse struct {
+	*variablegroups.Group
+	Redeploying []redeployment `json:"redeploying"`
+}
+
+func (gr *variableGroupsRouter) list(c *gin.Context) {
+	owner := c.Param("owner")
+
+	groups, err := gr.groupsProvider.List(c, owner)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list variable groups of owner %s", owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, groups)
+}
+
+func (gr *variableGroupsRouter) get(c *gin.Context) {
+	owner := c.Param("owner")
+
+	name := c.Param("name")
+	group, err := gr.groupsProvider.Get(c, owner, name)
+	if errors.Is(err, variablegroups.ErrGroupNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to get variable group %s of owner %s", name, owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (gr *variableGroupsRouter) upsert(c *gin.Context) {
+	owner := c.Param("owner")
+
+	name := c.Param("name")
+	if !variablegroups.IsValidName(name) {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-group-name"})
+		return
+	}
+
+	var body upsertGroupRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	after := make(map[string]string, len(body.Variables))
+	for _, v := range body.Variables {
+		if v.Name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-variable-name"})
+			return
+		}
+
+		if _, ok := after[v.Name]; ok {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "duplicate-variable", "message": v.Name})
+			return
+		}
+		after[v.Name] = v.Value
+	}
+
+	before := map[string]string{}
+	current, err := gr.groupsProvider.Get(c, owner, name)
+	if err != nil && !errors.Is(err, variablegroups.ErrGroupNotFound) {
+		logger.Ctx(c).Err(err).Msgf("fail to get variable group %s of owner %s", name, owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	if current != nil {
+		for _, v := range current.Variables {
+			before[v.Name] = v.Value
+		}
+	}
+
+	group, err := gr.groupsProvider.Upsert(c, owner, name, body.Variables)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to upsert variable group %s of owner %s", name, owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	changes := audit.DiffKeys(before, after)
+	if current == nil || !changes.IsEmpty() {
+		auth.Record(c, gr.auditProvider, owner, "", audit.ActionVariableGroupUpdate, fmt.Sprintf("%s/%s", owner, name), changes)
+	}
+
+	redeploying := []redeployment{}
+	if body.Redeploy && !changes.IsEmpty() {
+		authData, _ := auth.GetAuthData(c)
+		redeploying, err = gr.redeploy(c, owner, group.Repos, auth.GetActor(c, authData))
+		if err != nil {
+			logger.Ctx(c).Err(err).Msgf("fail to redeploy environments of variable group %s of owner %s", name, owner)
+			c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, upsertGroupResponse{group, redeploying})
+}
+
+func (gr *variableGroupsRouter) delete(c *gin.Context) {
+	owner := c.Param("owner")
+
+	name := c.Param("name")
+	err := gr.groupsProvider.Delete(c, owner, name)
+	if errors.Is(err, variablegroups.ErrGroupNotFound) {
+		c.JSON(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to delete variable group %s of owner %s", name, owner)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	auth.Record(c, gr.auditProvider, owner, "", audit.ActionVariableGroupDelete, fmt.Sprintf("%s/%s", owner, name), nil)
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,84 @@
+package variablegroups
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/github/ghapp"
+	"github.com/ergomake/ergomake/internal/github/ghlauncher"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+type redeployment struct {
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"`
+}
+
+// redeploy relaunches the permanent branches of repos in the background so
+// that they pick up new variables, it returns what is going to be relaunched
+func (gr *variableGroupsRouter) redeploy(ctx context.Context, owner string, repos []string, author string) ([]redeployment, error) {
+	redeployments := []redeployment{}
+	for _, repo := range repos {
+		branches, err := gr.permanentBranchesProvider.List(ctx, owner, repo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to list permanent branches of %s/%s", owner, repo)
+		}
+
+		for _, branch := range branches {
+			redeployments = append(redeployments, redeployment{repo, branch})
+		}
+	}
+
+	go func() {
+		logCtx := logger.With(logger.Get()).Str("owner", owner).Logger()
+		log := &logCtx
+		ctx := log.WithContext(context.Background())
+
+		var wg sync.WaitGroup
+		for _, r := range redeployments {
+			wg.Add(1)
+			go func(r redeployment) {
+				defer wg.Done()
+
+				sha, err := gr.ghApp.GetBranchSHA(ctx, owner, r.Repo, r.Branch)
+				if errors.Is(err, ghapp.BranchNotFoundError) {
+					return
+				}
+
+				if err != nil {
+					log.Err(err).Str("repo", r.Repo).Str("branch", r.Branch).Msg("fail to get branch sha")
+					return
+				}
+
+				isPrivate, err := gr.ghApp.IsRepoPrivate(ctx, owner, r.Repo)
+				if errors.Is(err, ghapp.RepoNotFoundError) {
+					return
+				}
+
+				if err != nil {
+					log.Err(err).Str("repo", r.Repo).Str("branch", r.Branch).Msg("fail to check if repo is private")
+					return
+				}
+
+				err = gr.ghLauncher.LaunchEnvironment(ctx, ghlauncher.LaunchEnvironmentRequest{
+					Owner:       owner,
+					BranchOwner: owner,
+					Repo:        r.Repo,
+					Branch:      r.Branch,
+					SHA:         sha,
+					Author:      author,
+					IsPrivate:   isPrivate,
+				})
+				if err != nil {
+					log.Err(err).Str("repo", r.Repo).Str("branch", r.Branch).
+						Msg("fail to relaunch environment after variable group was updated")
+				}
+			}(r)
+		}
+		wg.Wait()
+	}()
+
+	return redeployments, nil
+}
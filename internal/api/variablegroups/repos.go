@@ -0,0 +1,87 @@
+package variablegroups
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/rbac"
+	"github.com/ergomake/ergomake/internal/variablegroups"
+)
+
+type setRepoGroupsRequest struct {
+	Groups []string `json:"groups"`
+}
+
+// setRepoGroups attaches groups to a repo, it is restricted to owner admins
+// since attaching a group hands its values to everyone who can deploy the repo
+func (gr *variableGroupsRouter) setRepoGroups(c *gin.Context) {
+	owner := c.Param("owner")
+	if !auth.Authorize(c, gr.authorizer, owner, "", rbac.RoleAdmin) {
+		return
+	}
+
+	repo := c.Param("repo")
+	if repo == "" {
+		c.JSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	var body setRepoGroupsRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return
+	}
+
+	current, err := gr.groupsProvider.ListByRepo(c, owner, repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list variable groups of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	err = gr.groupsProvider.SetRepoGroups(c, owner, repo, body.Groups)
+	if errors.Is(err, variablegroups.ErrGroupNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "group-not-found", "message": err.Error()})
+		return
+	}
+
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to set variable groups of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	before := make(map[string]string, len(current))
+	for _, g := range current {
+		before[g.Name] = ""
+	}
+	after := make(map[string]string, len(body.Groups))
+	for _, name := range body.Groups {
+		after[name] = ""
+	}
+
+	changes := audit.DiffKeys(before, after)
+	if !changes.IsEmpty() {
+		auth.Record(c, gr.auditProvider, owner, "", audit.ActionVariableGroupsAttach, fmt.Sprintf("%s/%s", owner, repo), changes)
+	}
+
+	groups, err := gr.groupsProvider.ListByRepo(c, owner, repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list variable groups of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"groups": names})
+}
@@ -0,0 +1,50 @@
+package variablegroups
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
+	"github.com/ergomake/ergomake/internal/github/ghapp"
+	"github.com/ergomake/ergomake/internal/github/ghlauncher"
+	"github.com/ergomake/ergomake/internal/permanentbranches"
+	"github.com/ergomake/ergomake/internal/rbac"
+	"github.com/ergomake/ergomake/internal/variablegroups"
+)
+
+type variableGroupsRouter struct {
+	groupsProvider            variablegroups.GroupsProvider
+	permanentBranchesProvider permanentbranches.PermanentBranchesProvider
+	ghApp                     ghapp.GHAppClient
+	ghLauncher                ghlauncher.GHLauncher
+	authorizer                rbac.Authorizer
+	auditProvider             audit.AuditProvider
+}
+
+func NewVariableGroupsRouter(
+	groupsProvider variablegroups.GroupsProvider,
+	permanentBranchesProvider permanentbranches.PermanentBranchesProvider,
+	ghApp ghapp.GHAppClient,
+	ghLauncher ghlauncher.GHLauncher,
+	authorizer rbac.Authorizer,
+	auditProvider audit.AuditProvider,
+) *variableGroupsRouter {
+	return &variableGroupsRouter{
+		groupsProvider,
+		permanentBranchesProvider,
+		ghApp,
+		ghLauncher,
+		authorizer,
+		auditProvider,
+	}
+}
+
+func (gr *variableGroupsRouter) AddRoutes(router *gin.RouterGroup) {
+	requireAdmin := auth.RequireRole(gr.authorizer, rbac.RoleAdmin)
+
+	router.GET("/owner/:owner/variable-groups", requireAdmin, gr.list)
+	router.GET("/owner/:owner/variable-groups/:name", requireAdmin, gr.get)
+	router.PUT("/owner/:owner/variable-groups/:name", requireAdmin, gr.upsert)
+	router.DELETE("/owner/:owner/variable-groups/:name", requireAdmin, gr.delete)
+	router.PUT("/owner/:owner/repos/:repo/variable-groups", gr.setRepoGroups)
+}
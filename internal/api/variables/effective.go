@@ -0,0 +1,55 @@
+package variables
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ergomake/ergomake/internal/envvars"
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+// effective shows which variables a service gets when deployed from a branch,
+// values are masked. The environmentType query param defaults to pull-request.
+func (vr *variablesRouter) effective(c *gin.Context) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	target := envvars.Target{
+		Branch:          c.Query("branch"),
+		Service:         c.Query("service"),
+		EnvironmentType: envvars.EnvironmentType(c.DefaultQuery("environmentType", string(envvars.EnvironmentTypePullRequest))),
+	}
+	if target.Branch == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "missing-branch"})
+		return
+	}
+
+	if !target.EnvironmentType.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-environment-type"})
+		return
+	}
+
+	// resolving from the stored variables keeps references to secret stores
+	// unresolved, secrets only leave the stores when deploying
+	variables, err := vr.envVarsProvider.ListByRepo(c, owner, repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list variables for repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	groups, err := vr.groupsProvider.ListByRepo(c, owner, repo)
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to list variable groups of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	effective := envvars.MergeGroups(envvars.Effective(variables, target), groups)
+	for i := range effective {
+		effective[i] = envvars.Mask(effective[i])
+	}
+
+	c.JSON(http.StatusOK, effective)
+}
@@ -5,36 +5,21 @@ import (
 
 	"github.com/gin-gonic/gin"
 
-	"github.com/ergomake/ergomake/internal/api/auth"
 	"github.com/ergomake/ergomake/internal/logger"
 )
 
 func (vr *variablesRouter) list(c *gin.Context) {
-	authData, ok := auth.GetAuthData(c)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
-		return
-	}
-
 	owner := c.Param("owner")
 	repo := c.Param("repo")
-	if owner == "" || repo == "" {
-		c.JSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
-		return
-	}
 
-	isAuthorized, err := auth.IsAuthorized(c, owner, authData)
+	// the version is read first so that a change in between makes the ETag stale
+	version, err := vr.envVarsProvider.Version(c, owner, repo)
 	if err != nil {
-		logger.Ctx(c).Err(err).Msg("fail to list variables")
+		logger.Ctx(c).Err(err).Msgf("fail to get variables version for repo %s/%s", owner, repo)
 		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
 
-	if !isAuthorized {
-		c.JSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
-		return
-	}
-
 	variables, err := vr.envVarsProvider.ListByRepo(c, owner, repo)
 	if err != nil {
 		logger.Ctx(c).Err(err).Msgf("fail to list variables for repo %s/%s", owner, repo)
@@ -42,5 +27,6 @@ func (vr *variablesRouter) list(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", formatETag(version))
 	c.JSON(http.StatusOK, variables)
 }
@@ -3,18 +3,40 @@ package variables
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/apitokens"
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/envvars"
+	"github.com/ergomake/ergomake/internal/rbac"
+	"github.com/ergomake/ergomake/internal/variablegroups"
 )
 
 type variablesRouter struct {
 	envVarsProvider envvars.EnvVarsProvider
+	groupsProvider  variablegroups.GroupsProvider
+	authorizer      rbac.Authorizer
+	auditProvider   audit.AuditProvider
 }
 
-func NewVariablesRouter(envVarsProvider envvars.EnvVarsProvider) *variablesRouter {
-	return &variablesRouter{envVarsProvider}
+func NewVariablesRouter(
+	envVarsProvider envvars.EnvVarsProvider,
+	groupsProvider variablegroups.GroupsProvider,
+	authorizer rbac.Authorizer,
+	auditProvider audit.AuditProvider,
+) *variablesRouter {
+	return &variablesRouter{envVarsProvider, groupsProvider, authorizer, auditProvider}
 }
 
 func (er *variablesRouter) AddRoutes(router *gin.RouterGroup) {
-	router.GET("/owner/:owner/repos/:repo/variables", er.list)
-	router.POST("/owner/:owner/repos/:repo/variables", er.upsert)
+	variables := router.Group(
+		"/owner/:owner/repos/:repo/variables",
+		auth.RequireScope(apitokens.ScopeVariablesManage),
+		auth.RequireRole(er.authorizer, rbac.RoleDeveloper),
+	)
+
+	variables.GET("", er.list)
+	variables.POST("", er.upsert)
+	variables.PUT("", er.replace)
+	variables.PATCH("", er.patch)
+	variables.GET("/effective", er.effective)
 }
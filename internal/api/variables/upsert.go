@@ -3,86 +3,170 @@ package variables
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 
 	"github.com/ergomake/ergomake/internal/api/auth"
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/envvars"
 	"github.com/ergomake/ergomake/internal/logger"
 )
 
+type changeResponse struct {
+	Variables []envvars.EnvVar `json:"variables"`
+	Changes   audit.Changes    `json:"changes"`
+	Version   int64            `json:"version"`
+}
+
+type patchRequest struct {
+	Upsert []envvars.EnvVar `json:"upsert"`
+	// Remove only needs the name and scope of the variables
+	Remove []envvars.EnvVar `json:"remove"`
+}
+
+// upsert replaces the variables of the repo like replace does but responds with
+// the variables only, which is what the dashboard expects
 func (vr *variablesRouter) upsert(c *gin.Context) {
-	authData, ok := auth.GetAuthData(c)
+	res, ok := vr.replaceWithBody(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 		return
 	}
 
-	owner := c.Param("owner")
-	repo := c.Param("repo")
-	if owner == "" || repo == "" {
-		c.JSON(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
-		return
-	}
+	c.JSON(http.StatusOK, res.Variables)
+}
 
-	isAuthorized, err := auth.IsAuthorized(c, owner, authData)
-	if err != nil {
-		logger.Ctx(c).Err(err).Msg("fail to check for authorization")
-		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+// replace makes the body the only variables of the repo
+func (vr *variablesRouter) replace(c *gin.Context) {
+	res, ok := vr.replaceWithBody(c)
+	if !ok {
 		return
 	}
 
-	if !isAuthorized {
-		c.JSON(http.StatusForbidden, http.StatusText(http.StatusForbidden))
-		return
+	c.JSON(http.StatusOK, res)
+}
+
+// replaceWithBody requires If-Match since it overwrites every variable, writers
+// that really mean to drop changes they haven't seen can send `If-Match: *`
+func (vr *variablesRouter) replaceWithBody(c *gin.Context) (*changeResponse, bool) {
+	if strings.TrimSpace(c.GetHeader("If-Match")) == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"reason": "missing-if-match"})
+		return nil, false
 	}
 
 	var body []envvars.EnvVar
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
+		return nil, false
+	}
+
+	return vr.change(c, body, func(owner, repo string, version *int64) (*envvars.ChangeResult, error) {
+		return vr.envVarsProvider.Replace(c, owner, repo, body, version)
+	})
+}
+
+// patch upserts and removes single variables, leaving the others untouched
+func (vr *variablesRouter) patch(c *gin.Context) {
+	var body patchRequest
 	if err := c.ShouldBindJSON(&body); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"reason": "malformed-payload"})
 		return
 	}
 
-	existingList, err := vr.envVarsProvider.ListByRepo(c, owner, repo)
-	if err != nil {
-		logger.Ctx(c).Err(err).Msgf("fail to list variables for repo %s/%s", owner, repo)
-		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	vars := append(append([]envvars.EnvVar{}, body.Upsert...), body.Remove...)
+	res, ok := vr.change(c, vars, func(owner, repo string, version *int64) (*envvars.ChangeResult, error) {
+		return vr.envVarsProvider.Patch(c, owner, repo, body.Upsert, body.Remove, version)
+	})
+	if !ok {
 		return
 	}
 
-	toKeep := make(map[string]bool)
-	for _, v := range body {
-		err := vr.envVarsProvider.Upsert(c, owner, repo, v.Name, v.Value, v.Branch)
-		if err != nil {
-			logger.Ctx(c).Err(err).Msgf("fail to upsert variable %s", v.Name)
-			c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-			return
+	c.JSON(http.StatusOK, res)
+}
+
+// change validates vars and runs apply with the version of the If-Match header,
+// writing an error response and returning false on failure
+func (vr *variablesRouter) change(
+	c *gin.Context,
+	vars []envvars.EnvVar,
+	apply func(owner, repo string, version *int64) (*envvars.ChangeResult, error),
+) (*changeResponse, bool) {
+	owner := c.Param("owner")
+	repo := c.Param("repo")
+
+	for _, v := range vars {
+		if v.Name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-variable-name"})
+			return nil, false
 		}
 
-		branch := ""
-		if v.Branch != nil {
-			branch = *v.Branch
+		if err := v.Scope.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-variable-scope", "message": err.Error()})
+			return nil, false
 		}
+	}
 
-		toKeep[fmt.Sprintf("%s/%s", v.Name, branch)] = true
+	version, ok := parseIfMatch(c.GetHeader("If-Match"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"reason": "invalid-if-match"})
+		return nil, false
 	}
 
-	for _, v := range existingList {
-		branch := ""
-		if v.Branch != nil {
-			branch = *v.Branch
-		}
+	result, err := apply(owner, repo, version)
+	if errors.Is(err, envvars.ErrVersionMismatch) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"reason": "variables-changed"})
+		return nil, false
+	}
 
-		key := fmt.Sprintf("%s/%s", v.Name, branch)
-		if !toKeep[key] {
-			err := vr.envVarsProvider.Delete(c, owner, repo, v.Name, v.Branch)
-			if err != nil {
-				logger.Ctx(c).Err(err).Msgf("fail to delete variable %s", v.Name)
-				c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-				return
-			}
-		}
+	if err != nil {
+		logger.Ctx(c).Err(err).Msgf("fail to change variables of repo %s/%s", owner, repo)
+		c.JSON(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return nil, false
+	}
+
+	before := make(map[string]string, len(result.Before))
+	for _, v := range result.Before {
+		before[v.Key()] = v.Value
+	}
+
+	after := make(map[string]string, len(result.Variables))
+	for _, v := range result.Variables {
+		after[v.Key()] = v.Value
+	}
+
+	changes := audit.DiffKeys(before, after)
+	if !changes.IsEmpty() {
+		auth.Record(c, vr.auditProvider, owner, repo, audit.ActionVariablesUpdate, fmt.Sprintf("%s/%s", owner, repo), changes)
+	}
+
+	c.Header("ETag", formatETag(result.Version))
+
+	variables := result.Variables
+	if variables == nil {
+		variables = []envvars.EnvVar{}
+	}
+
+	return &changeResponse{Variables: variables, Changes: changes, Version: result.Version}, true
+}
+
+func formatETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// parseIfMatch returns the version of an If-Match header made out of an ETag of
+// the variables, the version is nil when the header is missing or *
+func parseIfMatch(header string) (*int64, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(header, "W/"), `"`), 10, 64)
+	if err != nil {
+		return nil, false
 	}
 
-	c.JSON(http.StatusOK, body)
+	return &version, true
 }
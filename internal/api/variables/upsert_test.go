@@ -0,0 +1,54 @@
+package variables
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		header  string
+		version *int64
+		ok      bool
+	}{
+		{header: "", version: nil, ok: true},
+		{header: "*", version: nil, ok: true},
+		{header: formatETag(3), version: ptr(3), ok: true},
+		{header: `W/"12"`, version: ptr(12), ok: true},
+		{header: `"abc"`, version: nil, ok: false},
+	}
+
+	for _, tc := range tt {
+		version, ok := parseIfMatch(tc.header)
+		assert.Equal(t, tc.ok, ok, tc.header)
+		assert.Equal(t, tc.version, version, tc.header)
+	}
+}
+
+func TestVariablesRouter_replaceRequiresIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	vr := &variablesRouter{}
+	router := gin.New()
+	router.POST("/owner/:owner/repos/:repo/variables", vr.upsert)
+	router.PUT("/owner/:owner/repos/:repo/variables", vr.replace)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut} {
+		req := httptest.NewRequest(method, "/owner/acme/repos/app/variables", bytes.NewBufferString(`[]`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code, method)
+	}
+}
+
+func ptr(v int64) *int64 {
+	return &v
+}
@@ -0,0 +1,105 @@
+package apitokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// TokenPrefix makes tokens easy to recognize by secret scanners
+const TokenPrefix = "ergo_"
+
+const (
+	ScopeEnvironmentsRead      = "environments:read"
+	ScopeVariablesManage       = "variables:manage"
+	ScopeEnvironmentsLaunch    = "environments:launch"
+	ScopeEnvironmentsTerminate = "environments:terminate"
+)
+
+var Scopes = []string{
+	ScopeEnvironmentsRead,
+	ScopeVariablesManage,
+	ScopeEnvironmentsLaunch,
+	ScopeEnvironmentsTerminate,
+}
+
+var ErrTokenNotFound = errors.New("api token not found")
+
+type Token struct {
+	ID         uuid.UUID  `json:"id"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	Owner      string     `json:"owner"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	Hint       string     `json:"hint"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	// CreatedBy is the login of who created the token, tokens can't do more
+	// than what their creator is currently allowed to
+	CreatedBy string `json:"createdBy"`
+}
+
+func (t *Token) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type TokensProvider interface {
+	// Create returns the created token and its plain text value, which is never stored
+	Create(ctx context.Context, owner, name, createdBy string, scopes []string) (*Token, string, error)
+	List(ctx context.Context, owner string) ([]Token, error)
+	Revoke(ctx context.Context, owner string, id uuid.UUID) error
+	// Authenticate finds the token whose plain text value is value, it returns
+	// ErrTokenNotFound if there is none or if it was revoked
+	Authenticate(ctx context.Context, value string) (*Token, error)
+}
+
+func IsValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lastUsedAtPrecision is how stale the last usage of a token can be, so that
+// busy tokens don't write to the database on every request
+const lastUsedAtPrecision = time.Minute
+
+func shouldUpdateLastUsedAt(lastUsedAt *time.Time, now time.Time) bool {
+	return lastUsedAt == nil || now.Sub(*lastUsedAt) >= lastUsedAtPrecision
+}
+
+func generate() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "fail to generate random token")
+	}
+
+	return TokenPrefix + hex.EncodeToString(b), nil
+}
+
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func hint(value string) string {
+	return "..." + value[len(value)-4:]
+}
+
+func looksLikeToken(value string) bool {
+	return strings.HasPrefix(value, TokenPrefix) && len(value) == len(TokenPrefix)+64
+}
@@ -0,0 +1,47 @@
+package apitokens
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	value, err := generate()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(value, TokenPrefix))
+	assert.True(t, looksLikeToken(value))
+	assert.Equal(t, "..."+value[len(value)-4:], hint(value))
+
+	other, err := generate()
+	require.NoError(t, err)
+	assert.NotEqual(t, value, other)
+	assert.NotEqual(t, hash(value), hash(other))
+}
+
+func TestLooksLikeToken(t *testing.T) {
+	assert.False(t, looksLikeToken(""))
+	assert.False(t, looksLikeToken("ergo_short"))
+	assert.False(t, looksLikeToken(strings.Repeat("a", len(TokenPrefix)+64)))
+}
+
+func TestToken_HasScope(t *testing.T) {
+	token := Token{Scopes: []string{ScopeEnvironmentsRead}}
+
+	assert.True(t, token.HasScope(ScopeEnvironmentsRead))
+	assert.False(t, token.HasScope(ScopeVariablesManage))
+}
+
+func TestShouldUpdateLastUsedAt(t *testing.T) {
+	now := time.Now()
+	recently := now.Add(-10 * time.Second)
+	longAgo := now.Add(-2 * time.Minute)
+
+	assert.True(t, shouldUpdateLastUsedAt(nil, now))
+	assert.False(t, shouldUpdateLastUsedAt(&recently, now))
+	assert.True(t, shouldUpdateLastUsedAt(&longAgo, now))
+}
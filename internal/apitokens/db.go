@@ -0,0 +1,153 @@
+package apitokens
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+type apiToken struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+	Owner      string         `gorm:"index"`
+	Name       string
+	Hash       string
+	Hint       string
+	Scopes     json.RawMessage `gorm:"type:jsonb"`
+	LastUsedAt *time.Time
+	CreatedBy  string
+}
+
+type dbTokensProvider struct {
+	db *database.DB
+}
+
+func NewDBTokensProvider(db *database.DB) *dbTokensProvider {
+	return &dbTokensProvider{db}
+}
+
+func (tp *dbTokensProvider) Create(
+	ctx context.Context,
+	owner, name, createdBy string,
+	scopes []string,
+) (*Token, string, error) {
+	value, err := generate()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fail to generate api token")
+	}
+
+	rawScopes, err := json.Marshal(scopes)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fail to marshal api token scopes")
+	}
+
+	dbToken := apiToken{
+		Owner:     owner,
+		Name:      name,
+		Hash:      hash(value),
+		Hint:      hint(value),
+		Scopes:    rawScopes,
+		CreatedBy: createdBy,
+	}
+	err = tp.db.Table("api_tokens").Create(&dbToken).Error
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "fail to create api token for owner %s", owner)
+	}
+
+	token, err := fromDB(dbToken)
+
+	return token, value, errors.Wrap(err, "fail to convert api token")
+}
+
+func (tp *dbTokensProvider) List(ctx context.Context, owner string) ([]Token, error) {
+	var dbTokens []apiToken
+	err := tp.db.Table("api_tokens").
+		Order("created_at ASC").
+		Find(&dbTokens, map[string]string{"owner": owner}).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list api tokens of owner %s", owner)
+	}
+
+	tokens := make([]Token, 0, len(dbTokens))
+	for _, dbToken := range dbTokens {
+		token, err := fromDB(dbToken)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to convert api token %s", dbToken.ID)
+		}
+
+		tokens = append(tokens, *token)
+	}
+
+	return tokens, nil
+}
+
+func (tp *dbTokensProvider) Revoke(ctx context.Context, owner string, id uuid.UUID) error {
+	res := tp.db.Table("api_tokens").
+		Where(map[string]interface{}{"owner": owner, "id": id}).
+		Delete(&apiToken{})
+	if res.Error != nil {
+		return errors.Wrapf(res.Error, "fail to revoke api token %s", id)
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrTokenNotFound
+	}
+
+	return nil
+}
+
+func (tp *dbTokensProvider) Authenticate(ctx context.Context, value string) (*Token, error) {
+	if !looksLikeToken(value) {
+		return nil, ErrTokenNotFound
+	}
+
+	var dbToken apiToken
+	err := tp.db.Table("api_tokens").First(&dbToken, map[string]string{"hash": hash(value)}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTokenNotFound
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to find api token")
+	}
+
+	now := time.Now()
+	if shouldUpdateLastUsedAt(dbToken.LastUsedAt, now) {
+		err = tp.db.Table("api_tokens").Where("id = ?", dbToken.ID).UpdateColumn("last_used_at", now).Error
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to update last usage of api token %s", dbToken.ID)
+		}
+		dbToken.LastUsedAt = &now
+	}
+
+	return fromDB(dbToken)
+}
+
+func fromDB(dbToken apiToken) (*Token, error) {
+	scopes := make([]string, 0)
+	if len(dbToken.Scopes) > 0 {
+		err := json.Unmarshal(dbToken.Scopes, &scopes)
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to unmarshal scopes")
+		}
+	}
+
+	return &Token{
+		ID:         dbToken.ID,
+		CreatedAt:  dbToken.CreatedAt,
+		Owner:      dbToken.Owner,
+		Name:       dbToken.Name,
+		Scopes:     scopes,
+		Hint:       dbToken.Hint,
+		LastUsedAt: dbToken.LastUsedAt,
+		CreatedBy:  dbToken.CreatedBy,
+	}, nil
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	ActionVariablesUpdate         = "variables.update"
+	ActionRegistryCreate          = "registry.create"
+	ActionRegistryDelete          = "registry.delete"
+	ActionPermanentBranchesUpdate = "permanent_branches.update"
+	ActionEnvironmentLaunch       = "environment.launch"
+	ActionEnvironmentTerminate    = "environment.terminate"
+	ActionAPITokenCreate          = "api_token.create"
+	ActionAPITokenRevoke          = "api_token.revoke"
+	ActionRoleAssign              = "role.assign"
+	ActionRoleUnassign            = "role.unassign"
+	ActionRoleSettingsUpdate      = "role_settings.update"
+	ActionPreviewAccessUpdate     = "preview_access.update"
+	ActionBypassTokenCreate       = "preview_bypass_token.create"
+	ActionBypassTokenRevoke       = "preview_bypass_token.revoke"
+	ActionShareLinkCreate         = "preview_share_link.create"
+	ActionShareLinkRevoke         = "preview_share_link.revoke"
+	ActionDomainUpdate            = "domain.update"
+	ActionDomainDelete            = "domain.delete"
+	ActionDomainVerify            = "domain.verify"
+	ActionSecretStoreUpdate       = "secret_store.update"
+	ActionSecretStoreDelete       = "secret_store.delete"
+	ActionVariableGroupUpdate     = "variable_group.update"
+	ActionVariableGroupDelete     = "variable_group.delete"
+	ActionVariableGroupsAttach    = "variable_groups.attach"
+	ActionForkPolicyUpdate        = "fork_policy.update"
+	ActionForkApprove             = "fork.approve"
+)
+
+// SystemActor is the actor of actions ergomake takes by itself
+const SystemActor = "ergomake"
+
+type Entry struct {
+	ID        uuid.UUID       `json:"id"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Actor     string          `json:"actor"`
+	Owner     string          `json:"owner"`
+	Repo      string          `json:"repo"`
+	Action    string          `json:"action"`
+	Target    string          `json:"target"`
+	Diff      json.RawMessage `json:"diff"`
+}
+
+type Filter struct {
+	Repo   string
+	Action string
+	Actor  string
+	Since  *time.Time
+	Until  *time.Time
+	// Cursor is the NextCursor of the previous page
+	Cursor string
+	Limit  int
+}
+
+type Page struct {
+	Entries    []Entry `json:"entries"`
+	NextCursor string  `json:"nextCursor"`
+}
+
+var ErrInvalidCursor = errors.New("invalid audit log cursor")
+
+type AuditProvider interface {
+	Record(ctx context.Context, entry Entry) error
+	// List returns the entries of owner from newest to oldest
+	List(ctx context.Context, owner string, filter Filter) (*Page, error)
+}
+
+// Changes is what an action changed, values of secret things like variables are
+// never part of it, only their names
+type Changes struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
+
+// DiffKeys compares before and after and returns the keys that changed, values are
+// compared but never included
+func DiffKeys(before, after map[string]string) Changes {
+	var changes Changes
+	for key, value := range after {
+		previous, ok := before[key]
+		if !ok {
+			changes.Added = append(changes.Added, key)
+		} else if previous != value {
+			changes.Changed = append(changes.Changed, key)
+		}
+	}
+
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			changes.Removed = append(changes.Removed, key)
+		}
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	sort.Strings(changes.Changed)
+
+	return changes
+}
+
+func (c Changes) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// NewDiff marshals v to be used as the Diff of an entry
+func NewDiff(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffKeys(t *testing.T) {
+	before := map[string]string{"A": "1", "B": "2", "C": "3"}
+	after := map[string]string{"A": "1", "B": "s3cret", "D": "4"}
+
+	changes := DiffKeys(before, after)
+	assert.Equal(t, Changes{
+		Added:   []string{"D"},
+		Removed: []string{"C"},
+		Changed: []string{"B"},
+	}, changes)
+	assert.NotContains(t, string(NewDiff(changes)), "s3cret")
+
+	assert.True(t, DiffKeys(before, before).IsEmpty())
+}
+
+func TestCursor(t *testing.T) {
+	createdAt := time.Unix(0, time.Now().UnixNano())
+	id := uuid.New()
+
+	decodedAt, decodedID, err := decodeCursor(encodeCursor(createdAt, id))
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(decodedAt))
+	assert.Equal(t, id, decodedID)
+
+	for _, cursor := range []string{"", "not base64!", "bm9wZQ"} {
+		_, _, err := decodeCursor(cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+	}
+}
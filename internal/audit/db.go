@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+type auditLog struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt time.Time
+	Actor     string
+	Owner     string
+	Repo      string
+	Action    string
+	Target    string
+	Diff      json.RawMessage `gorm:"type:jsonb"`
+}
+
+type dbAuditProvider struct {
+	db *database.DB
+}
+
+func NewDBAuditProvider(db *database.DB) *dbAuditProvider {
+	return &dbAuditProvider{db}
+}
+
+func (ap *dbAuditProvider) Record(ctx context.Context, entry Entry) error {
+	if entry.Actor == "" {
+		entry.Actor = SystemActor
+	}
+
+	err := ap.db.Table("audit_log").Create(&auditLog{
+		Actor:  entry.Actor,
+		Owner:  entry.Owner,
+		Repo:   entry.Repo,
+		Action: entry.Action,
+		Target: entry.Target,
+		Diff:   entry.Diff,
+	}).Error
+
+	return errors.Wrapf(err, "fail to record %s audit log entry of owner %s", entry.Action, entry.Owner)
+}
+
+func (ap *dbAuditProvider) List(ctx context.Context, owner string, filter Filter) (*Page, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	query := ap.db.Table("audit_log").Where("owner = ?", owner)
+	if filter.Repo != "" {
+		query = query.Where("repo = ?", filter.Repo)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Since != nil {
+		query = query.Where("created_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("created_at < ?", *filter.Until)
+	}
+	if filter.Cursor != "" {
+		createdAt, id, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		query = query.Where("(created_at, id) < (?, ?)", createdAt, id)
+	}
+
+	var logs []auditLog
+	err := query.Order("created_at DESC, id DESC").Limit(limit + 1).Find(&logs).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list audit log of owner %s", owner)
+	}
+
+	page := &Page{Entries: make([]Entry, 0, len(logs))}
+	if len(logs) > limit {
+		logs = logs[:limit]
+		last := logs[len(logs)-1]
+		page.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+
+	for _, l := range logs {
+		page.Entries = append(page.Entries, Entry{
+			ID:        l.ID,
+			CreatedAt: l.CreatedAt,
+			Actor:     l.Actor,
+			Owner:     l.Owner,
+			Repo:      l.Repo,
+			Action:    l.Action,
+			Target:    l.Target,
+			Diff:      l.Diff,
+		})
+	}
+
+	return page, nil
+}
+
+func encodeCursor(createdAt time.Time, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d_%s", createdAt.UnixNano(), id)))
+}
+
+func decodeCursor(cursor string) (time.Time, uuid.UUID, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(b), "_", 2)
+	if len(parts) != 2 {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	return time.Unix(0, nanos), id, nil
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/ergomake/ergomake/internal/github/ghapp"
 	"github.com/ergomake/ergomake/internal/github/ghlauncher"
 	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/notifications"
+	"github.com/ergomake/ergomake/internal/prcomments"
 	"github.com/ergomake/ergomake/internal/transformer"
 )
 
@@ -45,6 +47,8 @@ func WatchBuilds(
 	clusterClient cluster.Client,
 	db *database.DB,
 	ghApp ghapp.GHAppClient,
+	commentSettingsProvider prcomments.SettingsProvider,
+	notifier notifications.Notifier,
 	frontendURL string,
 ) (func(), error) {
 	buildCh := make(chan *kpackBuild.Build)
@@ -167,7 +171,7 @@ func WatchBuilds(
 						if err != nil {
 							logger.Get().Err(err).Str("env", env.ID.String()).Str("service", service.Name).
 								Msg("fail to scale deployment up when bringing environment up")
-							ghlauncher.FailRun(ctx, ghApp, db, envFrontendLink, &env, sha, nil)
+							ghlauncher.FailRun(ctx, ghApp, db, commentSettingsProvider, notifier, envFrontendLink, &env, sha, nil, nil)
 							continue outer
 						}
 					}
@@ -175,10 +179,10 @@ func WatchBuilds(
 					err := db.Model(&env).Update("status", database.EnvSuccess).Error
 					if err != nil {
 						logger.Ctx(ctx).Err(err).Str("env", env.ID.String()).Msg("fail to update db environment status to success")
-						ghlauncher.FailRun(ctx, ghApp, db, envFrontendLink, &env, sha, nil)
+						ghlauncher.FailRun(ctx, ghApp, db, commentSettingsProvider, notifier, envFrontendLink, &env, sha, nil, nil)
 						continue outer
 					}
-					ghlauncher.SuccessRun(ctx, ghApp, db, envFrontendLink, transformer.EnvironmentFromDB(&env), &env, sha)
+					ghlauncher.SuccessRun(ctx, ghApp, db, commentSettingsProvider, notifier, envFrontendLink, transformer.EnvironmentFromDB(&env), &env, sha)
 				} else {
 					err := db.Model(&env).Update("status", database.EnvDegraded).Error
 					if err != nil {
@@ -191,7 +195,7 @@ func WatchBuilds(
 						continue outer
 					}
 
-					ghlauncher.FailRun(ctx, ghApp, db, envFrontendLink, &env, sha, nil)
+					ghlauncher.FailRun(ctx, ghApp, db, commentSettingsProvider, notifier, envFrontendLink, &env, sha, nil, nil)
 				}
 
 				logger.Ctx(ctx).Info().Str("env", env.ID.String()).Bool("success", success).
@@ -36,13 +36,18 @@ type Client interface {
 	CreateServiceAccount(ctx context.Context, svcAcc *corev1.ServiceAccount) error
 	GetPreviewNamespaces(ctx context.Context) ([]corev1.Namespace, error)
 	GetIngress(ctx context.Context, namespace, name string) (*networkingv1.Ingress, error)
+	// ListIngresses returns the ingresses of namespace that have all of matchLabels
+	ListIngresses(ctx context.Context, namespace string, matchLabels map[string]string) ([]*networkingv1.Ingress, error)
 	GetIngressUrl(ctx context.Context, namespace string, serviceName string, protocol string) (string, error)
 	UpdateIngress(ctx context.Context, ingress *networkingv1.Ingress) error
+	// PatchConfigMapData sets the given keys of a configmap, keeping the others
+	PatchConfigMapData(ctx context.Context, namespace, name string, data map[string]string) error
 	GetDeployment(ctx context.Context, namespace string, deploymentName string) (*appsv1.Deployment, error)
 	ScaleDeployment(ctx context.Context, namespace string, deploymentName string, replicas int32) error
 	WaitJobs(ctx context.Context, jobs []*batchv1.Job) (*WaitJobsResult, error)
 	WaitDeployments(ctx context.Context, namespace string) error
 	GetJobLogs(ctx context.Context, job *batchv1.Job, size int64) (string, error)
+	GetJobPodStatus(ctx context.Context, job *batchv1.Job) (*corev1.PodStatus, error)
 	ListJobs(ctx context.Context, namespace string) ([]*batchv1.Job, error)
 	AreServicesAlive(ctx context.Context, namespace string) (bool, error)
 	WatchServiceLogs(ctx context.Context, namespace, name string, sinceSeconds int64) (<-chan string, <-chan error, error)
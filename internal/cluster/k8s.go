@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/kubernetes"
@@ -190,6 +191,26 @@ func (k8s *k8sClient) GetIngress(ctx context.Context, namespace, name string) (*
 	return ingress, err
 }
 
+func (k8s *k8sClient) ListIngresses(
+	ctx context.Context,
+	namespace string,
+	matchLabels map[string]string,
+) ([]*networkingv1.Ingress, error) {
+	ingressList, err := k8s.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(matchLabels).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list ingresses of namespace %s", namespace)
+	}
+
+	ingresses := make([]*networkingv1.Ingress, 0, len(ingressList.Items))
+	for i := range ingressList.Items {
+		ingresses = append(ingresses, &ingressList.Items[i])
+	}
+
+	return ingresses, nil
+}
+
 func (k8s *k8sClient) GetDeployment(ctx context.Context, namespace string, deploymentName string) (*appsv1.Deployment, error) {
 	return k8s.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
 }
@@ -348,6 +369,26 @@ func (k8s *k8sClient) GetJobLogs(ctx context.Context, job *batchv1.Job, size int
 	return logs, nil
 }
 
+// GetJobPodStatus returns the status of the most recent pod of job
+func (k8s *k8sClient) GetJobPodStatus(ctx context.Context, job *batchv1.Job) (*corev1.PodStatus, error) {
+	pods, err := k8s.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(job.Spec.Selector.MatchLabels).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list pods of job %s/%s", job.Namespace, job.Name)
+	}
+
+	if len(pods.Items) == 0 {
+		return nil, errors.Errorf("no pods found for job %s/%s", job.Namespace, job.Name)
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[j].CreationTimestamp.Before(&pods.Items[i].CreationTimestamp)
+	})
+
+	return &pods.Items[0].Status, nil
+}
+
 func (k8s *k8sClient) ListJobs(ctx context.Context, namespace string) ([]*batchv1.Job, error) {
 	jobList, err := k8s.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -382,6 +423,17 @@ func (k8s *k8sClient) UpdateIngress(ctx context.Context, ingress *networkingv1.I
 	return err
 }
 
+func (k8s *k8sClient) PatchConfigMapData(ctx context.Context, namespace, name string, data map[string]string) error {
+	patch, err := json.Marshal(map[string]interface{}{"data": data})
+	if err != nil {
+		return errors.Wrap(err, "fail to marshal configmap patch")
+	}
+
+	_, err = k8s.CoreV1().ConfigMaps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+
+	return errors.Wrapf(err, "fail to patch configmap %s at namespace %s", name, namespace)
+}
+
 func (k8s *k8sClient) AreServicesAlive(ctx context.Context, namespace string) (bool, error) {
 	services, err := k8s.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
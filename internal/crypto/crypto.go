@@ -1,64 +1,238 @@
 package crypto
 
 import (
-	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
-	"errors"
 	"io"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
-func Encrypt(encryptionKey string, text string) (string, error) {
-	key, err := hex.DecodeString(encryptionKey)
+// Ciphertexts look like v2:<key id>:<hex of nonce and sealed text>, the key id
+// tells which key of the ring to decrypt with. Older ciphertexts look like
+// <hex iv>:<hex text>, they were encrypted with AES-CFB and can't be checked for
+// tampering.
+//
+// Legacy ciphertexts are still read until SetAllowLegacy(false) is called. The
+// migration away from them is:
+//  1. deploy with ALLOW_LEGACY_CIPHERTEXTS unset, so that it defaults to true
+//  2. run `rotate --allow-legacy` of cmd/cli/crypto until it prints that no
+//     values in the legacy format remain
+//  3. deploy with ALLOW_LEGACY_CIPHERTEXTS=false
+const version = "v2"
+
+var (
+	ErrInvalidFormat = errors.New("invalid ciphertext format")
+	ErrUnknownKey    = errors.New("ciphertext was encrypted with a key that is not in the key ring")
+	ErrTampered      = errors.New("ciphertext was tampered with or the key is wrong")
+	ErrLegacyFormat  = errors.New("ciphertext uses the legacy unauthenticated format and must be rotated")
+)
+
+type key struct {
+	id    string
+	value []byte
+}
+
+// parseKeys reads a key ring, which is a comma separated list of hex keys,
+// each optionally prefixed by an id, as in `2023-06:<hex>,<hex>`. Keys without
+// an id are identified by their fingerprint. The first key encrypts, the
+// others only decrypt, and the last one decrypts ciphertexts from before key
+// ids existed, since that is the key that was in use back then.
+func parseKeys(keyRing string) ([]key, error) {
+	keys := []key{}
+	seen := map[string]struct{}{}
+	for _, entry := range strings.Split(keyRing, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, hexValue, ok := strings.Cut(entry, ":")
+		if !ok {
+			hexValue = id
+			id = ""
+		}
+
+		value, err := hex.DecodeString(hexValue)
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to decode key")
+		}
+
+		if len(value) != 16 && len(value) != 24 && len(value) != 32 {
+			return nil, errors.Errorf("key must have 16, 24 or 32 bytes, got %d", len(value))
+		}
+
+		if id == "" {
+			id = Fingerprint(value)
+		}
+
+		if strings.ContainsAny(id, ": ") {
+			return nil, errors.Errorf("key id `%s` must not have colons or spaces", id)
+		}
+
+		if _, ok := seen[id]; ok {
+			return nil, errors.Errorf("key id `%s` is repeated", id)
+		}
+		seen[id] = struct{}{}
+
+		keys = append(keys, key{id, value})
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("key ring is empty")
+	}
+
+	return keys, nil
+}
+
+// Fingerprint is the id of a key that has none of its own
+func Fingerprint(value []byte) string {
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:4])
+}
+
+// GenerateKey returns a new random 256 bits key encoded as hex
+func GenerateKey() (string, error) {
+	value := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, value); err != nil {
+		return "", errors.Wrap(err, "fail to read random bytes")
+	}
+
+	return hex.EncodeToString(value), nil
+}
+
+func newGCM(value []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(value)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	plaintext := []byte(text)
+	return cipher.NewGCM(block)
+}
 
-	iv := make([]byte, 16)
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
-		return "", err
+func Encrypt(keyRing string, text string) (string, error) {
+	keys, err := parseKeys(keyRing)
+	if err != nil {
+		return "", errors.Wrap(err, "fail to parse key ring")
 	}
+	primary := keys[0]
 
-	block, err := aes.NewCipher(key)
+	gcm, err := newGCM(primary.value)
 	if err != nil {
-		return "", err
+		return "", errors.Wrap(err, "fail to create cipher")
 	}
 
-	ciphertext := make([]byte, len(plaintext))
-	cfb := cipher.NewCFBEncrypter(block, iv)
-	cfb.XORKeyStream(ciphertext, plaintext)
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Wrap(err, "fail to read random bytes")
+	}
+
+	// the key id goes as additional data so that it can't be swapped
+	sealed := gcm.Seal(nonce, nonce, []byte(text), []byte(primary.id))
+
+	return strings.Join([]string{version, primary.id, hex.EncodeToString(sealed)}, ":"), nil
+}
 
-	return hex.EncodeToString(iv) + ":" + hex.EncodeToString(ciphertext), nil
+// allowLegacy is only set at startup, before anything is decrypted
+var allowLegacy = true
+
+// SetAllowLegacy tells whether Decrypt reads ciphertexts from before
+// authenticated encryption. It should be turned off once they were rotated,
+// otherwise anyone who can write to the database could swap a value for one in
+// the legacy format and go around tamper detection.
+func SetAllowLegacy(allow bool) {
+	allowLegacy = allow
+}
+
+// Decrypt returns ErrLegacyFormat for ciphertexts from before authenticated
+// encryption when they are no longer allowed, see SetAllowLegacy
+func Decrypt(keyRing string, hash string) (string, error) {
+	return decrypt(keyRing, hash, allowLegacy)
 }
 
-func Decrypt(encryptionKey string, hash string) (string, error) {
-	key, err := hex.DecodeString(encryptionKey)
+// IsLegacy tells whether hash is in the format from before authenticated encryption
+func IsLegacy(hash string) bool {
+	parts := strings.Split(hash, ":")
+	return len(parts) == 2 && parts[0] != version
+}
+
+func decrypt(keyRing string, hash string, allowLegacy bool) (string, error) {
+	keys, err := parseKeys(keyRing)
 	if err != nil {
-		return "", err
+		return "", errors.Wrap(err, "fail to parse key ring")
 	}
 
-	textParts := bytes.SplitN([]byte(hash), []byte(":"), 2)
-	if len(textParts) != 2 {
-		return "", errors.New("invalid hash format")
+	parts := strings.Split(hash, ":")
+	switch {
+	case len(parts) == 3 && parts[0] == version:
+		return decryptGCM(keys, parts[1], parts[2])
+	case IsLegacy(hash):
+		if !allowLegacy {
+			return "", ErrLegacyFormat
+		}
+
+		return decryptCFB(keys[len(keys)-1].value, parts[0], parts[1])
 	}
 
-	iv, err := hex.DecodeString(string(textParts[0]))
+	return "", ErrInvalidFormat
+}
+
+func decryptGCM(keys []key, id string, hexSealed string) (string, error) {
+	var value []byte
+	for _, k := range keys {
+		if k.id == id {
+			value = k.value
+			break
+		}
+	}
+
+	if value == nil {
+		return "", errors.Wrapf(ErrUnknownKey, "key id `%s`", id)
+	}
+
+	sealed, err := hex.DecodeString(hexSealed)
 	if err != nil {
-		return "", err
+		return "", ErrInvalidFormat
+	}
+
+	gcm, err := newGCM(value)
+	if err != nil {
+		return "", errors.Wrap(err, "fail to create cipher")
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return "", ErrInvalidFormat
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return "", ErrTampered
+	}
+
+	return string(plaintext), nil
+}
+
+// decryptCFB reads ciphertexts from before authenticated encryption, they
+// can't be checked for tampering and should be rotated away.
+func decryptCFB(value []byte, hexIV, hexCiphertext string) (string, error) {
+	iv, err := hex.DecodeString(hexIV)
+	if err != nil || len(iv) != aes.BlockSize {
+		return "", ErrInvalidFormat
 	}
 
-	ciphertext, err := hex.DecodeString(string(textParts[1]))
+	ciphertext, err := hex.DecodeString(hexCiphertext)
 	if err != nil {
-		return "", err
+		return "", ErrInvalidFormat
 	}
 
-	block, err := aes.NewCipher(key)
+	block, err := aes.NewCipher(value)
 	if err != nil {
-		return "", err
+		return "", errors.Wrap(err, "fail to create cipher")
 	}
 
 	plaintext := make([]byte, len(ciphertext))
@@ -67,3 +241,30 @@ func Decrypt(encryptionKey string, hash string) (string, error) {
 
 	return string(plaintext), nil
 }
+
+// Rotate re-encrypts hash with the first key of the ring, it returns false
+// when hash already is encrypted with that key and there is nothing to do.
+// Legacy ciphertexts are only read when allowLegacy is set, see Decrypt.
+func Rotate(keyRing string, hash string, allowLegacy bool) (string, bool, error) {
+	keys, err := parseKeys(keyRing)
+	if err != nil {
+		return "", false, errors.Wrap(err, "fail to parse key ring")
+	}
+
+	// decrypt even when there is nothing to do so that tampered rows show up
+	plaintext, err := decrypt(keyRing, hash, allowLegacy)
+	if err != nil {
+		return "", false, errors.Wrap(err, "fail to decrypt")
+	}
+
+	if strings.HasPrefix(hash, version+":"+keys[0].id+":") {
+		return hash, false, nil
+	}
+
+	rotated, err := Encrypt(keyRing, plaintext)
+	if err != nil {
+		return "", false, errors.Wrap(err, "fail to encrypt")
+	}
+
+	return rotated, true, nil
+}
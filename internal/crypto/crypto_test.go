@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	oldKey = "000102030405060708090a0b0c0d0e0f000102030405060708090a0b0c0d0e0f"
+	newKey = "0f0e0d0c0b0a090807060504030201000f0e0d0c0b0a09080706050403020100"
+)
+
+// encryptCFB is how values were encrypted before key rings
+func encryptCFB(t *testing.T, encryptionKey, text string) string {
+	key, err := hex.DecodeString(encryptionKey)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+
+	iv := make([]byte, aes.BlockSize)
+	ciphertext := make([]byte, len(text))
+	cipher.NewCFBEncrypter(block, iv).XORKeyStream(ciphertext, []byte(text))
+
+	return hex.EncodeToString(iv) + ":" + hex.EncodeToString(ciphertext)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	hash, err := Encrypt("2023-06:"+newKey+","+oldKey, "secret value")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "v2:2023-06:"))
+
+	value, err := Decrypt("2023-06:"+newKey, hash)
+	require.NoError(t, err)
+	assert.Equal(t, "secret value", value)
+
+	_, err = Decrypt(oldKey, hash)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestDecrypt_Tampered(t *testing.T) {
+	t.Parallel()
+
+	hash, err := Encrypt(newKey, "secret value")
+	require.NoError(t, err)
+
+	last := hash[len(hash)-1]
+	flipped := byte('0')
+	if last == '0' {
+		flipped = '1'
+	}
+
+	_, err = Decrypt(newKey, hash[:len(hash)-1]+string(flipped))
+	assert.ErrorIs(t, err, ErrTampered)
+
+	_, err = Decrypt(newKey, "v2:abc")
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func TestDecrypt_Legacy(t *testing.T) {
+	hash := encryptCFB(t, oldKey, "legacy value")
+	assert.True(t, IsLegacy(hash))
+
+	value, err := Decrypt(oldKey, hash)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy value", value)
+
+	SetAllowLegacy(false)
+	defer SetAllowLegacy(true)
+
+	_, err = Decrypt(oldKey, hash)
+	assert.ErrorIs(t, err, ErrLegacyFormat)
+
+	value, err = decrypt(oldKey, hash, true)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy value", value)
+
+	value, err = decrypt(newKey+","+oldKey, hash, true)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy value", value)
+}
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+
+	keyRing := newKey + "," + oldKey
+
+	_, _, err := Rotate(keyRing, encryptCFB(t, oldKey, "value"), false)
+	assert.ErrorIs(t, err, ErrLegacyFormat)
+
+	rotated, changed, err := Rotate(keyRing, encryptCFB(t, oldKey, "value"), true)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.False(t, IsLegacy(rotated))
+	assert.True(t, strings.HasPrefix(rotated, "v2:"+Fingerprint(mustDecodeHex(t, newKey))+":"))
+
+	again, changed, err := Rotate(keyRing, rotated, false)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, rotated, again)
+
+	fromOld, err := Encrypt(oldKey, "value")
+	require.NoError(t, err)
+	rotated, changed, err = Rotate(keyRing, fromOld, false)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	value, err := Decrypt(newKey, rotated)
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
+
+func TestParseKeys(t *testing.T) {
+	t.Parallel()
+
+	for _, keyRing := range []string{"", "zz", "0102", "a:" + oldKey + ",a:" + newKey, "a b:" + oldKey} {
+		_, err := parseKeys(keyRing)
+		assert.Error(t, err, keyRing)
+	}
+
+	keys, err := parseKeys(" a:" + newKey + " , " + oldKey)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "a", keys[0].id)
+	assert.Equal(t, Fingerprint(mustDecodeHex(t, oldKey)), keys[1].id)
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	value, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return value
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceEndpoint is a non-HTTP port of a service that is reachable from outside
+// the cluster, Credential is encrypted
+type ServiceEndpoint struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	CreatedAt     time.Time
+	EnvironmentID uuid.UUID `gorm:"type:uuid;index"`
+	Service       string
+	Protocol      string
+	TargetPort    int
+	Host          string
+	PublicPort    int
+	Credential    string
+}
+
+func (db *DB) FindEndpointsByEnvironment(environmentID uuid.UUID) ([]ServiceEndpoint, error) {
+	endpoints := make([]ServiceEndpoint, 0)
+	err := db.Where(map[string]interface{}{
+		"environment_id": environmentID,
+	}).Order("service ASC, target_port ASC").Find(&endpoints).Error
+
+	return endpoints, err
+}
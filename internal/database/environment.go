@@ -20,6 +20,9 @@ const (
 	EnvDegraded EnvStatus = "degraded"
 	EnvLimited  EnvStatus = "limited"
 	EnvStale    EnvStatus = "stale"
+	// EnvAwaitingApproval is a pull request of an external contributor that is
+	// only built after a maintainer approves it
+	EnvAwaitingApproval EnvStatus = "awaiting-approval"
 )
 
 type Environment struct {
@@ -37,6 +40,8 @@ type Environment struct {
 	DegradedReason json.RawMessage `gorm:"type:jsonb"`
 	Services       []Service       `gorm:"foreignKey:EnvironmentID"`
 	GHCommentID    int64           `gorm:"column:gh_comment_id"`
+	GHCheckRunID   int64           `gorm:"column:gh_check_run_id"`
+	GHDeploymentID int64           `gorm:"column:gh_deployment_id"`
 	BuildTool      string
 }
 
@@ -14,6 +14,7 @@ type Service struct {
 	Name          string
 	EnvironmentID uuid.UUID `gorm:"type:uuid;index"`
 	Url           string
+	Hosts         pq.StringArray `gorm:"type:text[]"`
 	Image         string
 	Build         string
 	BuildStatus   string
@@ -0,0 +1,157 @@
+package domains
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/crypto"
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+type customDomain struct {
+	ID                uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
+	Owner             string
+	BaseDomain        string
+	URLTemplate       string
+	VerificationToken string
+	VerifiedAt        *time.Time
+	TLSMode           string
+	ClusterIssuer     string
+	TLSCert           string
+	TLSKey            string
+}
+
+type dbDomainsProvider struct {
+	db     *database.DB
+	secret string
+}
+
+// NewDBDomainsProvider stores custom domains in the database, tls keys are encrypted with secret
+func NewDBDomainsProvider(db *database.DB, secret string) *dbDomainsProvider {
+	return &dbDomainsProvider{db, secret}
+}
+
+func (dp *dbDomainsProvider) Get(ctx context.Context, owner string) (*Domain, error) {
+	var dbDomain customDomain
+	err := dp.db.Table("custom_domains").First(&dbDomain, map[string]string{"owner": owner}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrDomainNotFound
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to find custom domain of owner %s", owner)
+	}
+
+	return dp.fromDB(dbDomain)
+}
+
+func (dp *dbDomainsProvider) Upsert(ctx context.Context, domain Domain) (*Domain, error) {
+	current, err := dp.Get(ctx, domain.Owner)
+	if err != nil && !errors.Is(err, ErrDomainNotFound) {
+		return nil, errors.Wrap(err, "fail to get current custom domain")
+	}
+
+	token := ""
+	var verifiedAt *time.Time
+	if current != nil && current.BaseDomain == domain.BaseDomain {
+		token = current.VerificationToken
+		verifiedAt = current.VerifiedAt
+	} else {
+		token, err = generateToken()
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to generate verification token")
+		}
+	}
+
+	tlsKey := ""
+	if domain.TLSKey != "" {
+		tlsKey, err = crypto.Encrypt(dp.secret, domain.TLSKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to encrypt tls key")
+		}
+	}
+
+	var dbDomain customDomain
+	err = dp.db.Table("custom_domains").Where(map[string]interface{}{
+		"owner": domain.Owner,
+	}).Assign(map[string]interface{}{
+		"base_domain":        domain.BaseDomain,
+		"url_template":       domain.URLTemplate,
+		"verification_token": token,
+		"verified_at":        verifiedAt,
+		"tls_mode":           string(domain.TLSMode),
+		"cluster_issuer":     domain.ClusterIssuer,
+		"tls_cert":           domain.TLSCert,
+		"tls_key":            tlsKey,
+	}).FirstOrCreate(&dbDomain).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to upsert custom domain of owner %s", domain.Owner)
+	}
+
+	return dp.fromDB(dbDomain)
+}
+
+func (dp *dbDomainsProvider) MarkVerified(ctx context.Context, owner string) error {
+	err := dp.db.Table("custom_domains").
+		Where(map[string]interface{}{"owner": owner}).
+		Where("deleted_at IS NULL").
+		UpdateColumn("verified_at", time.Now()).Error
+
+	return errors.Wrapf(err, "fail to mark custom domain of owner %s as verified", owner)
+}
+
+func (dp *dbDomainsProvider) Delete(ctx context.Context, owner string) error {
+	res := dp.db.Table("custom_domains").
+		Where(map[string]interface{}{"owner": owner}).
+		Delete(&customDomain{})
+	if res.Error != nil {
+		return errors.Wrapf(res.Error, "fail to delete custom domain of owner %s", owner)
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrDomainNotFound
+	}
+
+	return nil
+}
+
+func (dp *dbDomainsProvider) fromDB(dbDomain customDomain) (*Domain, error) {
+	tlsKey := ""
+	if dbDomain.TLSKey != "" {
+		var err error
+		tlsKey, err = crypto.Decrypt(dp.secret, dbDomain.TLSKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to decrypt tls key")
+		}
+	}
+
+	return &Domain{
+		Owner:             dbDomain.Owner,
+		BaseDomain:        dbDomain.BaseDomain,
+		URLTemplate:       dbDomain.URLTemplate,
+		VerificationToken: dbDomain.VerificationToken,
+		VerifiedAt:        dbDomain.VerifiedAt,
+		TLSMode:           TLSMode(dbDomain.TLSMode),
+		ClusterIssuer:     dbDomain.ClusterIssuer,
+		TLSCert:           dbDomain.TLSCert,
+		TLSKey:            tlsKey,
+	}, nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "fail to generate random token")
+	}
+
+	return "ergomake-verification=" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,159 @@
+package domains
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cbroglie/mustache"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/slug"
+)
+
+type TLSMode string
+
+const (
+	// TLSModeNone leaves TLS to the default certificate of the ingress controller
+	TLSModeNone TLSMode = "none"
+	// TLSModeCertManager asks cert-manager to issue a certificate for each preview host
+	TLSModeCertManager TLSMode = "cert-manager"
+	// TLSModeSecret uses a wildcard certificate uploaded by the owner
+	TLSModeSecret TLSMode = "secret"
+)
+
+func (m TLSMode) IsValid() bool {
+	switch m {
+	case TLSModeNone, TLSModeCertManager, TLSModeSecret:
+		return true
+	}
+
+	return false
+}
+
+// DefaultURLTemplate renders the same hosts as the cluster domain, but under the custom domain
+const DefaultURLTemplate = "{{service}}-{{repo}}-{{suffix}}"
+
+// ChallengeRecordPrefix is prepended to the base domain to get the name of the TXT
+// record that proves the owner controls it
+const ChallengeRecordPrefix = "_ergomake-challenge."
+
+var ErrDomainNotFound = errors.New("custom domain not found")
+var ErrInvalidTemplate = errors.New("invalid url template")
+
+type Domain struct {
+	Owner string `json:"owner"`
+	// BaseDomain is what goes after the rendered template, eg: preview.acme.dev
+	BaseDomain        string     `json:"baseDomain"`
+	URLTemplate       string     `json:"urlTemplate"`
+	VerificationToken string     `json:"verificationToken"`
+	VerifiedAt        *time.Time `json:"verifiedAt"`
+	TLSMode           TLSMode    `json:"tlsMode"`
+	ClusterIssuer     string     `json:"clusterIssuer"`
+	// TLSCert and TLSKey are the PEM encoded wildcard certificate of TLSModeSecret
+	TLSCert string `json:"-"`
+	TLSKey  string `json:"-"`
+}
+
+func (d *Domain) IsVerified() bool {
+	return d.VerifiedAt != nil
+}
+
+func (d *Domain) ChallengeRecord() string {
+	return ChallengeRecordPrefix + d.BaseDomain
+}
+
+// URLParams is what url templates can use
+type URLParams struct {
+	Service  string
+	Owner    string
+	Repo     string
+	Branch   string
+	PrNumber *int
+}
+
+func (p URLParams) toMap() map[string]interface{} {
+	suffix := slug.Slugify(p.Branch)
+	pr := ""
+	if p.PrNumber != nil {
+		pr = strconv.Itoa(*p.PrNumber)
+		suffix = pr
+	}
+
+	return map[string]interface{}{
+		"service": slug.Slugify(p.Service),
+		"owner":   slug.Slugify(p.Owner),
+		"repo":    slug.Slugify(p.Repo),
+		"branch":  slug.Slugify(p.Branch),
+		"pr":      pr,
+		"suffix":  suffix,
+	}
+}
+
+// Host renders the url template of the domain for a service
+func (d *Domain) Host(params URLParams) (string, error) {
+	label, err := renderLabel(d.URLTemplate, params)
+	if err != nil {
+		return "", err
+	}
+
+	return label + "." + d.BaseDomain, nil
+}
+
+// ValidateURLTemplate checks that template renders a single DNS label, which
+// is what a wildcard certificate for the base domain covers
+func ValidateURLTemplate(template string) error {
+	_, err := renderLabel(template, URLParams{
+		Service:  "web",
+		Owner:    "owner",
+		Repo:     "repo",
+		Branch:   "main",
+		PrNumber: nil,
+	})
+	return err
+}
+
+var baseDomainRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+func IsValidBaseDomain(domain string) bool {
+	return len(domain) <= 200 && baseDomainRegex.MatchString(domain)
+}
+
+// renderLabel checks the tags of template itself instead of relying on
+// mustache.AllowMissingVariables, which is global to every template rendered
+func renderLabel(template string, params URLParams) (string, error) {
+	tmpl, err := mustache.ParseStringRaw(template, true)
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidTemplate, err.Error())
+	}
+
+	values := params.toMap()
+	for _, tag := range tmpl.Tags() {
+		if _, ok := values[tag.Name()]; !ok || tag.Type() != mustache.Variable {
+			return "", errors.Wrapf(ErrInvalidTemplate, "`%s` is not a known variable", tag.Name())
+		}
+	}
+
+	label, err := tmpl.Render(values)
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidTemplate, err.Error())
+	}
+
+	label = slug.Truncate(strings.Trim(label, "-"))
+	if !slug.IsValidLabel(label) {
+		return "", errors.Wrapf(ErrInvalidTemplate, "%q is not a valid dns label", label)
+	}
+
+	return label, nil
+}
+
+type DomainsProvider interface {
+	// Get returns ErrDomainNotFound when the owner has no custom domain
+	Get(ctx context.Context, owner string) (*Domain, error)
+	// Upsert resets the verification when the base domain changes
+	Upsert(ctx context.Context, domain Domain) (*Domain, error)
+	MarkVerified(ctx context.Context, owner string) error
+	Delete(ctx context.Context, owner string) error
+}
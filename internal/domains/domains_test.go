@@ -0,0 +1,164 @@
+package domains
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/cbroglie/mustache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDomain_Host(t *testing.T) {
+	pr := 42
+	tt := []struct {
+		name     string
+		template string
+		params   URLParams
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "default template with pull request",
+			template: DefaultURLTemplate,
+			params:   URLParams{Service: "web", Owner: "ergomake", Repo: "Ergomake", Branch: "feat/x", PrNumber: &pr},
+			want:     "web-ergomake-42.previews.acme.com",
+		},
+		{
+			name:     "default template with branch",
+			template: DefaultURLTemplate,
+			params:   URLParams{Service: "web", Owner: "ergomake", Repo: "ergomake", Branch: "feat/New_Thing"},
+			want:     "web-ergomake-feat-new-thing.previews.acme.com",
+		},
+		{
+			name:     "custom template",
+			template: "{{owner}}--{{branch}}",
+			params:   URLParams{Service: "web", Owner: "acme", Repo: "app", Branch: "main"},
+			want:     "acme--main.previews.acme.com",
+		},
+		{
+			name:     "unknown variable",
+			template: "{{service}}-{{nope}}",
+			params:   URLParams{Service: "web"},
+			wantErr:  true,
+		},
+		{
+			name:     "section",
+			template: "{{#pr}}pr-{{pr}}{{/pr}}{{service}}",
+			params:   URLParams{Service: "web", PrNumber: &pr},
+			wantErr:  true,
+		},
+		{
+			name:     "more than one label",
+			template: "{{service}}.{{repo}}",
+			params:   URLParams{Service: "web", Repo: "app"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			domain := Domain{BaseDomain: "previews.acme.com", URLTemplate: tc.template}
+			host, err := domain.Host(tc.params)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidTemplate)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, host)
+		})
+	}
+}
+
+func TestIsValidBaseDomain(t *testing.T) {
+	assert.True(t, IsValidBaseDomain("previews.acme.com"))
+	assert.True(t, IsValidBaseDomain("acme.io"))
+	assert.False(t, IsValidBaseDomain("localhost"))
+	assert.False(t, IsValidBaseDomain("*.acme.com"))
+	assert.False(t, IsValidBaseDomain("-acme.com"))
+}
+
+func TestApplyTLS(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	newIngress := func() *networkingv1.Ingress {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Labels: labels, Annotations: labels},
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{
+					{Host: "web-app-1.previews.acme.com"},
+					{Host: "web-app-1.env.ergomake.link"},
+				},
+			},
+		}
+	}
+
+	ingress := newIngress()
+	ApplyTLS(ingress, &Domain{BaseDomain: "previews.acme.com", TLSMode: TLSModeNone})
+	assert.Empty(t, ingress.Spec.TLS)
+
+	ingress = newIngress()
+	ApplyTLS(ingress, &Domain{BaseDomain: "previews.acme.com", TLSMode: TLSModeSecret})
+	assert.Equal(t, []networkingv1.IngressTLS{
+		{Hosts: []string{"web-app-1.previews.acme.com"}, SecretName: TLSSecretName},
+	}, ingress.Spec.TLS)
+
+	ingress = newIngress()
+	ApplyTLS(ingress, &Domain{BaseDomain: "previews.acme.com", TLSMode: TLSModeCertManager, ClusterIssuer: "letsencrypt"})
+	assert.Equal(t, "web-tls", ingress.Spec.TLS[0].SecretName)
+	assert.Equal(t, "letsencrypt", ingress.Annotations[clusterIssuerAnnotation])
+	assert.NotContains(t, labels, clusterIssuerAnnotation)
+}
+
+func TestNewTLSSecret(t *testing.T) {
+	assert.Nil(t, NewTLSSecret("ns", &Domain{TLSMode: TLSModeCertManager}))
+	assert.Nil(t, NewTLSSecret("ns", nil))
+
+	secret := NewTLSSecret("ns", &Domain{TLSMode: TLSModeSecret, TLSCert: "cert", TLSKey: "key"})
+	require.NotNil(t, secret)
+	assert.Equal(t, TLSSecretName, secret.Name)
+	assert.Equal(t, "ns", secret.Namespace)
+	assert.Equal(t, []byte("cert"), secret.Data["tls.crt"])
+	assert.Equal(t, []byte("key"), secret.Data["tls.key"])
+}
+
+type fakeResolver struct {
+	records map[string][]string
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, ok := r.records[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+
+	return records, nil
+}
+
+func TestCheckOwnership(t *testing.T) {
+	domain := &Domain{BaseDomain: "previews.acme.com", VerificationToken: "ergomake-verification=abc"}
+
+	ok, err := CheckOwnership(context.Background(), &fakeResolver{}, domain)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = CheckOwnership(context.Background(), &fakeResolver{records: map[string][]string{
+		"_ergomake-challenge.previews.acme.com": {"v=spf1", "ergomake-verification=wrong"},
+	}}, domain)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = CheckOwnership(context.Background(), &fakeResolver{records: map[string][]string{
+		"_ergomake-challenge.previews.acme.com": {"ergomake-verification=abc"},
+	}}, domain)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestValidateURLTemplate_KeepsMustacheDefaults(t *testing.T) {
+	require.Error(t, ValidateURLTemplate("{{nope}}"))
+	assert.True(t, mustache.AllowMissingVariables)
+}
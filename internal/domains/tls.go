@@ -0,0 +1,72 @@
+package domains
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TLSSecretName is the name of the secret the wildcard certificate of TLSModeSecret
+// is copied to, in the namespace of each environment
+const TLSSecretName = "preview-tls"
+
+const clusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+
+// ApplyTLS adds a TLS section for the hosts of ingress that are under the custom domain.
+// With cert-manager, its ingress-shim creates the Certificate objects out of it.
+func ApplyTLS(ingress *networkingv1.Ingress, domain *Domain) {
+	if domain == nil || domain.TLSMode == TLSModeNone {
+		return
+	}
+
+	hosts := []string{}
+	for _, rule := range ingress.Spec.Rules {
+		if strings.HasSuffix(rule.Host, "."+domain.BaseDomain) {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+
+	if len(hosts) == 0 {
+		return
+	}
+
+	secretName := TLSSecretName
+	if domain.TLSMode == TLSModeCertManager {
+		secretName = ingress.Name + "-tls"
+
+		// annotations are often the same map as the labels, so never change it in place
+		annotations := make(map[string]string, len(ingress.Annotations)+1)
+		for k, v := range ingress.Annotations {
+			annotations[k] = v
+		}
+		annotations[clusterIssuerAnnotation] = domain.ClusterIssuer
+		ingress.Annotations = annotations
+	}
+
+	ingress.Spec.TLS = append(ingress.Spec.TLS, networkingv1.IngressTLS{
+		Hosts:      hosts,
+		SecretName: secretName,
+	})
+}
+
+// NewTLSSecret returns the secret with the wildcard certificate of TLSModeSecret,
+// it returns nil for other modes
+func NewTLSSecret(namespace string, domain *Domain) *corev1.Secret {
+	if domain == nil || domain.TLSMode != TLSModeSecret {
+		return nil
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TLSSecretName,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte(domain.TLSCert),
+			corev1.TLSPrivateKeyKey: []byte(domain.TLSKey),
+		},
+	}
+}
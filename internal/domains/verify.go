@@ -0,0 +1,35 @@
+package domains
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TXTResolver is satisfied by *net.Resolver
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// CheckOwnership tells whether the challenge record of domain has its verification token
+func CheckOwnership(ctx context.Context, resolver TXTResolver, domain *Domain) (bool, error) {
+	records, err := resolver.LookupTXT(ctx, domain.ChallengeRecord())
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false, nil
+		}
+
+		return false, errors.Wrapf(err, "fail to lookup txt records of %s", domain.ChallengeRecord())
+	}
+
+	for _, record := range records {
+		if strings.TrimSpace(record) == domain.VerificationToken {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,169 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/crypto"
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+// maxAllocateAttempts bounds retries when concurrent launches race for the same port
+const maxAllocateAttempts = 5
+
+const uniqueViolationCode = "23505"
+
+type dbEndpointsProvider struct {
+	db        *database.DB
+	secret    string
+	proxyHost string
+	portStart int
+	portEnd   int
+}
+
+// NewDBEndpointsProvider stores endpoints in the database with credentials encrypted
+// with secret, stream endpoints get a port of proxyHost in [portStart, portEnd]
+func NewDBEndpointsProvider(db *database.DB, secret, proxyHost string, portStart, portEnd int) *dbEndpointsProvider {
+	return &dbEndpointsProvider{db, secret, proxyHost, portStart, portEnd}
+}
+
+func (ep *dbEndpointsProvider) Allocate(ctx context.Context, req AllocateRequest) (*Endpoint, error) {
+	if !req.Protocol.IsValid() || req.Protocol == ProtocolHTTP {
+		return nil, errors.Errorf("protocol %s has no endpoints", req.Protocol)
+	}
+
+	credential, err := generateCredential()
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to generate credential")
+	}
+
+	encryptedCredential, err := crypto.Encrypt(ep.secret, credential)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to encrypt credential")
+	}
+
+	dbEndpoint := database.ServiceEndpoint{
+		EnvironmentID: req.EnvironmentID,
+		Service:       req.Service,
+		Protocol:      string(req.Protocol),
+		TargetPort:    req.TargetPort,
+		Host:          req.Host,
+		PublicPort:    443,
+		Credential:    encryptedCredential,
+	}
+
+	if !req.Protocol.IsStream() {
+		err := ep.db.WithContext(ctx).Create(&dbEndpoint).Error
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to create endpoint")
+		}
+
+		return ep.fromDB(dbEndpoint, credential), nil
+	}
+
+	dbEndpoint.Host = ep.proxyHost
+	for attempt := 0; attempt < maxAllocateAttempts; attempt++ {
+		port, err := ep.freePort(ctx, req.Protocol)
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to find free port")
+		}
+
+		dbEndpoint.ID = uuid.Nil
+		dbEndpoint.PublicPort = port
+		err = ep.db.WithContext(ctx).Create(&dbEndpoint).Error
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			continue
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to create endpoint")
+		}
+
+		return ep.fromDB(dbEndpoint, credential), nil
+	}
+
+	return nil, errors.Wrapf(ErrNoFreePort, "gave up after %d attempts", maxAllocateAttempts)
+}
+
+// freePort returns the lowest port of the range that no live environment holds,
+// ports of deleted environments are released on the way
+func (ep *dbEndpointsProvider) freePort(ctx context.Context, protocol Protocol) (int, error) {
+	err := ep.db.WithContext(ctx).Exec(`
+		DELETE FROM service_endpoints se
+		USING environments e
+		WHERE se.environment_id = e.id AND e.deleted_at IS NOT NULL`,
+	).Error
+	if err != nil {
+		return 0, errors.Wrap(err, "fail to release ports of deleted environments")
+	}
+
+	var used []int
+	err = ep.db.WithContext(ctx).Model(&database.ServiceEndpoint{}).
+		Where("protocol = ?", protocol).
+		Where("public_port BETWEEN ? AND ?", ep.portStart, ep.portEnd).
+		Pluck("public_port", &used).Error
+	if err != nil {
+		return 0, errors.Wrap(err, "fail to list used ports")
+	}
+
+	usedSet := make(map[int]struct{}, len(used))
+	for _, port := range used {
+		usedSet[port] = struct{}{}
+	}
+
+	for port := ep.portStart; port <= ep.portEnd; port++ {
+		if _, ok := usedSet[port]; !ok {
+			return port, nil
+		}
+	}
+
+	return 0, ErrNoFreePort
+}
+
+func (ep *dbEndpointsProvider) ListByEnvironment(ctx context.Context, envID uuid.UUID) ([]Endpoint, error) {
+	dbEndpoints, err := ep.db.FindEndpointsByEnvironment(envID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to find endpoints of environment %s", envID)
+	}
+
+	endpoints := make([]Endpoint, 0, len(dbEndpoints))
+	for _, dbEndpoint := range dbEndpoints {
+		credential, err := crypto.Decrypt(ep.secret, dbEndpoint.Credential)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to decrypt credential of endpoint %s", dbEndpoint.ID)
+		}
+
+		endpoints = append(endpoints, *ep.fromDB(dbEndpoint, credential))
+	}
+
+	return endpoints, nil
+}
+
+func (ep *dbEndpointsProvider) fromDB(dbEndpoint database.ServiceEndpoint, credential string) *Endpoint {
+	return &Endpoint{
+		ID:            dbEndpoint.ID,
+		EnvironmentID: dbEndpoint.EnvironmentID,
+		Service:       dbEndpoint.Service,
+		Protocol:      Protocol(dbEndpoint.Protocol),
+		TargetPort:    dbEndpoint.TargetPort,
+		Host:          dbEndpoint.Host,
+		PublicPort:    dbEndpoint.PublicPort,
+		Credential:    credential,
+	}
+}
+
+func generateCredential() (string, error) {
+	b := make([]byte, 24)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
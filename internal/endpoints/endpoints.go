@@ -0,0 +1,82 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+type Protocol string
+
+const (
+	ProtocolHTTP Protocol = "http"
+	ProtocolGRPC Protocol = "grpc"
+	ProtocolTCP  Protocol = "tcp"
+	ProtocolUDP  Protocol = "udp"
+)
+
+func (p Protocol) IsValid() bool {
+	switch p {
+	case ProtocolHTTP, ProtocolGRPC, ProtocolTCP, ProtocolUDP:
+		return true
+	}
+
+	return false
+}
+
+// IsStream tells whether the protocol goes through the shared stream proxy
+// instead of an ingress
+func (p Protocol) IsStream() bool {
+	return p == ProtocolTCP || p == ProtocolUDP
+}
+
+var ErrNoFreePort = errors.New("no free port left in the stream proxy range")
+
+// Endpoint is a port of a preview service that is reachable from outside the
+// cluster without going through the regular http ingress.
+type Endpoint struct {
+	ID            uuid.UUID `json:"id"`
+	EnvironmentID uuid.UUID `json:"environmentId"`
+	Service       string    `json:"service"`
+	Protocol      Protocol  `json:"protocol"`
+	TargetPort    int       `json:"targetPort"`
+	Host          string    `json:"host"`
+	PublicPort    int       `json:"publicPort"`
+	// Credential is handed to the service through CredentialEnvVar, the stream
+	// proxy can't authenticate clients so the service itself has to require it,
+	// as in POSTGRES_PASSWORD: $(ERGOMAKE_TCP_5432_CREDENTIAL)
+	Credential string `json:"credential"`
+}
+
+func (e *Endpoint) Address() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(e.PublicPort))
+}
+
+// CredentialEnvVar is the name of the env var that holds the credential inside the service
+func (e *Endpoint) CredentialEnvVar() string {
+	return fmt.Sprintf("ERGOMAKE_%s_%d_CREDENTIAL", strings.ToUpper(string(e.Protocol)), e.TargetPort)
+}
+
+type AllocateRequest struct {
+	EnvironmentID uuid.UUID
+	Service       string
+	Protocol      Protocol
+	TargetPort    int
+	// Host is where grpc endpoints are served, stream endpoints always use the proxy host
+	Host string
+}
+
+type EndpointsProvider interface {
+	Allocate(ctx context.Context, req AllocateRequest) (*Endpoint, error)
+	ListByEnvironment(ctx context.Context, envID uuid.UUID) ([]Endpoint, error)
+}
+
+// Proxy routes the public ports of stream endpoints to their services
+type Proxy interface {
+	Expose(ctx context.Context, namespace string, endpoints []Endpoint) error
+}
@@ -0,0 +1,49 @@
+package endpoints
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	clusterMocks "github.com/ergomake/ergomake/mocks/cluster"
+)
+
+func TestProtocol(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ProtocolGRPC.IsValid())
+	assert.False(t, Protocol("sctp").IsValid())
+	assert.True(t, ProtocolUDP.IsStream())
+	assert.False(t, ProtocolGRPC.IsStream())
+}
+
+func TestEndpoint(t *testing.T) {
+	t.Parallel()
+
+	endpoint := Endpoint{Protocol: ProtocolTCP, TargetPort: 5432, Host: "tcp.ergomake.link", PublicPort: 30001}
+	assert.Equal(t, "tcp.ergomake.link:30001", endpoint.Address())
+	assert.Equal(t, "ERGOMAKE_TCP_5432_CREDENTIAL", endpoint.CredentialEnvVar())
+}
+
+func TestIngressNginxProxy_Expose(t *testing.T) {
+	t.Parallel()
+
+	clusterClient := clusterMocks.NewClient(t)
+	clusterClient.EXPECT().PatchConfigMapData(mock.Anything, "ingress-nginx", "tcp-services", map[string]string{
+		"30001": "env-ns/db:5432",
+	}).Return(nil)
+	clusterClient.EXPECT().PatchConfigMapData(mock.Anything, "ingress-nginx", "udp-services", map[string]string{
+		"30002": "env-ns/dns:53",
+	}).Return(nil)
+
+	proxy := NewIngressNginxProxy(clusterClient, "ingress-nginx")
+	err := proxy.Expose(context.Background(), "env-ns", []Endpoint{
+		{Service: "db", Protocol: ProtocolTCP, TargetPort: 5432, PublicPort: 30001},
+		{Service: "dns", Protocol: ProtocolUDP, TargetPort: 53, PublicPort: 30002},
+		{Service: "api", Protocol: ProtocolGRPC, TargetPort: 50051, PublicPort: 443},
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,58 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/cluster"
+)
+
+// ingress-nginx reads what to proxy on each port from these configmaps
+var proxyConfigMaps = map[Protocol]string{
+	ProtocolTCP: "tcp-services",
+	ProtocolUDP: "udp-services",
+}
+
+type ingressNginxProxy struct {
+	clusterClient    cluster.Client
+	ingressNamespace string
+}
+
+// NewIngressNginxProxy exposes stream endpoints through the tcp-services and
+// udp-services configmaps of ingress-nginx. The controller service must already
+// listen on the whole port range given to the endpoints provider.
+func NewIngressNginxProxy(clusterClient cluster.Client, ingressNamespace string) *ingressNginxProxy {
+	return &ingressNginxProxy{clusterClient, ingressNamespace}
+}
+
+func (p *ingressNginxProxy) Expose(ctx context.Context, namespace string, endpoints []Endpoint) error {
+	data := map[Protocol]map[string]string{}
+	for _, endpoint := range endpoints {
+		if !endpoint.Protocol.IsStream() {
+			continue
+		}
+
+		if data[endpoint.Protocol] == nil {
+			data[endpoint.Protocol] = map[string]string{}
+		}
+
+		data[endpoint.Protocol][strconv.Itoa(endpoint.PublicPort)] = fmt.Sprintf(
+			"%s/%s:%d",
+			namespace,
+			endpoint.Service,
+			endpoint.TargetPort,
+		)
+	}
+
+	for protocol, entries := range data {
+		err := p.clusterClient.PatchConfigMapData(ctx, p.ingressNamespace, proxyConfigMaps[protocol], entries)
+		if err != nil {
+			return errors.Wrapf(err, "fail to expose %s endpoints", protocol)
+		}
+	}
+
+	return nil
+}
@@ -10,8 +10,13 @@ import (
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/cluster"
 	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/github/ghapp"
+	"github.com/ergomake/ergomake/internal/github/ghdeployments"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/notifications"
 	"github.com/ergomake/ergomake/internal/payment"
 	"github.com/ergomake/ergomake/internal/permanentbranches"
 )
@@ -31,6 +36,9 @@ type dbEnvironmentsProvider struct {
 	envLimitAmount            int
 	permanentBranchesProvider permanentbranches.PermanentBranchesProvider
 	clusterClient             cluster.Client
+	ghApp                     ghapp.GHAppClient
+	notifier                  notifications.Notifier
+	auditProvider             audit.AuditProvider
 }
 
 func NewDBEnvironmentsProvider(
@@ -39,8 +47,20 @@ func NewDBEnvironmentsProvider(
 	envLimitAmount int,
 	permanentBranchesProvider permanentbranches.PermanentBranchesProvider,
 	clusterClient cluster.Client,
+	ghApp ghapp.GHAppClient,
+	notifier notifications.Notifier,
+	auditProvider audit.AuditProvider,
 ) *dbEnvironmentsProvider {
-	return &dbEnvironmentsProvider{db, paymentProvider, envLimitAmount, permanentBranchesProvider, clusterClient}
+	return &dbEnvironmentsProvider{
+		db,
+		paymentProvider,
+		envLimitAmount,
+		permanentBranchesProvider,
+		clusterClient,
+		ghApp,
+		notifier,
+		auditProvider,
+	}
 }
 
 func (ep *dbEnvironmentsProvider) IsOwnerLimited(ctx context.Context, owner string) (bool, error) {
@@ -74,7 +94,8 @@ func (ep *dbEnvironmentsProvider) IsOwnerLimited(ctx context.Context, owner stri
 	}
 	currentEnvCount := 0
 	for _, env := range ownerEnvs {
-		if env.Status == database.EnvLimited || env.Status == database.EnvDegraded {
+		if env.Status == database.EnvLimited || env.Status == database.EnvDegraded ||
+			env.Status == database.EnvAwaitingApproval {
 			continue
 		}
 		currentEnvCount += 1
@@ -90,7 +111,7 @@ func (ep *dbEnvironmentsProvider) GetEnvironmentFromHost(
 	var env database.Environment
 	err := ep.db.Table("environments").Select("environments.*").
 		Joins("INNER JOIN services s ON s.environment_id = environments.id").
-		Where("s.url = ?", host).
+		Where("s.url = ? OR ? = ANY(s.hosts)", host, host).
 		Order("environments.created_at DESC").
 		Preload("Services").
 		First(&env).Error
@@ -102,6 +123,20 @@ func (ep *dbEnvironmentsProvider) GetEnvironmentFromHost(
 	return &env, errors.Wrapf(err, "fail to query for environment of host %s", host)
 }
 
+func (ep *dbEnvironmentsProvider) GetEnvironment(ctx context.Context, id uuid.UUID) (*database.Environment, error) {
+	var env database.Environment
+	err := ep.db.Unscoped().First(&env, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.Wrapf(ErrEnvironmentNotFound, "no environment found for id %s", id)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to query for environment %s", id)
+	}
+
+	return &env, nil
+}
+
 func (ep *dbEnvironmentsProvider) SaveEnvironment(ctx context.Context, env *database.Environment) error {
 	err := ep.db.Save(env).Error
 	return errors.Wrap(err, "fail to save environment to db")
@@ -153,6 +188,24 @@ func (ep *dbEnvironmentsProvider) ListEnvironmentsByBranch(
 	return envs, err
 }
 
+func (ep *dbEnvironmentsProvider) ListEnvironmentsByRepo(
+	ctx context.Context,
+	owner, repo string,
+) ([]*database.Environment, error) {
+	envs := make([]*database.Environment, 0)
+
+	err := ep.db.Table("environments").
+		Preload("Services", func(db *gorm.DB) *gorm.DB {
+			return db.Order("services.index ASC")
+		}).
+		Find(&envs, map[string]string{
+			"owner": owner,
+			"repo":  repo,
+		}).Error
+
+	return envs, errors.Wrapf(err, "fail to list environments of repo %s/%s", owner, repo)
+}
+
 func (ep *dbEnvironmentsProvider) DeleteEnvironment(ctx context.Context, id uuid.UUID) error {
 	return ep.db.Table("environments").Delete(&database.Environment{ID: id}).Error
 }
@@ -184,6 +237,25 @@ func (ep *dbEnvironmentsProvider) TerminateEnvironment(ctx context.Context, req
 		if err != nil {
 			return errors.Wrap(err, "fail to delete environment in DB")
 		}
+
+		err = ghdeployments.SetStatus(ctx, ep.ghApp, env, ghdeployments.StateInactive, "", "")
+		if err != nil {
+			logger.Ctx(ctx).Err(err).Str("env", env.ID.String()).Msg("fail to set deployment of terminated environment as inactive")
+		}
+
+		ep.notifier.Notify(ctx, notifications.NewEnvironmentEvent(notifications.EventTerminated, env, "", "", ""))
+
+		err = ep.auditProvider.Record(ctx, audit.Entry{
+			Actor:  req.Actor,
+			Owner:  env.Owner,
+			Repo:   env.Repo,
+			Action: audit.ActionEnvironmentTerminate,
+			Target: env.ID.String(),
+			Diff:   audit.NewDiff(map[string]interface{}{"branch": env.Branch.String, "prNumber": req.PrNumber}),
+		})
+		if err != nil {
+			logger.Ctx(ctx).Err(err).Str("env", env.ID.String()).Msg("fail to record environment termination in audit log")
+		}
 	}
 
 	return nil
@@ -11,7 +11,10 @@ import (
 	"github.com/ergomake/ergomake/e2e/testutils"
 	"github.com/ergomake/ergomake/internal/database"
 	"github.com/ergomake/ergomake/internal/payment"
+	auditMocks "github.com/ergomake/ergomake/mocks/audit"
 	clusterMocks "github.com/ergomake/ergomake/mocks/cluster"
+	ghAppMocks "github.com/ergomake/ergomake/mocks/github/ghapp"
+	notificationsMocks "github.com/ergomake/ergomake/mocks/notifications"
 	paymentMocks "github.com/ergomake/ergomake/mocks/payment"
 	permanentbranchesMocks "github.com/ergomake/ergomake/mocks/permanentbranches"
 )
@@ -103,6 +106,9 @@ func TestDBEnvironmentsProvider_IsOwnerLimited(t *testing.T) {
 				tc.limit,
 				permanentbranchesMocks.NewPermanentBranchesProvider(t),
 				clusterMocks.NewClient(t),
+				ghAppMocks.NewGHAppClient(t),
+				notificationsMocks.NewNotifier(t),
+				auditMocks.NewAuditProvider(t),
 			)
 			limited, err := ep.IsOwnerLimited(context.Background(), "owner")
 			require.NoError(t, err)
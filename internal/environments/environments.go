@@ -16,15 +16,20 @@ type TerminateEnvironmentRequest struct {
 	Repo     string
 	Branch   string
 	PrNumber *int
+	// Actor is who asked for the termination, it is empty when ergomake did it by itself
+	Actor string
 }
 
 type EnvironmentsProvider interface {
 	IsOwnerLimited(ctx context.Context, owner string) (bool, error)
 	GetEnvironmentFromHost(ctx context.Context, host string) (*database.Environment, error)
+	// GetEnvironment finds an environment by id, including terminated ones
+	GetEnvironment(ctx context.Context, id uuid.UUID) (*database.Environment, error)
 	SaveEnvironment(ctx context.Context, env *database.Environment) error
 	ListSuccessEnvironments(ctx context.Context) ([]*database.Environment, error)
 	ShouldDeploy(ctx context.Context, owner string, repo string, branch string) (bool, error)
 	ListEnvironmentsByBranch(ctx context.Context, owner, repo, branch string) ([]*database.Environment, error)
+	ListEnvironmentsByRepo(ctx context.Context, owner, repo string) ([]*database.Environment, error)
 	DeleteEnvironment(ctx context.Context, id uuid.UUID) error
 	TerminateEnvironment(ctx context.Context, req TerminateEnvironmentRequest) error
 }
@@ -3,6 +3,7 @@ package envvars
 import (
 	"context"
 	"database/sql"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,27 +16,62 @@ import (
 )
 
 type EnvVar struct {
-	Name   string  `json:"name"`
-	Value  string  `json:"value"`
-	Branch *string `json:"branch"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Scope
+	// Group is the variable group the variable comes from, empty for repo variables
+	Group string `json:"group,omitempty"`
+	// Plain variables aren't secret, their values are shown and not redacted from
+	// logs. Variables are secret unless marked plain.
+	Plain bool `json:"plain,omitempty"`
+	// External tells the value was read from the secret store of the owner
+	External bool `json:"-"`
 }
 
+// IsSecret tells whether the value of v must be kept from code that isn't
+// trusted and from logs. Values of groups and of secret stores are secret even
+// when marked plain, only the repo can tell its own variables aren't secret.
+func (v EnvVar) IsSecret() bool {
+	return !v.Plain || v.External || v.Group != ""
+}
+
+var ErrVersionMismatch = errors.New("env vars were changed since the given version")
+
 type EnvVarsProvider interface {
-	Upsert(ctx context.Context, owner, repo, name, value string, branch *string) error
-	Delete(ctx context.Context, owner, repo, name string, branch *string) error
+	Upsert(ctx context.Context, owner, repo, name, value string, scope Scope) error
+	Delete(ctx context.Context, owner, repo, name string, scope Scope) error
 	ListByRepo(ctx context.Context, owner, repo string) ([]EnvVar, error)
-	ListByRepoBranch(ctx context.Context, owner, repo, branch string) ([]EnvVar, error)
+	// ListEffective returns the variables that apply to target, see Effective
+	ListEffective(ctx context.Context, owner, repo string, target Target) ([]EnvVar, error)
+	// Version is increased by every change to the variables of repo
+	Version(ctx context.Context, owner, repo string) (int64, error)
+	// Replace makes vars the only variables of repo, failing with ErrVersionMismatch
+	// when version is given and the variables of repo are at another one
+	Replace(ctx context.Context, owner, repo string, vars []EnvVar, version *int64) (*ChangeResult, error)
+	// Patch upserts and removes variables of repo, see Replace. Only the name and
+	// scope of the variables to remove matter.
+	Patch(ctx context.Context, owner, repo string, upsert, remove []EnvVar, version *int64) (*ChangeResult, error)
+}
+
+// ChangeResult is the variables of a repo before and after a change
+type ChangeResult struct {
+	Before    []EnvVar
+	Variables []EnvVar
+	Version   int64
 }
 
 type DBEnvVar struct {
-	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Owner     string
-	Repo      string
-	Name      string
-	Value     string
-	Branch    sql.NullString
+	ID              uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Owner           string
+	Repo            string
+	Name            string
+	Value           string
+	Branch          sql.NullString
+	Service         sql.NullString
+	EnvironmentType sql.NullString
+	Plain           bool
 }
 
 type dbEnvVarsProvider struct {
@@ -47,92 +83,259 @@ func NewDBEnvVarProvider(db *database.DB, secret string) *dbEnvVarsProvider {
 	return &dbEnvVarsProvider{db, secret}
 }
 
-func (evp *dbEnvVarsProvider) Upsert(ctx context.Context, owner, repo, name, value string, branch *string) error {
-	encryptedValue, err := crypto.Encrypt(evp.secret, value)
+func (evp *dbEnvVarsProvider) Upsert(ctx context.Context, owner, repo, name, value string, scope Scope) error {
+	_, err := evp.Patch(ctx, owner, repo, []EnvVar{{Name: name, Value: value, Scope: scope}}, nil, nil)
+	return errors.Wrap(err, "failed to upsert env var")
+}
+
+func (evp *dbEnvVarsProvider) Delete(ctx context.Context, owner, repo, name string, scope Scope) error {
+	_, err := evp.Patch(ctx, owner, repo, nil, []EnvVar{{Name: name, Scope: scope}}, nil)
+	return errors.Wrap(err, "failed to delete env var")
+}
+
+func (evp *dbEnvVarsProvider) ListByRepo(ctx context.Context, owner, repo string) ([]EnvVar, error) {
+	dbVars, err := evp.listDBVars(evp.db.DB, owner, repo)
 	if err != nil {
-		return errors.Wrap(err, "failed to encrypt value")
+		return nil, err
 	}
 
-	var dbVar DBEnvVar
-	err = evp.db.Table("env_vars").Where(map[string]interface{}{
-		"owner":  owner,
-		"repo":   repo,
-		"name":   name,
-		"branch": branch,
-	}).Assign(map[string]interface{}{
-		"value": encryptedValue,
-	}).FirstOrCreate(&dbVar).Error
+	vars := make([]EnvVar, 0, len(dbVars))
+	for _, v := range dbVars {
+		envVar, err := evp.fromDB(v)
+		if err != nil {
+			return nil, err
+		}
+		vars = append(vars, envVar)
+	}
 
-	return errors.Wrap(err, "failed to upsert env var")
+	return vars, nil
 }
 
-func (evp *dbEnvVarsProvider) Delete(ctx context.Context, owner, repo, name string, branch *string) error {
-	err := evp.db.Table("env_vars").
-		Where(map[string]interface{}{
-			"owner":  owner,
-			"repo":   repo,
-			"name":   name,
-			"branch": branch,
-		}).
-		Delete(&DBEnvVar{}).Error
+func (evp *dbEnvVarsProvider) Version(ctx context.Context, owner, repo string) (int64, error) {
+	var versions []int64
+	err := evp.db.Table("env_var_versions").
+		Where(map[string]interface{}{"owner": owner, "repo": repo}).
+		Pluck("version", &versions).Error
+	if err != nil {
+		return 0, errors.Wrapf(err, "fail to get env vars version of repo %s/%s", owner, repo)
+	}
+
+	if len(versions) == 0 {
+		return 0, nil
+	}
 
-	return err
+	return versions[0], nil
 }
 
-func (evp *dbEnvVarsProvider) ListByRepo(ctx context.Context, owner, repo string) ([]EnvVar, error) {
-	vars := make([]EnvVar, 0)
-	var dbVars []DBEnvVar
-	err := evp.db.Table("env_vars").Where(map[string]string{
-		"owner": owner,
-		"repo":  repo,
-	}).Find(&dbVars).Error
+func (evp *dbEnvVarsProvider) Replace(
+	ctx context.Context,
+	owner, repo string,
+	vars []EnvVar,
+	version *int64,
+) (*ChangeResult, error) {
+	return evp.change(ctx, owner, repo, version, func(map[string]EnvVar) map[string]EnvVar {
+		wanted := make(map[string]EnvVar, len(vars))
+		for _, v := range vars {
+			wanted[v.Key()] = v
+		}
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return vars, nil
-	}
+		return wanted
+	})
+}
 
-	for _, v := range dbVars {
-		value, err := crypto.Decrypt(evp.secret, v.Value)
-		if err != nil {
-			return nil, errors.Wrapf(err, "fail to decrypt value of env var %s", v.ID)
+func (evp *dbEnvVarsProvider) Patch(
+	ctx context.Context,
+	owner, repo string,
+	upsert, remove []EnvVar,
+	version *int64,
+) (*ChangeResult, error) {
+	return evp.change(ctx, owner, repo, version, func(current map[string]EnvVar) map[string]EnvVar {
+		wanted := make(map[string]EnvVar, len(current))
+		for key, v := range current {
+			wanted[key] = v
 		}
 
-		var branch *string
-		if v.Branch.Valid {
-			branch = pointer.String(v.Branch.String)
+		for _, v := range remove {
+			delete(wanted, v.Key())
 		}
 
-		vars = append(vars, EnvVar{v.Name, value, branch})
-	}
+		for _, v := range upsert {
+			wanted[v.Key()] = v
+		}
 
-	return vars, err
+		return wanted
+	})
 }
 
-func (evp *dbEnvVarsProvider) ListByRepoBranch(ctx context.Context, owner, repo, branch string) ([]EnvVar, error) {
-	allRepoVars, err := evp.ListByRepo(ctx, owner, repo)
-	if err != nil {
-		return nil, errors.Wrapf(err, "fail to list env vars for repo %s/%s", owner, repo)
-	}
+// change applies the variables makeWanted returns out of the current ones in a
+// single transaction, with the version of the repo locked so that concurrent
+// changes are serialized
+func (evp *dbEnvVarsProvider) change(
+	ctx context.Context,
+	owner, repo string,
+	version *int64,
+	makeWanted func(current map[string]EnvVar) map[string]EnvVar,
+) (*ChangeResult, error) {
+	var result ChangeResult
+	err := evp.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(
+			"INSERT INTO env_var_versions (owner, repo) VALUES (?, ?) ON CONFLICT (owner, repo) DO NOTHING",
+			owner, repo,
+		).Error
+		if err != nil {
+			return errors.Wrap(err, "fail to create env vars version")
+		}
+
+		var current int64
+		err = tx.Raw(
+			"SELECT version FROM env_var_versions WHERE owner = ? AND repo = ? FOR UPDATE",
+			owner, repo,
+		).Scan(&current).Error
+		if err != nil {
+			return errors.Wrap(err, "fail to lock env vars version")
+		}
+
+		if version != nil && *version != current {
+			return ErrVersionMismatch
+		}
 
-	vars := make(map[string]EnvVar)
-	for _, v := range allRepoVars {
-		if v.Branch != nil {
-			if *v.Branch == branch {
-				vars[v.Name] = v
+		dbVars, err := evp.listDBVars(tx, owner, repo)
+		if err != nil {
+			return err
+		}
+
+		currentVars := make(map[string]EnvVar, len(dbVars))
+		currentIDs := make(map[string]uuid.UUID, len(dbVars))
+		for _, dbVar := range dbVars {
+			v, err := evp.fromDB(dbVar)
+			if err != nil {
+				return err
+			}
+
+			result.Before = append(result.Before, v)
+			currentVars[v.Key()] = v
+			currentIDs[v.Key()] = dbVar.ID
+		}
+
+		wanted := makeWanted(currentVars)
+
+		for key, id := range currentIDs {
+			if _, ok := wanted[key]; ok {
 				continue
 			}
+
+			err := tx.Table("env_vars").Delete(&DBEnvVar{}, "id = ?", id).Error
+			if err != nil {
+				return errors.Wrapf(err, "fail to delete env var %s", key)
+			}
 		}
 
-		_, ok := vars[v.Name]
-		if !ok {
-			vars[v.Name] = v
+		for key, v := range wanted {
+			result.Variables = append(result.Variables, v)
+
+			existing, ok := currentVars[key]
+			if ok && existing.Value == v.Value && existing.Plain == v.Plain {
+				continue
+			}
+
+			value, err := crypto.Encrypt(evp.secret, v.Value)
+			if err != nil {
+				return errors.Wrapf(err, "fail to encrypt value of env var %s", key)
+			}
+
+			if ok {
+				err = tx.Table("env_vars").
+					Where("id = ?", currentIDs[key]).
+					Updates(map[string]interface{}{"value": value, "plain": v.Plain, "updated_at": time.Now()}).Error
+			} else {
+				err = tx.Table("env_vars").Create(evp.toDB(owner, repo, v, value)).Error
+			}
+			if err != nil {
+				return errors.Wrapf(err, "fail to save env var %s", key)
+			}
 		}
+
+		result.Version = current + 1
+		err = tx.Exec(
+			"UPDATE env_var_versions SET version = ?, updated_at = NOW() WHERE owner = ? AND repo = ?",
+			result.Version, owner, repo,
+		).Error
+		return errors.Wrap(err, "fail to update env vars version")
+	})
+	if errors.Is(err, ErrVersionMismatch) {
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to change env vars of repo %s/%s", owner, repo)
+	}
+
+	sortByKey(result.Before)
+	sortByKey(result.Variables)
+
+	return &result, nil
+}
+
+func sortByKey(vars []EnvVar) {
+	sort.Slice(vars, func(i, j int) bool {
+		return vars[i].Key() < vars[j].Key()
+	})
+}
+
+func (evp *dbEnvVarsProvider) listDBVars(db *gorm.DB, owner, repo string) ([]DBEnvVar, error) {
+	var dbVars []DBEnvVar
+	err := db.Table("env_vars").Where(map[string]string{
+		"owner": owner,
+		"repo":  repo,
+	}).Find(&dbVars).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list env vars of repo %s/%s", owner, repo)
+	}
+
+	return dbVars, nil
+}
+
+func (evp *dbEnvVarsProvider) fromDB(v DBEnvVar) (EnvVar, error) {
+	value, err := crypto.Decrypt(evp.secret, v.Value)
+	if err != nil {
+		return EnvVar{}, errors.Wrapf(err, "fail to decrypt value of env var %s", v.ID)
+	}
+
+	var scope Scope
+	if v.Branch.Valid {
+		scope.Branch = pointer.String(v.Branch.String)
+	}
+	if v.Service.Valid {
+		scope.Service = pointer.String(v.Service.String)
 	}
+	if v.EnvironmentType.Valid {
+		environmentType := EnvironmentType(v.EnvironmentType.String)
+		scope.EnvironmentType = &environmentType
+	}
+
+	return EnvVar{Name: v.Name, Value: value, Scope: scope, Plain: v.Plain}, nil
+}
+
+func (evp *dbEnvVarsProvider) toDB(owner, repo string, v EnvVar, encryptedValue string) *DBEnvVar {
+	dbVar := &DBEnvVar{Owner: owner, Repo: repo, Name: v.Name, Value: encryptedValue, Plain: v.Plain}
+	if v.Branch != nil {
+		dbVar.Branch = sql.NullString{String: *v.Branch, Valid: true}
+	}
+	if v.Service != nil {
+		dbVar.Service = sql.NullString{String: *v.Service, Valid: true}
+	}
+	if v.EnvironmentType != nil {
+		dbVar.EnvironmentType = sql.NullString{String: string(*v.EnvironmentType), Valid: true}
+	}
+
+	return dbVar
+}
 
-	result := make([]EnvVar, 0)
-	for _, v := range vars {
-		result = append(result, v)
+func (evp *dbEnvVarsProvider) ListEffective(ctx context.Context, owner, repo string, target Target) ([]EnvVar, error) {
+	allRepoVars, err := evp.ListByRepo(ctx, owner, repo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list env vars for repo %s/%s", owner, repo)
 	}
 
-	return result, nil
+	return Effective(allRepoVars, target), nil
 }
@@ -0,0 +1,196 @@
+package envvars
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/secretstores"
+)
+
+var ErrReadOnly = errors.New("env vars provider is read only")
+
+type externalEnvVarsProvider struct {
+	base           EnvVarsProvider
+	configProvider secretstores.ConfigProvider
+	newStore       secretstores.StoreFactory
+}
+
+// NewExternalEnvVarsProvider resolves the values of base that reference the
+// secret store of the owner, as in vault://kv/app#DB_PASSWORD, when listing
+// the effective variables to deploy. Variables that base doesn't have are
+// read from the secret at the path configured for the store, so base takes
+// precedence. base can be nil to only use the store, which is read only.
+func NewExternalEnvVarsProvider(
+	base EnvVarsProvider,
+	configProvider secretstores.ConfigProvider,
+	newStore secretstores.StoreFactory,
+) *externalEnvVarsProvider {
+	return &externalEnvVarsProvider{base, configProvider, newStore}
+}
+
+func (evp *externalEnvVarsProvider) Upsert(ctx context.Context, owner, repo, name, value string, scope Scope) error {
+	if evp.base == nil {
+		return ErrReadOnly
+	}
+
+	return evp.base.Upsert(ctx, owner, repo, name, value, scope)
+}
+
+func (evp *externalEnvVarsProvider) Delete(ctx context.Context, owner, repo, name string, scope Scope) error {
+	if evp.base == nil {
+		return ErrReadOnly
+	}
+
+	return evp.base.Delete(ctx, owner, repo, name, scope)
+}
+
+func (evp *externalEnvVarsProvider) Version(ctx context.Context, owner, repo string) (int64, error) {
+	if evp.base == nil {
+		return 0, nil
+	}
+
+	return evp.base.Version(ctx, owner, repo)
+}
+
+func (evp *externalEnvVarsProvider) Replace(
+	ctx context.Context,
+	owner, repo string,
+	vars []EnvVar,
+	version *int64,
+) (*ChangeResult, error) {
+	if evp.base == nil {
+		return nil, ErrReadOnly
+	}
+
+	return evp.base.Replace(ctx, owner, repo, vars, version)
+}
+
+func (evp *externalEnvVarsProvider) Patch(
+	ctx context.Context,
+	owner, repo string,
+	upsert, remove []EnvVar,
+	version *int64,
+) (*ChangeResult, error) {
+	if evp.base == nil {
+		return nil, ErrReadOnly
+	}
+
+	return evp.base.Patch(ctx, owner, repo, upsert, remove, version)
+}
+
+// ListByRepo returns the variables of base as they are stored, references
+// are not resolved so that secrets don't leave the store outside of deploys
+func (evp *externalEnvVarsProvider) ListByRepo(ctx context.Context, owner, repo string) ([]EnvVar, error) {
+	if evp.base == nil {
+		return []EnvVar{}, nil
+	}
+
+	return evp.base.ListByRepo(ctx, owner, repo)
+}
+
+func (evp *externalEnvVarsProvider) ListEffective(ctx context.Context, owner, repo string, target Target) ([]EnvVar, error) {
+	vars := []EnvVar{}
+	if evp.base != nil {
+		var err error
+		vars, err = evp.base.ListEffective(ctx, owner, repo, target)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to list env vars for repo %s/%s", owner, repo)
+		}
+	}
+
+	config, err := evp.configProvider.Get(ctx, owner)
+	if errors.Is(err, secretstores.ErrConfigNotFound) {
+		for _, v := range vars {
+			if _, ok := secretstores.ParseReference(v.Value); ok {
+				return nil, errors.Errorf("env var %s references a secret store but owner %s has none configured", v.Name, owner)
+			}
+		}
+
+		return vars, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to get secret store of owner %s", owner)
+	}
+
+	store, err := evp.newStore(*config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to create secret store client for owner %s", owner)
+	}
+
+	// the same secret is usually referenced by several variables
+	secrets := map[string]map[string]string{}
+	read := func(path string) (map[string]string, error) {
+		if secret, ok := secrets[path]; ok {
+			return secret, nil
+		}
+
+		secret, err := store.Read(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		secrets[path] = secret
+
+		return secret, nil
+	}
+
+	names := map[string]struct{}{}
+	for i, v := range vars {
+		names[v.Name] = struct{}{}
+
+		ref, ok := secretstores.ParseReference(v.Value)
+		if !ok {
+			continue
+		}
+
+		if ref.Kind != config.Kind {
+			return nil, errors.Errorf("env var %s references a %s store but owner %s uses %s", v.Name, ref.Kind, owner, config.Kind)
+		}
+
+		secret, err := read(ref.Path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to read secret of env var %s", v.Name)
+		}
+
+		value, err := ref.Lookup(secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to resolve env var %s", v.Name)
+		}
+		vars[i].Value = value
+		vars[i].External = true
+	}
+
+	path, err := config.DefaultsPath(repo, target.Branch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to get default env vars path of %s/%s", owner, repo)
+	}
+
+	if path == "" {
+		return vars, nil
+	}
+
+	defaults, err := read(path)
+	if errors.Is(err, secretstores.ErrSecretNotFound) {
+		return vars, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to read default env vars of %s/%s", owner, repo)
+	}
+
+	defaultNames := make([]string, 0, len(defaults))
+	for name := range defaults {
+		if _, ok := names[name]; !ok && name != "" {
+			defaultNames = append(defaultNames, name)
+		}
+	}
+	sort.Strings(defaultNames)
+
+	for _, name := range defaultNames {
+		vars = append(vars, EnvVar{Name: name, Value: defaults[name], External: true})
+	}
+
+	return vars, nil
+}
@@ -0,0 +1,96 @@
+package envvars_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ergomake/ergomake/internal/envvars"
+	"github.com/ergomake/ergomake/internal/secretstores"
+	envvarsMocks "github.com/ergomake/ergomake/mocks/envvars"
+	secretstoresMocks "github.com/ergomake/ergomake/mocks/secretstores"
+)
+
+func TestExternalEnvVarsProvider_ListEffective(t *testing.T) {
+	t.Parallel()
+
+	target := envvars.Target{Branch: "main", Service: "api", EnvironmentType: envvars.EnvironmentTypePullRequest}
+	base := envvarsMocks.NewEnvVarsProvider(t)
+	base.EXPECT().ListEffective(mock.Anything, "acme", "api", target).Return([]envvars.EnvVar{
+		{Name: "DB_PASSWORD", Value: "vault://kv/api#DB_PASSWORD"},
+		{Name: "DB_USER", Value: "vault://kv/api#DB_USER"},
+		{Name: "PORT", Value: "8080"},
+	}, nil)
+
+	configProvider := secretstoresMocks.NewConfigProvider(t)
+	configProvider.EXPECT().Get(mock.Anything, "acme").Return(&secretstores.Config{
+		Owner: "acme",
+		Kind:  secretstores.KindVault,
+		Path:  "kv/defaults/{{repo}}",
+	}, nil)
+
+	store := secretstoresMocks.NewStore(t)
+	store.EXPECT().Read(mock.Anything, "kv/api").Return(map[string]string{
+		"DB_PASSWORD": "hunter2",
+		"DB_USER":     "api",
+	}, nil).Once()
+	store.EXPECT().Read(mock.Anything, "kv/defaults/api").Return(map[string]string{
+		"PORT":       "9090",
+		"SENTRY_DSN": "https://sentry",
+	}, nil).Once()
+
+	provider := envvars.NewExternalEnvVarsProvider(base, configProvider, func(secretstores.Config) (secretstores.Store, error) {
+		return store, nil
+	})
+
+	vars, err := provider.ListEffective(context.Background(), "acme", "api", target)
+	require.NoError(t, err)
+	assert.Equal(t, []envvars.EnvVar{
+		{Name: "DB_PASSWORD", Value: "hunter2", External: true},
+		{Name: "DB_USER", Value: "api", External: true},
+		{Name: "PORT", Value: "8080"},
+		{Name: "SENTRY_DSN", Value: "https://sentry", External: true},
+	}, vars)
+}
+
+func TestExternalEnvVarsProvider_ListEffectiveErrors(t *testing.T) {
+	t.Parallel()
+
+	target := envvars.Target{Branch: "main", Service: "api", EnvironmentType: envvars.EnvironmentTypePullRequest}
+	base := envvarsMocks.NewEnvVarsProvider(t)
+	base.EXPECT().ListEffective(mock.Anything, "acme", "api", target).Return([]envvars.EnvVar{
+		{Name: "DB_PASSWORD", Value: "vault://kv/api#MISSING"},
+	}, nil)
+
+	configProvider := secretstoresMocks.NewConfigProvider(t)
+	configProvider.EXPECT().Get(mock.Anything, "acme").Return(nil, secretstores.ErrConfigNotFound).Once()
+
+	provider := envvars.NewExternalEnvVarsProvider(base, configProvider, func(secretstores.Config) (secretstores.Store, error) {
+		store := secretstoresMocks.NewStore(t)
+		store.EXPECT().Read(mock.Anything, "kv/api").Return(map[string]string{}, nil)
+		return store, nil
+	})
+
+	_, err := provider.ListEffective(context.Background(), "acme", "api", target)
+	assert.Error(t, err)
+
+	configProvider.EXPECT().Get(mock.Anything, "acme").Return(&secretstores.Config{Owner: "acme", Kind: secretstores.KindVault}, nil).Once()
+	_, err = provider.ListEffective(context.Background(), "acme", "api", target)
+	assert.ErrorIs(t, err, secretstores.ErrKeyNotFound)
+}
+
+func TestExternalEnvVarsProvider_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	provider := envvars.NewExternalEnvVarsProvider(nil, secretstoresMocks.NewConfigProvider(t), nil)
+
+	err := provider.Upsert(context.Background(), "acme", "api", "PORT", "8080", envvars.Scope{})
+	assert.ErrorIs(t, err, envvars.ErrReadOnly)
+
+	vars, err := provider.ListByRepo(context.Background(), "acme", "api")
+	require.NoError(t, err)
+	assert.Empty(t, vars)
+}
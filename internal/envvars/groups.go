@@ -0,0 +1,66 @@
+package envvars
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/variablegroups"
+)
+
+type groupsEnvVarsProvider struct {
+	EnvVarsProvider
+	groupsProvider variablegroups.GroupsProvider
+}
+
+// NewGroupsEnvVarsProvider adds the variables of the groups attached to a repo
+// to the effective variables of base, see MergeGroups
+func NewGroupsEnvVarsProvider(
+	base EnvVarsProvider,
+	groupsProvider variablegroups.GroupsProvider,
+) *groupsEnvVarsProvider {
+	return &groupsEnvVarsProvider{base, groupsProvider}
+}
+
+func (evp *groupsEnvVarsProvider) ListEffective(ctx context.Context, owner, repo string, target Target) ([]EnvVar, error) {
+	vars, err := evp.EnvVarsProvider.ListEffective(ctx, owner, repo, target)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list env vars for repo %s/%s", owner, repo)
+	}
+
+	groups, err := evp.groupsProvider.ListByRepo(ctx, owner, repo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list variable groups of repo %s/%s", owner, repo)
+	}
+
+	return MergeGroups(vars, groups), nil
+}
+
+// MergeGroups adds the variables of groups that vars doesn't have, so repo
+// variables take precedence over groups, and groups earlier in the list take
+// precedence over later ones. The result is sorted by name.
+func MergeGroups(vars []EnvVar, groups []variablegroups.Group) []EnvVar {
+	merged := append([]EnvVar{}, vars...)
+	names := make(map[string]struct{}, len(vars))
+	for _, v := range vars {
+		names[v.Name] = struct{}{}
+	}
+
+	for _, group := range groups {
+		for _, v := range group.Variables {
+			if _, ok := names[v.Name]; ok {
+				continue
+			}
+			names[v.Name] = struct{}{}
+
+			merged = append(merged, EnvVar{Name: v.Name, Value: v.Value, Group: group.Name})
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Name < merged[j].Name
+	})
+
+	return merged
+}
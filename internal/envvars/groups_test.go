@@ -0,0 +1,33 @@
+package envvars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ergomake/ergomake/internal/variablegroups"
+)
+
+func TestMergeGroups(t *testing.T) {
+	t.Parallel()
+
+	vars := []EnvVar{{Name: "STRIPE_KEY", Value: "repo"}, {Name: "PORT", Value: "8080"}}
+	groups := []variablegroups.Group{
+		{Name: "payments", Variables: []variablegroups.Variable{
+			{Name: "STRIPE_KEY", Value: "payments"},
+			{Name: "STRIPE_WEBHOOK", Value: "payments"},
+		}},
+		{Name: "shared", Variables: []variablegroups.Variable{
+			{Name: "SENTRY_DSN", Value: "shared"},
+			{Name: "STRIPE_WEBHOOK", Value: "shared"},
+		}},
+	}
+
+	assert.Equal(t, []EnvVar{
+		{Name: "PORT", Value: "8080"},
+		{Name: "SENTRY_DSN", Value: "shared", Group: "shared"},
+		{Name: "STRIPE_KEY", Value: "repo"},
+		{Name: "STRIPE_WEBHOOK", Value: "payments", Group: "payments"},
+	}, MergeGroups(vars, groups))
+	assert.Equal(t, "8080", vars[1].Value)
+}
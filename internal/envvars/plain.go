@@ -0,0 +1,34 @@
+package envvars
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+type plainEnvVarsProvider struct {
+	EnvVarsProvider
+}
+
+// NewPlainEnvVarsProvider only lets the plain variables of base be deployed,
+// for previews of code that isn't trusted with secrets. Variables of groups and
+// of secret stores are secret.
+func NewPlainEnvVarsProvider(base EnvVarsProvider) *plainEnvVarsProvider {
+	return &plainEnvVarsProvider{base}
+}
+
+func (evp *plainEnvVarsProvider) ListEffective(ctx context.Context, owner, repo string, target Target) ([]EnvVar, error) {
+	vars, err := evp.EnvVarsProvider.ListEffective(ctx, owner, repo, target)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list env vars for repo %s/%s", owner, repo)
+	}
+
+	plain := []EnvVar{}
+	for _, v := range vars {
+		if !v.IsSecret() {
+			plain = append(plain, v)
+		}
+	}
+
+	return plain, nil
+}
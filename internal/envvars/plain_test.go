@@ -0,0 +1,30 @@
+package envvars_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ergomake/ergomake/internal/envvars"
+	envvarsMocks "github.com/ergomake/ergomake/mocks/envvars"
+)
+
+func TestPlainEnvVarsProvider_ListEffective(t *testing.T) {
+	t.Parallel()
+
+	target := envvars.Target{Branch: "fix", EnvironmentType: envvars.EnvironmentTypePullRequest}
+	base := envvarsMocks.NewEnvVarsProvider(t)
+	base.EXPECT().ListEffective(mock.Anything, "acme", "api", target).Return([]envvars.EnvVar{
+		{Name: "API_URL", Value: "https://api.acme.dev", Plain: true},
+		{Name: "DB_PASSWORD", Value: "hunter2"},
+		{Name: "PORT", Value: "8080", Plain: true, External: true},
+		{Name: "SENTRY_DSN", Value: "https://sentry", Group: "shared"},
+	}, nil)
+
+	vars, err := envvars.NewPlainEnvVarsProvider(base).ListEffective(context.Background(), "acme", "api", target)
+	require.NoError(t, err)
+	assert.Equal(t, []envvars.EnvVar{{Name: "API_URL", Value: "https://api.acme.dev", Plain: true}}, vars)
+}
@@ -0,0 +1,201 @@
+package envvars
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/secretstores"
+)
+
+type EnvironmentType string
+
+const (
+	EnvironmentTypePullRequest     EnvironmentType = "pull-request"
+	EnvironmentTypePermanentBranch EnvironmentType = "permanent-branch"
+)
+
+func (t EnvironmentType) IsValid() bool {
+	switch t {
+	case EnvironmentTypePullRequest, EnvironmentTypePermanentBranch:
+		return true
+	}
+
+	return false
+}
+
+// Scope narrows down where a variable applies, nil fields match everything.
+// Branch is either a branch name or a glob pattern such as release/*, where *
+// doesn't match slashes.
+type Scope struct {
+	Branch          *string          `json:"branch"`
+	Service         *string          `json:"service"`
+	EnvironmentType *EnvironmentType `json:"environmentType"`
+}
+
+func (s Scope) Validate() error {
+	if s.Branch != nil {
+		if *s.Branch == "" {
+			return errors.New("branch can't be empty")
+		}
+
+		if _, err := path.Match(*s.Branch, ""); err != nil {
+			return errors.Errorf("branch pattern `%s` is malformed", *s.Branch)
+		}
+	}
+
+	if s.Service != nil && *s.Service == "" {
+		return errors.New("service can't be empty")
+	}
+
+	if s.EnvironmentType != nil && !s.EnvironmentType.IsValid() {
+		return errors.Errorf("environment type `%s` is not one of %s or %s",
+			*s.EnvironmentType, EnvironmentTypePullRequest, EnvironmentTypePermanentBranch)
+	}
+
+	return nil
+}
+
+// String identifies the scope in audit logs and command line output, eg:
+// @release/*[service=web,type=pull-request]
+func (s Scope) String() string {
+	str := ""
+	if s.Branch != nil {
+		str = "@" + *s.Branch
+	}
+
+	qualifiers := []string{}
+	if s.Service != nil {
+		qualifiers = append(qualifiers, "service="+*s.Service)
+	}
+	if s.EnvironmentType != nil {
+		qualifiers = append(qualifiers, "type="+string(*s.EnvironmentType))
+	}
+	if len(qualifiers) > 0 {
+		str += fmt.Sprintf("[%s]", strings.Join(qualifiers, ","))
+	}
+
+	return str
+}
+
+// Key identifies a variable among the variables of a repo
+func (v EnvVar) Key() string {
+	return v.Name + v.Scope.String()
+}
+
+// Target is what variables are listed for when deploying
+type Target struct {
+	Branch string
+	// Service is left empty to list only variables that aren't scoped by service
+	Service         string
+	EnvironmentType EnvironmentType
+}
+
+func (s Scope) matches(target Target) bool {
+	if s.Service != nil && *s.Service != target.Service {
+		return false
+	}
+
+	if s.EnvironmentType != nil && *s.EnvironmentType != target.EnvironmentType {
+		return false
+	}
+
+	if s.Branch != nil {
+		matched, err := path.Match(*s.Branch, target.Branch)
+		if err != nil || !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+const (
+	branchUnscoped = iota
+	branchGlob
+	branchExact
+)
+
+func (s Scope) branchKind() int {
+	if s.Branch == nil {
+		return branchUnscoped
+	}
+
+	if strings.ContainsAny(*s.Branch, `*?[\`) {
+		return branchGlob
+	}
+
+	return branchExact
+}
+
+// moreSpecific tells whether s takes precedence over other, see Effective
+func (s Scope) moreSpecific(other Scope) bool {
+	if (s.Service != nil) != (other.Service != nil) {
+		return s.Service != nil
+	}
+
+	if s.branchKind() != other.branchKind() {
+		return s.branchKind() > other.branchKind()
+	}
+
+	if s.branchKind() == branchGlob && len(*s.Branch) != len(*other.Branch) {
+		return len(*s.Branch) > len(*other.Branch)
+	}
+
+	if (s.EnvironmentType != nil) != (other.EnvironmentType != nil) {
+		return s.EnvironmentType != nil
+	}
+
+	return s.String() < other.String()
+}
+
+// Effective returns the variables that apply to target, sorted by name. When
+// several variables with the same name apply, the most specific one wins:
+//
+//  1. scoped to the service over not scoped by service
+//  2. exact branch over branch pattern over not scoped by branch, and longer
+//     patterns over shorter ones
+//  3. scoped to the environment type over not scoped by environment type
+func Effective(vars []EnvVar, target Target) []EnvVar {
+	effective := map[string]EnvVar{}
+	for _, v := range vars {
+		if !v.Scope.matches(target) {
+			continue
+		}
+
+		current, ok := effective[v.Name]
+		if !ok || v.Scope.moreSpecific(current.Scope) {
+			effective[v.Name] = v
+		}
+	}
+
+	result := make([]EnvVar, 0, len(effective))
+	for _, v := range effective {
+		result = append(result, v)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// MaskedValue replaces values that must not be shown
+const MaskedValue = "********"
+
+// Mask hides the value of v unless it is plain, references to secret stores are
+// kept since they are not secrets themselves
+func Mask(v EnvVar) EnvVar {
+	if v.Plain {
+		return v
+	}
+
+	if _, ok := secretstores.ParseReference(v.Value); !ok {
+		v.Value = MaskedValue
+	}
+
+	return v
+}
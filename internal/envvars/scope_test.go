@@ -0,0 +1,102 @@
+package envvars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+)
+
+func TestEffective(t *testing.T) {
+	t.Parallel()
+
+	pr := EnvironmentTypePullRequest
+	vars := []EnvVar{
+		{Name: "API_URL", Value: "default"},
+		{Name: "API_URL", Value: "release", Scope: Scope{Branch: pointer.String("release/*")}},
+		{Name: "API_URL", Value: "release-1", Scope: Scope{Branch: pointer.String("release/1.*")}},
+		{Name: "API_URL", Value: "web", Scope: Scope{Service: pointer.String("web")}},
+		{Name: "DEBUG", Value: "false"},
+		{Name: "DEBUG", Value: "true", Scope: Scope{EnvironmentType: &pr}},
+		{Name: "DEBUG", Value: "main", Scope: Scope{Branch: pointer.String("main")}},
+		{Name: "ONLY_WEB", Value: "1", Scope: Scope{Service: pointer.String("web")}},
+	}
+
+	tt := []struct {
+		name     string
+		target   Target
+		expected map[string]string
+	}{
+		{
+			name:     "unscoped",
+			target:   Target{Branch: "feature", Service: "api", EnvironmentType: EnvironmentTypePermanentBranch},
+			expected: map[string]string{"API_URL": "default", "DEBUG": "false"},
+		},
+		{
+			name:     "environment type",
+			target:   Target{Branch: "feature", Service: "api", EnvironmentType: EnvironmentTypePullRequest},
+			expected: map[string]string{"API_URL": "default", "DEBUG": "true"},
+		},
+		{
+			name:     "exact branch over environment type",
+			target:   Target{Branch: "main", Service: "api", EnvironmentType: EnvironmentTypePullRequest},
+			expected: map[string]string{"API_URL": "default", "DEBUG": "main"},
+		},
+		{
+			name:     "longest branch pattern",
+			target:   Target{Branch: "release/1.2", Service: "api", EnvironmentType: EnvironmentTypePermanentBranch},
+			expected: map[string]string{"API_URL": "release-1", "DEBUG": "false"},
+		},
+		{
+			name:     "pattern doesn't match slashes",
+			target:   Target{Branch: "release/2/hotfix", Service: "api", EnvironmentType: EnvironmentTypePermanentBranch},
+			expected: map[string]string{"API_URL": "default", "DEBUG": "false"},
+		},
+		{
+			name:     "service over branch",
+			target:   Target{Branch: "release/1.2", Service: "web", EnvironmentType: EnvironmentTypePermanentBranch},
+			expected: map[string]string{"API_URL": "web", "DEBUG": "false", "ONLY_WEB": "1"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := map[string]string{}
+			for _, v := range Effective(vars, tc.target) {
+				got[v.Name] = v.Value
+			}
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestScope_Validate(t *testing.T) {
+	t.Parallel()
+
+	invalidType := EnvironmentType("preview")
+	assert.NoError(t, Scope{Branch: pointer.String("release/*"), Service: pointer.String("web")}.Validate())
+	assert.Error(t, Scope{Branch: pointer.String("release/[")}.Validate())
+	assert.Error(t, Scope{Branch: pointer.String("")}.Validate())
+	assert.Error(t, Scope{EnvironmentType: &invalidType}.Validate())
+}
+
+func TestEnvVar_Key(t *testing.T) {
+	t.Parallel()
+
+	pr := EnvironmentTypePullRequest
+	assert.Equal(t, "API_URL", EnvVar{Name: "API_URL"}.Key())
+	assert.Equal(t, "API_URL@main", EnvVar{Name: "API_URL", Scope: Scope{Branch: pointer.String("main")}}.Key())
+	assert.Equal(t, "API_URL@release/*[service=web,type=pull-request]", EnvVar{
+		Name:  "API_URL",
+		Scope: Scope{Branch: pointer.String("release/*"), Service: pointer.String("web"), EnvironmentType: &pr},
+	}.Key())
+}
+
+func TestMask(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, MaskedValue, Mask(EnvVar{Name: "TOKEN", Value: "secret"}).Value)
+	assert.Equal(t, "vault://kv/app#TOKEN", Mask(EnvVar{Name: "TOKEN", Value: "vault://kv/app#TOKEN"}).Value)
+	assert.Equal(t, "info", Mask(EnvVar{Name: "LOG_LEVEL", Value: "info", Plain: true}).Value)
+}
@@ -8,6 +8,19 @@ type ErgopackApp struct {
 	Path          string            `yaml:"path"`
 	Image         string            `yaml:"image"`
 	PublicPort    string            `yaml:"publicPort"`
+	Routes        []ErgopackRoute   `yaml:"routes"`
 	InternalPorts []string          `yaml:"internalPorts"`
 	Env           map[string]string `yaml:"env"`
 }
+
+// ErgopackRoute exposes a port of an app at a path of a hostname. Host names
+// the hostname, apps routing to the same host share it, and it defaults to the
+// app name, which is also where publicPort is exposed. Protocol is one of http,
+// grpc, tcp or udp, tcp and udp ports get a port of the shared stream proxy
+// instead of a hostname.
+type ErgopackRoute struct {
+	Port     string `yaml:"port"`
+	Path     string `yaml:"path"`
+	Host     string `yaml:"host"`
+	Protocol string `yaml:"protocol"`
+}
@@ -0,0 +1,108 @@
+package forkpolicy
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+type forkPolicySettings struct {
+	ID              uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+	Owner           string
+	Repo            string
+	Secrets         string
+	RequireApproval bool
+}
+
+type forkApproval struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt  time.Time
+	Owner      string
+	Repo       string
+	PrNumber   int
+	SHA        string `gorm:"column:sha"`
+	ApprovedBy string
+}
+
+type dbPolicyProvider struct {
+	db *database.DB
+}
+
+func NewDBPolicyProvider(db *database.DB) *dbPolicyProvider {
+	return &dbPolicyProvider{db}
+}
+
+func (pp *dbPolicyProvider) GetSettings(ctx context.Context, owner, repo string) (*Settings, error) {
+	var dbSettings forkPolicySettings
+	err := pp.db.Table("fork_policy_settings").
+		First(&dbSettings, map[string]string{"owner": owner, "repo": repo}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		settings := DefaultSettings(owner, repo)
+		return &settings, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to find fork policy settings of repo %s/%s", owner, repo)
+	}
+
+	return &Settings{
+		Owner:           dbSettings.Owner,
+		Repo:            dbSettings.Repo,
+		Secrets:         SecretsPolicy(dbSettings.Secrets),
+		RequireApproval: dbSettings.RequireApproval,
+	}, nil
+}
+
+func (pp *dbPolicyProvider) UpsertSettings(ctx context.Context, settings Settings) error {
+	var dbSettings forkPolicySettings
+	err := pp.db.Table("fork_policy_settings").Where(map[string]interface{}{
+		"owner": settings.Owner,
+		"repo":  settings.Repo,
+	}).Assign(map[string]interface{}{
+		"secrets":          string(settings.Secrets),
+		"require_approval": settings.RequireApproval,
+	}).FirstOrCreate(&dbSettings).Error
+
+	return errors.Wrapf(err, "fail to upsert fork policy settings of repo %s/%s", settings.Owner, settings.Repo)
+}
+
+func (pp *dbPolicyProvider) Approve(ctx context.Context, approval Approval) error {
+	dbApproval := forkApproval{
+		Owner:      approval.Owner,
+		Repo:       approval.Repo,
+		PrNumber:   approval.PrNumber,
+		SHA:        approval.SHA,
+		ApprovedBy: approval.ApprovedBy,
+	}
+	err := pp.db.Table("fork_approvals").Clauses(clause.OnConflict{DoNothing: true}).Create(&dbApproval).Error
+
+	return errors.Wrapf(
+		err,
+		"fail to approve %s/%s#%d at %s",
+		approval.Owner, approval.Repo, approval.PrNumber, approval.SHA,
+	)
+}
+
+func (pp *dbPolicyProvider) IsApproved(ctx context.Context, owner, repo string, prNumber int, sha string) (bool, error) {
+	var count int64
+	err := pp.db.Table("fork_approvals").Where(map[string]interface{}{
+		"owner":     owner,
+		"repo":      repo,
+		"pr_number": prNumber,
+		"sha":       sha,
+	}).Count(&count).Error
+	if err != nil {
+		return false, errors.Wrapf(err, "fail to count approvals of %s/%s#%d at %s", owner, repo, prNumber, sha)
+	}
+
+	return count > 0, nil
+}
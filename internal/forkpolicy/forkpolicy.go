@@ -0,0 +1,150 @@
+package forkpolicy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SecretsPolicy tells whether previews of pull requests from forks get the
+// secret variables of the repo. Fork pull requests run code that anyone can
+// write, so like pull_request_target workflows of GitHub Actions, secrets are
+// only given to code a maintainer trusts. Plain variables are always given.
+type SecretsPolicy string
+
+const (
+	// SecretsNever never gives secrets to fork pull requests
+	SecretsNever SecretsPolicy = "never"
+	// SecretsApproved gives secrets to the commits of fork pull requests a
+	// maintainer approved, every new commit needs to be approved again
+	SecretsApproved SecretsPolicy = "approved"
+	// SecretsAlways gives secrets to every fork pull request, anyone who opens one
+	// is able to read them
+	SecretsAlways SecretsPolicy = "always"
+)
+
+func (p SecretsPolicy) IsValid() bool {
+	switch p {
+	case SecretsNever, SecretsApproved, SecretsAlways:
+		return true
+	}
+
+	return false
+}
+
+// ApprovalLabel is the label maintainers add to fork pull requests to approve
+// the head commit they had when labeled, it is removed when new commits are
+// pushed. Approvals are for both secrets and RequireApproval.
+const ApprovalLabel = "ergomake:approved"
+
+// ApprovalCommand is the comment maintainers write in fork pull requests,
+// followed by the SHA of the commit they approve. Approvals name their commit
+// so that commits pushed after a maintainer reviewed the code aren't approved.
+const ApprovalCommand = "/ergomake approve"
+
+// minApprovalSHALength is the length of the abbreviated SHAs GitHub shows
+const minApprovalSHALength = 7
+
+type Settings struct {
+	Owner   string        `json:"owner"`
+	Repo    string        `json:"repo"`
+	Secrets SecretsPolicy `json:"secrets"`
+	// RequireApproval holds previews of pull requests from forks, of authors who
+	// can't push to the repo, until a maintainer approves their head commit
+	RequireApproval bool `json:"requireApproval"`
+}
+
+func DefaultSettings(owner, repo string) Settings {
+	return Settings{Owner: owner, Repo: repo, Secrets: SecretsNever}
+}
+
+// Approval is a maintainer trusting the code of a commit of a pull request
+type Approval struct {
+	Owner      string
+	Repo       string
+	PrNumber   int
+	SHA        string
+	ApprovedBy string
+}
+
+type PolicyProvider interface {
+	GetSettings(ctx context.Context, owner, repo string) (*Settings, error)
+	UpsertSettings(ctx context.Context, settings Settings) error
+	// Approve records approval, approving the same commit twice is not an error
+	Approve(ctx context.Context, approval Approval) error
+	IsApproved(ctx context.Context, owner, repo string, prNumber int, sha string) (bool, error)
+}
+
+// IsFork tells whether a branch of branchOwner is from a fork of a repo of owner
+func IsFork(owner, branchOwner string) bool {
+	return branchOwner != "" && !strings.EqualFold(owner, branchOwner)
+}
+
+// CanApprove tells whether someone with a GitHub repository permission level
+// can approve pull requests, which is who can push to the repo anyway
+func CanApprove(permission string) bool {
+	switch permission {
+	case "admin", "maintain", "write":
+		return true
+	}
+
+	return false
+}
+
+// ParseApprovalCommand returns the SHA, possibly abbreviated, approved by the
+// body of a comment, ok is false when body isn't ApprovalCommand followed by a SHA
+func ParseApprovalCommand(body string) (sha string, ok bool) {
+	fields := strings.Fields(body)
+	if len(fields) != 3 || !strings.EqualFold(fields[0]+" "+fields[1], ApprovalCommand) {
+		return "", false
+	}
+
+	sha = strings.ToLower(fields[2])
+	if len(sha) < minApprovalSHALength || len(sha) > 40 {
+		return "", false
+	}
+
+	for _, c := range sha {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return "", false
+		}
+	}
+
+	return sha, true
+}
+
+// ApprovesSHA tells whether approvedSHA, possibly abbreviated, is sha
+func ApprovesSHA(approvedSHA, sha string) bool {
+	return len(approvedSHA) >= minApprovalSHALength && strings.HasPrefix(strings.ToLower(sha), approvedSHA)
+}
+
+// SecretsAllowed tells whether the preview of sha, of a branch of branchOwner,
+// gets the secret variables of owner/repo. Only pull requests from forks are
+// restricted, branches of the repo itself can only be pushed by collaborators.
+func SecretsAllowed(
+	ctx context.Context,
+	provider PolicyProvider,
+	owner, branchOwner, repo string,
+	prNumber *int,
+	sha string,
+) (bool, error) {
+	if !IsFork(owner, branchOwner) || prNumber == nil {
+		return true, nil
+	}
+
+	settings, err := provider.GetSettings(ctx, owner, repo)
+	if err != nil {
+		return false, errors.Wrapf(err, "fail to get fork policy of repo %s/%s", owner, repo)
+	}
+
+	switch settings.Secrets {
+	case SecretsAlways:
+		return true, nil
+	case SecretsApproved:
+		approved, err := provider.IsApproved(ctx, owner, repo, *prNumber, sha)
+		return approved, errors.Wrapf(err, "fail to check approval of %s/%s#%d at %s", owner, repo, *prNumber, sha)
+	}
+
+	return false, nil
+}
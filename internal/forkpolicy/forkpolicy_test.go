@@ -0,0 +1,92 @@
+package forkpolicy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ergomake/ergomake/internal/forkpolicy"
+	forkpolicyMocks "github.com/ergomake/ergomake/mocks/forkpolicy"
+)
+
+func TestParseApprovalCommand(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		body   string
+		wantOK bool
+		want   string
+	}{
+		{body: "/ergomake approve 1a2b3c4", wantOK: true, want: "1a2b3c4"},
+		{body: "  /Ergomake Approve 1A2B3C4D5E\n", wantOK: true, want: "1a2b3c4d5e"},
+		{body: "/ergomake approve"},
+		{body: "/ergomake approve 1a2b3c"},
+		{body: "/ergomake approve please"},
+		{body: "/ergomake approve 1a2b3c4 please"},
+		{body: "> /ergomake approve 1a2b3c4"},
+	}
+
+	for _, tc := range tt {
+		sha, ok := forkpolicy.ParseApprovalCommand(tc.body)
+		assert.Equal(t, tc.wantOK, ok, tc.body)
+		assert.Equal(t, tc.want, sha, tc.body)
+	}
+}
+
+func TestApprovesSHA(t *testing.T) {
+	t.Parallel()
+
+	sha := "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b"
+
+	assert.True(t, forkpolicy.ApprovesSHA(sha, sha))
+	assert.True(t, forkpolicy.ApprovesSHA("1a2b3c4", sha))
+	assert.False(t, forkpolicy.ApprovesSHA("1a2b3c5", sha))
+	assert.False(t, forkpolicy.ApprovesSHA("1a2b", sha))
+	assert.False(t, forkpolicy.ApprovesSHA("", sha))
+}
+
+func TestSecretsAllowed(t *testing.T) {
+	t.Parallel()
+
+	prNumber := 7
+
+	tt := []struct {
+		name        string
+		branchOwner string
+		prNumber    *int
+		policy      forkpolicy.SecretsPolicy
+		approved    bool
+		want        bool
+	}{
+		{name: "branch of the repo", branchOwner: "acme", prNumber: &prNumber, want: true},
+		{name: "branch of the repo with other case", branchOwner: "ACME", prNumber: &prNumber, want: true},
+		{name: "fork branch without pull request", branchOwner: "mallory", want: true},
+		{name: "never", branchOwner: "mallory", prNumber: &prNumber, policy: forkpolicy.SecretsNever, want: false},
+		{name: "always", branchOwner: "mallory", prNumber: &prNumber, policy: forkpolicy.SecretsAlways, want: true},
+		{name: "approved", branchOwner: "mallory", prNumber: &prNumber, policy: forkpolicy.SecretsApproved, approved: true, want: true},
+		{name: "not approved", branchOwner: "mallory", prNumber: &prNumber, policy: forkpolicy.SecretsApproved, want: false},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := forkpolicyMocks.NewPolicyProvider(t)
+			if tc.policy != "" {
+				provider.EXPECT().GetSettings(mock.Anything, "acme", "api").
+					Return(&forkpolicy.Settings{Owner: "acme", Repo: "api", Secrets: tc.policy}, nil)
+			}
+			if tc.policy == forkpolicy.SecretsApproved {
+				provider.EXPECT().IsApproved(mock.Anything, "acme", "api", prNumber, "abc").Return(tc.approved, nil)
+			}
+
+			allowed, err := forkpolicy.SecretsAllowed(context.Background(), provider, "acme", tc.branchOwner, "api", tc.prNumber, "abc")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, allowed)
+		})
+	}
+}
@@ -24,10 +24,21 @@ const CheckName string = "Ergomake"
 var InstallationNotFoundError = errors.New("installation not found")
 var RepoNotFoundError = errors.New("repository not found")
 var BranchNotFoundError = errors.New("branch not found")
+var FileNotFoundError = errors.New("file not found")
 
 type GHAppClient interface {
 	git.RemoteGitClient
 	CreateCommitStatus(ctx context.Context, owner, repo, sha, state string, targetURL *string) error
+	CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, error)
+	UpdateCheckRun(
+		ctx context.Context,
+		owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions,
+	) (*github.CheckRun, error)
+	CreateDeployment(ctx context.Context, owner, repo string, req *github.DeploymentRequest) (*github.Deployment, error)
+	CreateDeploymentStatus(
+		ctx context.Context,
+		owner, repo string, deploymentID int64, req *github.DeploymentStatusRequest,
+	) error
 	UpsertComment(
 		ctx context.Context,
 		owner string, repo string, prNumber int, commentID int64, comment string,
@@ -45,6 +56,12 @@ type GHAppClient interface {
 	GetBranchSHA(ctx context.Context, owner, repo, branch string) (string, error)
 	ListBranches(ctx context.Context, owner, repo string) ([]string, error)
 	IsRepoPrivate(ctx context.Context, owner, repo string) (bool, error)
+	GetFileContent(ctx context.Context, owner, repo, ref, path string) (string, error)
+	IsTeamMember(ctx context.Context, org, teamSlug, login string) (bool, error)
+	IsOrgMember(ctx context.Context, org, login string) (bool, error)
+	GetRepoPermissionLevel(ctx context.Context, owner, repo, login string) (string, error)
+	GetPullRequest(ctx context.Context, owner, repo string, prNumber int) (*github.PullRequest, error)
+	RemoveLabel(ctx context.Context, owner, repo string, prNumber int, label string) error
 }
 
 type ghAppClient struct {
@@ -157,6 +174,102 @@ func (gh *ghAppClient) CreateCommitStatus(ctx context.Context, owner, repo, sha,
 	return nil
 }
 
+func (gh *ghAppClient) CreateCheckRun(
+	ctx context.Context,
+	owner, repo string,
+	opts github.CreateCheckRunOptions,
+) (*github.CheckRun, error) {
+	installationClient, err := gh.getOwnerInstallationClient(ctx, owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create installation client")
+	}
+
+	checkRun, res, err := installationClient.Checks.CreateCheckRun(ctx, owner, repo, opts)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusForbidden {
+			logger.Ctx(ctx).Warn().AnErr("err", err).Msg("fail to create check run, missing permissions")
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to create check run")
+	}
+
+	return checkRun, nil
+}
+
+func (gh *ghAppClient) UpdateCheckRun(
+	ctx context.Context,
+	owner, repo string,
+	checkRunID int64,
+	opts github.UpdateCheckRunOptions,
+) (*github.CheckRun, error) {
+	installationClient, err := gh.getOwnerInstallationClient(ctx, owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create installation client")
+	}
+
+	checkRun, res, err := installationClient.Checks.UpdateCheckRun(ctx, owner, repo, checkRunID, opts)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusForbidden {
+			logger.Ctx(ctx).Warn().AnErr("err", err).Int64("checkRunID", checkRunID).
+				Msg("fail to update check run, missing permissions")
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "failed to update check run %d", checkRunID)
+	}
+
+	return checkRun, nil
+}
+
+func (gh *ghAppClient) CreateDeployment(
+	ctx context.Context,
+	owner, repo string,
+	req *github.DeploymentRequest,
+) (*github.Deployment, error) {
+	installationClient, err := gh.getOwnerInstallationClient(ctx, owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create installation client")
+	}
+
+	deployment, res, err := installationClient.Repositories.CreateDeployment(ctx, owner, repo, req)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusForbidden {
+			logger.Ctx(ctx).Warn().AnErr("err", err).Msg("fail to create deployment, missing permissions")
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to create deployment")
+	}
+
+	return deployment, nil
+}
+
+func (gh *ghAppClient) CreateDeploymentStatus(
+	ctx context.Context,
+	owner, repo string,
+	deploymentID int64,
+	req *github.DeploymentStatusRequest,
+) error {
+	installationClient, err := gh.getOwnerInstallationClient(ctx, owner)
+	if err != nil {
+		return errors.Wrap(err, "failed to create installation client")
+	}
+
+	_, res, err := installationClient.Repositories.CreateDeploymentStatus(ctx, owner, repo, deploymentID, req)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusForbidden {
+			logger.Ctx(ctx).Warn().AnErr("err", err).Str("state", req.GetState()).
+				Msg("fail to create deployment status, missing permissions")
+			return nil
+		}
+
+		return errors.Wrapf(err, "failed to create status for deployment %d", deploymentID)
+	}
+
+	return nil
+}
+
 func (gh *ghAppClient) PostComment(ctx context.Context, owner string, repo string, prNumber int, comment string) error {
 	installationClient, err := gh.getOwnerInstallationClient(ctx, owner)
 	if err != nil {
@@ -506,3 +619,115 @@ func (c *ghAppClient) IsRepoPrivate(ctx context.Context, owner, repo string) (bo
 
 	return repository.GetPrivate(), nil
 }
+
+// GetFileContent reads path at ref, an empty ref reads the default branch
+func (gh *ghAppClient) GetFileContent(ctx context.Context, owner, repo, ref, path string) (string, error) {
+	installationClient, err := gh.getOwnerInstallationClient(ctx, owner)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create installation client")
+	}
+
+	file, _, resp, err := installationClient.Repositories.GetContents(
+		ctx,
+		owner,
+		repo,
+		path,
+		&github.RepositoryContentGetOptions{Ref: ref},
+	)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", FileNotFoundError
+		}
+
+		return "", errors.Wrapf(err, "failed to get content of %s", path)
+	}
+
+	if file == nil {
+		return "", FileNotFoundError
+	}
+
+	content, err := file.GetContent()
+
+	return content, errors.Wrapf(err, "failed to decode content of %s", path)
+}
+
+func (gh *ghAppClient) IsTeamMember(ctx context.Context, org, teamSlug, login string) (bool, error) {
+	installationClient, err := gh.getOwnerInstallationClient(ctx, org)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create installation client")
+	}
+
+	membership, resp, err := installationClient.Teams.GetTeamMembershipBySlug(ctx, org, teamSlug, login)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+
+		return false, errors.Wrapf(err, "failed to get membership of %s in team %s/%s", login, org, teamSlug)
+	}
+
+	return membership.GetState() == "active", nil
+}
+
+func (gh *ghAppClient) IsOrgMember(ctx context.Context, org, login string) (bool, error) {
+	installationClient, err := gh.getOwnerInstallationClient(ctx, org)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create installation client")
+	}
+
+	isMember, _, err := installationClient.Organizations.IsMember(ctx, org, login)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to check membership of %s in org %s", login, org)
+	}
+
+	return isMember, nil
+}
+
+// GetRepoPermissionLevel returns admin, write, read or none
+func (gh *ghAppClient) GetRepoPermissionLevel(ctx context.Context, owner, repo, login string) (string, error) {
+	installationClient, err := gh.getOwnerInstallationClient(ctx, owner)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create installation client")
+	}
+
+	permission, resp, err := installationClient.Repositories.GetPermissionLevel(ctx, owner, repo, login)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "none", nil
+		}
+
+		return "", errors.Wrapf(err, "failed to get permission level of %s in %s/%s", login, owner, repo)
+	}
+
+	return permission.GetPermission(), nil
+}
+
+func (gh *ghAppClient) GetPullRequest(ctx context.Context, owner, repo string, prNumber int) (*github.PullRequest, error) {
+	installationClient, err := gh.getOwnerInstallationClient(ctx, owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create installation client")
+	}
+
+	pr, _, err := installationClient.PullRequests.Get(ctx, owner, repo, prNumber)
+
+	return pr, errors.Wrapf(err, "failed to get pull request %s/%s#%d", owner, repo, prNumber)
+}
+
+// RemoveLabel removes label from a pull request, doing nothing when it doesn't have it
+func (gh *ghAppClient) RemoveLabel(ctx context.Context, owner, repo string, prNumber int, label string) error {
+	installationClient, err := gh.getOwnerInstallationClient(ctx, owner)
+	if err != nil {
+		return errors.Wrap(err, "failed to create installation client")
+	}
+
+	resp, err := installationClient.Issues.RemoveLabelForIssue(ctx, owner, repo, prNumber, label)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+
+		return errors.Wrapf(err, "failed to remove label %s from %s/%s#%d", label, owner, repo, prNumber)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package ghdeployments
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v52/github"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/github/ghapp"
+)
+
+const (
+	StateInProgress = "in_progress"
+	StateSuccess    = "success"
+	StateFailure    = "failure"
+	StateInactive   = "inactive"
+)
+
+// EnvironmentName is the name of the GitHub environment an ergomake
+// environment is deployed to, eg: preview/pr-42 or preview/main
+func EnvironmentName(env *database.Environment) string {
+	if env.PullRequest.Valid {
+		return fmt.Sprintf("preview/pr-%d", env.PullRequest.Int32)
+	}
+
+	return fmt.Sprintf("preview/%s", env.Branch.String)
+}
+
+// EnvironmentURL is the URL of the main service of env, it is empty when
+// the main service is not exposed
+func EnvironmentURL(env *database.Environment) string {
+	for _, svc := range env.Services {
+		if svc.Index == 0 && svc.Url != "" {
+			return fmt.Sprintf("https://%s", svc.Url)
+		}
+	}
+
+	return ""
+}
+
+// Create registers env as a GitHub deployment of sha and stores the deployment id in env
+func Create(
+	ctx context.Context,
+	ghApp ghapp.GHAppClient,
+	db *database.DB,
+	env *database.Environment,
+	sha string,
+) error {
+	deployment, err := ghApp.CreateDeployment(ctx, env.Owner, env.Repo, &github.DeploymentRequest{
+		Ref:                   github.String(sha),
+		Task:                  github.String("deploy:preview"),
+		AutoMerge:             github.Bool(false),
+		RequiredContexts:      &[]string{},
+		Environment:           github.String(EnvironmentName(env)),
+		Description:           github.String("Ergomake preview environment"),
+		TransientEnvironment:  github.Bool(env.PullRequest.Valid),
+		ProductionEnvironment: github.Bool(false),
+	})
+	if err != nil {
+		return errors.Wrap(err, "fail to create deployment")
+	}
+
+	if deployment == nil {
+		return nil
+	}
+
+	env.GHDeploymentID = deployment.GetID()
+	err = db.Model(env).Update("gh_deployment_id", env.GHDeploymentID).Error
+
+	return errors.Wrap(err, "fail to save GHDeploymentID to database for env")
+}
+
+// SetStatus moves the GitHub deployment of env to state, it does nothing
+// when env was never registered as a deployment
+func SetStatus(
+	ctx context.Context,
+	ghApp ghapp.GHAppClient,
+	env *database.Environment,
+	state string,
+	environmentURL string,
+	logURL string,
+) error {
+	if env.GHDeploymentID == 0 {
+		return nil
+	}
+
+	req := &github.DeploymentStatusRequest{
+		State:       github.String(state),
+		Environment: github.String(EnvironmentName(env)),
+		// ergomake marks older deployments as inactive itself when they are terminated
+		AutoInactive: github.Bool(false),
+	}
+
+	if environmentURL != "" {
+		req.EnvironmentURL = github.String(environmentURL)
+	}
+
+	if logURL != "" {
+		req.LogURL = github.String(logURL)
+	}
+
+	err := ghApp.CreateDeploymentStatus(ctx, env.Owner, env.Repo, env.GHDeploymentID, req)
+
+	return errors.Wrapf(err, "fail to set deployment status to %s", state)
+}
@@ -0,0 +1,52 @@
+package ghdeployments
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+func TestEnvironmentName(t *testing.T) {
+	tt := []struct {
+		name string
+		env  *database.Environment
+		want string
+	}{
+		{
+			name: "pull request environment",
+			env: &database.Environment{
+				Branch:      sql.NullString{String: "feature", Valid: true},
+				PullRequest: sql.NullInt32{Int32: 42, Valid: true},
+			},
+			want: "preview/pr-42",
+		},
+		{
+			name: "branch environment",
+			env: &database.Environment{
+				Branch: sql.NullString{String: "main", Valid: true},
+			},
+			want: "preview/main",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, EnvironmentName(tc.env))
+		})
+	}
+}
+
+func TestEnvironmentURL(t *testing.T) {
+	env := &database.Environment{
+		Services: []database.Service{
+			{Name: "db", Index: 1},
+			{Name: "web", Index: 0, Url: "web.env.ergomake.link"},
+		},
+	}
+
+	assert.Equal(t, "https://web.env.ergomake.link", EnvironmentURL(env))
+	assert.Equal(t, "", EnvironmentURL(&database.Environment{}))
+}
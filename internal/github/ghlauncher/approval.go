@@ -0,0 +1,92 @@
+package ghlauncher
+
+import (
+	"context"
+
+	"github.com/google/go-github/v52/github"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/forkpolicy"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/prcomments"
+)
+
+// awaitsApproval tells whether req must not be built until a maintainer approves
+// its commit, which is when the repo requires approvals and req is a pull
+// request from a fork of an author who can't push to the repo. Requests without
+// PrAuthor are held unless approved, whoever triggered them.
+func (gh *ghLauncher) awaitsApproval(ctx context.Context, req LaunchEnvironmentRequest) (bool, error) {
+	if req.PrNumber == nil || !forkpolicy.IsFork(req.Owner, req.BranchOwner) {
+		return false, nil
+	}
+
+	settings, err := gh.forkPolicyProvider.GetSettings(ctx, req.Owner, req.Repo)
+	if err != nil {
+		return false, errors.Wrapf(err, "fail to get fork policy of repo %s/%s", req.Owner, req.Repo)
+	}
+
+	if !settings.RequireApproval {
+		return false, nil
+	}
+
+	if req.PrAuthor != "" {
+		permission, err := gh.ghApp.GetRepoPermissionLevel(ctx, req.Owner, req.Repo, req.PrAuthor)
+		if err != nil {
+			return false, errors.Wrapf(err, "fail to get permission level of %s", req.PrAuthor)
+		}
+
+		if forkpolicy.CanApprove(permission) {
+			return false, nil
+		}
+	}
+
+	approved, err := gh.forkPolicyProvider.IsApproved(ctx, req.Owner, req.Repo, *req.PrNumber, req.SHA)
+	if err != nil {
+		return false, errors.Wrapf(err, "fail to check approval of %s/%s#%d", req.Owner, req.Repo, *req.PrNumber)
+	}
+
+	return !approved, nil
+}
+
+// holdForApproval marks env as awaiting approval and tells so in its commit
+// status, check run and pull request comment
+func (gh *ghLauncher) holdForApproval(
+	ctx context.Context,
+	env *database.Environment,
+	sha string,
+	envFrontendLink string,
+) error {
+	err := gh.ghApp.CreateCommitStatus(ctx, env.Owner, env.Repo, sha, "pending", github.String(envFrontendLink))
+	if err != nil {
+		logger.Ctx(ctx).Err(err).Msg("fail to create commit status for env awaiting approval")
+	}
+
+	data := prcomments.NewData(prcomments.StatusAwaitingApproval)
+	ghComment, err := postComment(ctx, gh.ghApp, gh.commentSettingsProvider, env, sha, data)
+	if err != nil {
+		logger.Ctx(ctx).Err(err).Msg("fail to create gh comment for env awaiting approval")
+	} else if ghComment != nil {
+		env.GHCommentID = ghComment.GetID()
+	}
+
+	CompleteCheckRun(ctx, gh.ghApp, gh.db, envFrontendLink, env, sha, "action_required", &github.CheckRunOutput{
+		Title: github.String("Awaiting approval"),
+		Summary: github.String(
+			"Previews of pull requests from external contributors are only built after a maintainer approves them. " +
+				"Comment `" + forkpolicy.ApprovalCommand + " " + sha + "` or add the `" + forkpolicy.ApprovalLabel +
+				"` label to approve this commit.",
+		),
+	})
+
+	env.Status = database.EnvAwaitingApproval
+
+	err = gh.db.Save(env).Error
+	if err != nil {
+		return errors.Wrap(err, "fail to save env awaiting approval")
+	}
+
+	logger.Ctx(ctx).Info().Msg("env awaiting approval")
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package ghlauncher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ergomake/ergomake/internal/forkpolicy"
+	forkpolicyMocks "github.com/ergomake/ergomake/mocks/forkpolicy"
+	ghAppMocks "github.com/ergomake/ergomake/mocks/github/ghapp"
+)
+
+func TestGHLauncher_awaitsApproval(t *testing.T) {
+	prNumber := 7
+	fork := LaunchEnvironmentRequest{
+		Owner:       "acme",
+		BranchOwner: "mallory",
+		Repo:        "api",
+		SHA:         "abc",
+		PrNumber:    &prNumber,
+		Author:      "maintainer",
+		PrAuthor:    "mallory",
+	}
+	withoutPrAuthor := fork
+	withoutPrAuthor.PrAuthor = ""
+
+	tt := []struct {
+		name            string
+		req             LaunchEnvironmentRequest
+		requireApproval bool
+		permission      string
+		approved        bool
+		want            bool
+	}{
+		{
+			name: "branch of the repo",
+			req:  LaunchEnvironmentRequest{Owner: "acme", BranchOwner: "acme", Repo: "api", PrNumber: &prNumber},
+			want: false,
+		},
+		{name: "approvals not required", req: fork, want: false},
+		{name: "author can push", req: fork, requireApproval: true, permission: "write", want: false},
+		{name: "not approved", req: fork, requireApproval: true, permission: "read", want: true},
+		{name: "approved", req: fork, requireApproval: true, permission: "none", approved: true, want: false},
+		{name: "without pr author", req: withoutPrAuthor, requireApproval: true, want: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			policyProvider := forkpolicyMocks.NewPolicyProvider(t)
+			ghApp := ghAppMocks.NewGHAppClient(t)
+			if forkpolicy.IsFork(tc.req.Owner, tc.req.BranchOwner) {
+				policyProvider.EXPECT().GetSettings(mock.Anything, "acme", "api").
+					Return(&forkpolicy.Settings{Owner: "acme", Repo: "api", RequireApproval: tc.requireApproval}, nil)
+			}
+			if tc.permission != "" {
+				ghApp.EXPECT().GetRepoPermissionLevel(mock.Anything, "acme", "api", "mallory").Return(tc.permission, nil)
+			}
+			if tc.requireApproval && !forkpolicy.CanApprove(tc.permission) {
+				policyProvider.EXPECT().IsApproved(mock.Anything, "acme", "api", prNumber, "abc").Return(tc.approved, nil)
+			}
+
+			gh := &ghLauncher{ghApp: ghApp, forkPolicyProvider: policyProvider}
+			awaits, err := gh.awaitsApproval(context.Background(), tc.req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, awaits)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package ghlauncher
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/ergomake/ergomake/internal/cluster"
+	"github.com/ergomake/ergomake/internal/envvars"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/servicelogs"
+	"github.com/ergomake/ergomake/internal/transformer"
+)
+
+// amount of log chars fetched from a failed build, the failing step is searched in them
+const buildFailureLogSize = 64 * 1024
+
+// amount of log lines shown to users
+const buildFailureExcerptLines = 30
+
+type BuildFailureKind string
+
+const (
+	BuildFailureUnknown            BuildFailureKind = ""
+	BuildFailureOOMKilled          BuildFailureKind = "oom-killed"
+	BuildFailureDeadlineExceeded   BuildFailureKind = "deadline-exceeded"
+	BuildFailureCloneFailed        BuildFailureKind = "clone-failed"
+	BuildFailureRegistryPushDenied BuildFailureKind = "registry-push-denied"
+)
+
+func (k BuildFailureKind) Description() string {
+	switch k {
+	case BuildFailureOOMKilled:
+		return "OOM-killed: the build ran out of memory."
+	case BuildFailureDeadlineExceeded:
+		return "Deadline exceeded: the build took longer than the maximum allowed time."
+	case BuildFailureCloneFailed:
+		return "Clone failed: we couldn't clone the repository."
+	case BuildFailureRegistryPushDenied:
+		return "Registry push denied: the image could not be pushed to the registry."
+	}
+
+	return ""
+}
+
+type BuildFailure struct {
+	Service string
+	Kind    BuildFailureKind
+	Step    string
+	Logs    string
+	Path    string
+	Line    int
+}
+
+// CollectBuildFailures tells why each of jobs failed. Their logs are posted to
+// pull requests, which can be public, so secrets are redacted with redactor and
+// logs are left out when redactor is nil.
+func CollectBuildFailures(
+	ctx context.Context,
+	clusterClient cluster.Client,
+	compose *transformer.Environment,
+	jobs []*batchv1.Job,
+	redactor *servicelogs.Redactor,
+) []BuildFailure {
+	failures := make([]BuildFailure, 0, len(jobs))
+	for _, job := range jobs {
+		failure := BuildFailure{Service: job.GetLabels()["preview.ergomake.dev/service"]}
+
+		logs, err := clusterClient.GetJobLogs(ctx, job, buildFailureLogSize)
+		if err != nil {
+			logger.Ctx(ctx).Err(err).Str("job", job.GetName()).Msg("fail to get logs of failed build job")
+		}
+
+		podStatus, err := clusterClient.GetJobPodStatus(ctx, job)
+		if err != nil {
+			logger.Ctx(ctx).Err(err).Str("job", job.GetName()).Msg("fail to get pod status of failed build job")
+		}
+
+		failure.Kind = classifyBuildFailure(job, podStatus, logs)
+		if redactor != nil {
+			failure.Step = escapeCodeFences(redactor.RedactText(findFailingStep(logs)))
+			failure.Logs = escapeCodeFences(redactor.RedactText(lastLines(logs, buildFailureExcerptLines)))
+		}
+
+		if compose != nil {
+			failure.Path = compose.ConfigPath
+			failure.Line = compose.ServiceLine(failure.Service)
+		}
+
+		failures = append(failures, failure)
+	}
+
+	return failures
+}
+
+// makeBuildLogsRedactor redacts every secret of the services of compose, even
+// the ones the build didn't get because of the fork policy
+func (gh *ghLauncher) makeBuildLogsRedactor(
+	ctx context.Context,
+	req LaunchEnvironmentRequest,
+	compose *transformer.Environment,
+) (*servicelogs.Redactor, error) {
+	environmentType := envvars.EnvironmentTypePermanentBranch
+	if req.PrNumber != nil {
+		environmentType = envvars.EnvironmentTypePullRequest
+	}
+
+	if compose == nil {
+		return nil, errors.New("can't know the secrets of a build without its compose")
+	}
+
+	services := make([]string, 0, len(compose.Services))
+	for name := range compose.Services {
+		services = append(services, name)
+	}
+
+	return servicelogs.NewEnvRedactor(ctx, gh.envVarsProvider, req.Owner, req.Repo, envvars.Target{
+		Branch:          req.Branch,
+		EnvironmentType: environmentType,
+	}, services)
+}
+
+var registryPushDeniedRegex = regexp.MustCompile(
+	`(?i)(error checking push permissions|push access denied|error pushing image|failed to push).*`,
+)
+
+func classifyBuildFailure(job *batchv1.Job, podStatus *corev1.PodStatus, logs string) BuildFailureKind {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Reason == "DeadlineExceeded" {
+			return BuildFailureDeadlineExceeded
+		}
+	}
+
+	if podStatus != nil {
+		for _, status := range podStatus.InitContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+				return BuildFailureCloneFailed
+			}
+		}
+
+		for _, status := range podStatus.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.Reason == "OOMKilled" {
+				return BuildFailureOOMKilled
+			}
+		}
+	}
+
+	if registryPushDeniedRegex.MatchString(logs) {
+		return BuildFailureRegistryPushDenied
+	}
+
+	return BuildFailureUnknown
+}
+
+var dockerfileStepRegex = regexp.MustCompile(
+	`^INFO\[\d+\]\s+((?:FROM|RUN|COPY|ADD|WORKDIR|ENV|ARG|USER|ONBUILD|SHELL|VOLUME)\s.*)$`,
+)
+
+// findFailingStep returns the last Dockerfile instruction kaniko started executing
+func findFailingStep(logs string) string {
+	lines := strings.Split(logs, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		match := dockerfileStepRegex.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if len(match) == 2 {
+			return strings.TrimSpace(match[1])
+		}
+	}
+
+	return ""
+}
+
+func lastLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// escapeCodeFences keeps text from closing the markdown code block it is shown in
+func escapeCodeFences(text string) string {
+	return strings.ReplaceAll(text, "```", "'''")
+}
@@ -0,0 +1,141 @@
+package ghlauncher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ergomake/ergomake/internal/servicelogs"
+	clusterMocks "github.com/ergomake/ergomake/mocks/cluster"
+)
+
+func TestClassifyBuildFailure(t *testing.T) {
+	terminated := func(reason string, exitCode int32) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{Reason: reason, ExitCode: exitCode},
+			},
+		}
+	}
+
+	tt := []struct {
+		name      string
+		job       *batchv1.Job
+		podStatus *corev1.PodStatus
+		logs      string
+		want      BuildFailureKind
+	}{
+		{
+			name: "deadline exceeded",
+			job: &batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Reason: "DeadlineExceeded"},
+			}}},
+			want: BuildFailureDeadlineExceeded,
+		},
+		{
+			name: "clone failed",
+			job:  &batchv1.Job{},
+			podStatus: &corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{terminated("Error", 128)},
+			},
+			want: BuildFailureCloneFailed,
+		},
+		{
+			name: "oom killed",
+			job:  &batchv1.Job{},
+			podStatus: &corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{terminated("Completed", 0)},
+				ContainerStatuses:     []corev1.ContainerStatus{terminated("OOMKilled", 137)},
+			},
+			want: BuildFailureOOMKilled,
+		},
+		{
+			name: "registry push denied",
+			job:  &batchv1.Job{},
+			logs: "error checking push permissions -- make sure you entered the correct tag name",
+			want: BuildFailureRegistryPushDenied,
+		},
+		{
+			name: "unknown",
+			job:  &batchv1.Job{},
+			logs: "error building image: exit status 1",
+			want: BuildFailureUnknown,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, classifyBuildFailure(tc.job, tc.podStatus, tc.logs))
+		})
+	}
+}
+
+func TestFindFailingStep(t *testing.T) {
+	logs := `INFO[0000] Retrieving image manifest node:18
+INFO[0003] FROM node:18
+INFO[0004] WORKDIR /app
+INFO[0005] RUN npm install
+npm ERR! code E404
+error building image: error building stage: failed to execute command: exit status 1`
+
+	assert.Equal(t, "RUN npm install", findFailingStep(logs))
+	assert.Equal(t, "", findFailingStep("error building image"))
+}
+
+func TestLastLines(t *testing.T) {
+	assert.Equal(t, "b\nc", lastLines("a\nb\nc\n", 2))
+	assert.Equal(t, "a\nb", lastLines("a\nb", 5))
+}
+
+func TestCollectBuildFailures(t *testing.T) {
+	logs := "INFO[0003] FROM node:18\n" +
+		"INFO[0005] RUN echo hunter2\n" +
+		"hunter2\n" +
+		"```\n" +
+		"error building image: exit status 1\n"
+
+	tt := []struct {
+		name     string
+		redactor *servicelogs.Redactor
+		want     BuildFailure
+	}{
+		{
+			name:     "redacts secrets and code fences",
+			redactor: servicelogs.NewRedactor([]string{"hunter2"}),
+			want: BuildFailure{
+				Service: "api",
+				Step:    "RUN echo ********",
+				Logs: "INFO[0003] FROM node:18\n" +
+					"INFO[0005] RUN echo ********\n" +
+					"********\n" +
+					"'''\n" +
+					"error building image: exit status 1",
+			},
+		},
+		{
+			name: "leaves logs out without redactor",
+			want: BuildFailure{Service: "api"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Name:   "api-build",
+				Labels: map[string]string{"preview.ergomake.dev/service": "api"},
+			}}
+
+			clusterClient := clusterMocks.NewClient(t)
+			clusterClient.EXPECT().GetJobLogs(mock.Anything, job, int64(buildFailureLogSize)).Return(logs, nil)
+			clusterClient.EXPECT().GetJobPodStatus(mock.Anything, job).Return(nil, nil)
+
+			got := CollectBuildFailures(context.Background(), clusterClient, nil, []*batchv1.Job{job}, tc.redactor)
+			assert.Equal(t, []BuildFailure{tc.want}, got)
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package ghlauncher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v52/github"
+
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/github/ghapp"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/transformer"
+)
+
+const (
+	CheckRunRerunAction   = "rerun"
+	CheckRunDestroyAction = "destroy"
+)
+
+// github refuses check runs with more than 50 annotations per request
+const maxCheckRunAnnotations = 50
+
+// github limits check run summary and annotation details to 65535 chars
+const maxCheckRunTextSize = 65535
+
+func StartCheckRun(
+	ctx context.Context,
+	ghApp ghapp.GHAppClient,
+	db *database.DB,
+	envFrontendLink string,
+	env *database.Environment,
+	sha string,
+) {
+	log := logger.Ctx(ctx)
+
+	checkRun, err := ghApp.CreateCheckRun(ctx, env.Owner, env.Repo, github.CreateCheckRunOptions{
+		Name:       ghapp.CheckName,
+		HeadSHA:    sha,
+		DetailsURL: github.String(envFrontendLink),
+		ExternalID: github.String(env.ID.String()),
+		Status:     github.String("in_progress"),
+		Output: &github.CheckRunOutput{
+			Title:   github.String("Building preview environment"),
+			Summary: github.String(fmt.Sprintf("Follow the build logs [here](%s).", envFrontendLink)),
+		},
+		Actions: getCheckRunActions(),
+	})
+	if err != nil {
+		log.Err(err).Msg("fail to create check run")
+		return
+	}
+
+	if checkRun == nil {
+		return
+	}
+
+	env.GHCheckRunID = checkRun.GetID()
+	err = db.Model(env).Update("gh_check_run_id", env.GHCheckRunID).Error
+	if err != nil {
+		log.Err(err).Msg("fail to save GHCheckRunID to database for env")
+	}
+}
+
+func CompleteCheckRun(
+	ctx context.Context,
+	ghApp ghapp.GHAppClient,
+	db *database.DB,
+	envFrontendLink string,
+	env *database.Environment,
+	sha string,
+	conclusion string,
+	output *github.CheckRunOutput,
+) {
+	log := logger.Ctx(ctx).With().Str("conclusion", conclusion).Logger()
+
+	if env.GHCheckRunID != 0 {
+		_, err := ghApp.UpdateCheckRun(ctx, env.Owner, env.Repo, env.GHCheckRunID, github.UpdateCheckRunOptions{
+			Name:        ghapp.CheckName,
+			DetailsURL:  github.String(envFrontendLink),
+			ExternalID:  github.String(env.ID.String()),
+			Status:      github.String("completed"),
+			Conclusion:  github.String(conclusion),
+			CompletedAt: &github.Timestamp{Time: time.Now()},
+			Output:      output,
+			Actions:     getCheckRunActions(),
+		})
+		if err != nil {
+			log.Err(err).Msg("fail to update check run")
+		}
+		return
+	}
+
+	checkRun, err := ghApp.CreateCheckRun(ctx, env.Owner, env.Repo, github.CreateCheckRunOptions{
+		Name:        ghapp.CheckName,
+		HeadSHA:     sha,
+		DetailsURL:  github.String(envFrontendLink),
+		ExternalID:  github.String(env.ID.String()),
+		Status:      github.String("completed"),
+		Conclusion:  github.String(conclusion),
+		CompletedAt: &github.Timestamp{Time: time.Now()},
+		Output:      output,
+		Actions:     getCheckRunActions(),
+	})
+	if err != nil {
+		log.Err(err).Msg("fail to create check run")
+		return
+	}
+
+	if checkRun == nil {
+		return
+	}
+
+	env.GHCheckRunID = checkRun.GetID()
+	err = db.Model(env).Update("gh_check_run_id", env.GHCheckRunID).Error
+	if err != nil {
+		log.Err(err).Msg("fail to save GHCheckRunID to database for env")
+	}
+}
+
+func getCheckRunActions() []*github.CheckRunAction {
+	return []*github.CheckRunAction{
+		{
+			Label:       "Re-run",
+			Description: "Build and deploy this environment again",
+			Identifier:  CheckRunRerunAction,
+		},
+		{
+			Label:       "Destroy environment",
+			Description: "Terminate this preview environment",
+			Identifier:  CheckRunDestroyAction,
+		},
+	}
+}
+
+func createSuccessCheckRunOutput(
+	db *database.DB,
+	env *database.Environment,
+	compose *transformer.Environment,
+	envFrontendLink string,
+) *github.CheckRunOutput {
+	summary := fmt.Sprintf(
+		"Your preview environment is available at %s\n\n%s\n\nHere are your environment's [logs](%s).",
+		getMainServiceUrl(compose),
+		getCheckRunServiceTable(db, env),
+		envFrontendLink,
+	)
+
+	return &github.CheckRunOutput{
+		Title:   github.String("Preview environment is ready"),
+		Summary: github.String(truncateCheckRunText(summary)),
+	}
+}
+
+// createFailureCheckRunOutput makes the output of a failed environment, serviceTable
+// is the getCheckRunServiceTable of the environment
+func createFailureCheckRunOutput(
+	serviceTable string,
+	envFrontendLink string,
+	validationError *transformer.ProjectValidationError,
+	buildFailures []BuildFailure,
+) *github.CheckRunOutput {
+	annotations := make([]*github.CheckRunAnnotation, 0)
+
+	if validationError != nil {
+		summary := fmt.Sprintf("%s\n\nSee the environment [here](%s).", validationError.Message, envFrontendLink)
+		output := &github.CheckRunOutput{
+			Title:   github.String("Invalid environment configuration"),
+			Summary: github.String(truncateCheckRunText(summary)),
+		}
+
+		if validationError.Path != "" {
+			line := validationError.Line
+			if line == 0 {
+				line = 1
+			}
+
+			output.Annotations = append(annotations, &github.CheckRunAnnotation{
+				Path:            github.String(validationError.Path),
+				StartLine:       github.Int(line),
+				EndLine:         github.Int(line),
+				AnnotationLevel: github.String("failure"),
+				Title:           github.String("Invalid environment configuration"),
+				Message:         github.String(validationError.Message),
+			})
+		}
+
+		return output
+	}
+
+	failedServices := make([]string, 0, len(buildFailures))
+	for _, failure := range buildFailures {
+		failedServices = append(failedServices, fmt.Sprintf("`%s`", failure.Service))
+
+		if failure.Path == "" || len(annotations) >= maxCheckRunAnnotations {
+			continue
+		}
+
+		line := failure.Line
+		if line == 0 {
+			line = 1
+		}
+
+		annotations = append(annotations, &github.CheckRunAnnotation{
+			Path:            github.String(failure.Path),
+			StartLine:       github.Int(line),
+			EndLine:         github.Int(line),
+			AnnotationLevel: github.String("failure"),
+			Title:           github.String(fmt.Sprintf("Service %s failed to build", failure.Service)),
+			Message:         github.String(getBuildFailureMessage(failure)),
+			RawDetails:      github.String(tailCheckRunText(failure.Logs)),
+		})
+	}
+
+	reason := fmt.Sprintf("See the environment build logs [here](%s).", envFrontendLink)
+	if len(failedServices) > 0 {
+		reason = fmt.Sprintf("Failed to build %s. %s", strings.Join(failedServices, ", "), reason)
+	}
+
+	summary := fmt.Sprintf("%s\n\n%s", reason, serviceTable)
+
+	return &github.CheckRunOutput{
+		Title:       github.String("Preview environment failed"),
+		Summary:     github.String(truncateCheckRunText(summary)),
+		Annotations: annotations,
+	}
+}
+
+func getBuildFailureMessage(failure BuildFailure) string {
+	message := fmt.Sprintf("The image for service `%s` could not be built.", failure.Service)
+
+	if description := failure.Kind.Description(); description != "" {
+		message = fmt.Sprintf("%s\n%s", message, description)
+	}
+
+	if failure.Step != "" {
+		message = fmt.Sprintf("%s\nFailing step: %s", message, failure.Step)
+	}
+
+	return message
+}
+
+func getCheckRunServiceTable(db *database.DB, env *database.Environment) string {
+	services, err := db.FindServicesByEnvironment(env.ID)
+	if err != nil || len(services) == 0 {
+		return ""
+	}
+
+	rows := []string{"| Service | Source | Build | URL |", "| - | - | - | - |"}
+	for _, svc := range services {
+		source := svc.Image
+		if svc.Build != "" {
+			source = "Dockerfile"
+		}
+
+		url := "[not exposed - internal service]"
+		if svc.Url != "" {
+			url = fmt.Sprintf("https://%s", svc.Url)
+		}
+
+		rows = append(rows, fmt.Sprintf("| %s | %s | %s | %s |", svc.Name, source, svc.BuildStatus, url))
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// truncateCheckRunText cuts text on a rune boundary, github refuses invalid utf-8
+func truncateCheckRunText(text string) string {
+	if len(text) <= maxCheckRunTextSize {
+		return text
+	}
+
+	end := maxCheckRunTextSize
+	for end > 0 && !utf8.RuneStart(text[end]) {
+		end--
+	}
+
+	return text[:end]
+}
+
+// tailCheckRunText keeps the end of text since that is where build errors usually are
+func tailCheckRunText(text string) string {
+	if len(text) <= maxCheckRunTextSize {
+		return text
+	}
+
+	start := len(text) - maxCheckRunTextSize
+	for start < len(text) && !utf8.RuneStart(text[start]) {
+		start++
+	}
+
+	return text[start:]
+}
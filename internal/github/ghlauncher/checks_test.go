@@ -0,0 +1,144 @@
+package ghlauncher
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v52/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ergomake/ergomake/internal/transformer"
+)
+
+func TestTruncateCheckRunText(t *testing.T) {
+	tt := []struct {
+		name string
+		text string
+		want int
+	}{
+		{name: "short", text: "hello", want: 5},
+		{name: "ascii", text: strings.Repeat("a", maxCheckRunTextSize+10), want: maxCheckRunTextSize},
+		{
+			name: "rune across the limit",
+			text: strings.Repeat("a", maxCheckRunTextSize-1) + "é" + "a",
+			want: maxCheckRunTextSize - 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			truncated := truncateCheckRunText(tc.text)
+
+			assert.Len(t, truncated, tc.want)
+			assert.True(t, utf8.ValidString(truncated))
+		})
+	}
+}
+
+func TestTailCheckRunText(t *testing.T) {
+	tt := []struct {
+		name string
+		text string
+		want int
+	}{
+		{name: "short", text: "hello", want: 5},
+		{name: "ascii", text: strings.Repeat("a", maxCheckRunTextSize+10), want: maxCheckRunTextSize},
+		{
+			name: "rune across the limit",
+			text: "a" + "é" + strings.Repeat("a", maxCheckRunTextSize-1),
+			want: maxCheckRunTextSize - 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tail := tailCheckRunText(tc.text)
+
+			assert.Len(t, tail, tc.want)
+			assert.True(t, utf8.ValidString(tail))
+			assert.True(t, strings.HasSuffix(tc.text, tail))
+		})
+	}
+}
+
+func TestCreateFailureCheckRunOutput(t *testing.T) {
+	link := "https://app.ergomake.dev/gh/owner/repos/repo/envs/id"
+
+	tt := []struct {
+		name            string
+		validationError *transformer.ProjectValidationError
+		buildFailures   []BuildFailure
+		want            *github.CheckRunOutput
+	}{
+		{
+			name:            "validation error without path",
+			validationError: &transformer.ProjectValidationError{T: "compose-not-found", Message: "No compose."},
+			want: &github.CheckRunOutput{
+				Title:   github.String("Invalid environment configuration"),
+				Summary: github.String("No compose.\n\nSee the environment [here](" + link + ")."),
+			},
+		},
+		{
+			name: "validation error with path",
+			validationError: &transformer.ProjectValidationError{
+				T:       "invalid-compose",
+				Message: "Invalid services.",
+				Path:    "compose.yaml",
+			},
+			want: &github.CheckRunOutput{
+				Title:   github.String("Invalid environment configuration"),
+				Summary: github.String("Invalid services.\n\nSee the environment [here](" + link + ")."),
+				Annotations: []*github.CheckRunAnnotation{
+					{
+						Path:            github.String("compose.yaml"),
+						StartLine:       github.Int(1),
+						EndLine:         github.Int(1),
+						AnnotationLevel: github.String("failure"),
+						Title:           github.String("Invalid environment configuration"),
+						Message:         github.String("Invalid services."),
+					},
+				},
+			},
+		},
+		{
+			name: "build failures",
+			buildFailures: []BuildFailure{
+				{Service: "web", Kind: BuildFailureOOMKilled, Step: "RUN npm install", Logs: "Killed", Path: "Dockerfile", Line: 3},
+				{Service: "api"},
+			},
+			want: &github.CheckRunOutput{
+				Title:   github.String("Preview environment failed"),
+				Summary: github.String("Failed to build `web`, `api`. See the environment build logs [here](" + link + ").\n\n| table |"),
+				Annotations: []*github.CheckRunAnnotation{
+					{
+						Path:            github.String("Dockerfile"),
+						StartLine:       github.Int(3),
+						EndLine:         github.Int(3),
+						AnnotationLevel: github.String("failure"),
+						Title:           github.String("Service web failed to build"),
+						Message: github.String("The image for service `web` could not be built.\n" +
+							"OOM-killed: the build ran out of memory.\nFailing step: RUN npm install"),
+						RawDetails: github.String("Killed"),
+					},
+				},
+			},
+		},
+		{
+			name: "no build failures",
+			want: &github.CheckRunOutput{
+				Title:       github.String("Preview environment failed"),
+				Summary:     github.String("See the environment build logs [here](" + link + ").\n\n| table |"),
+				Annotations: []*github.CheckRunAnnotation{},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			output := createFailureCheckRunOutput("| table |", link, tc.validationError, tc.buildFailures)
+
+			assert.Equal(t, tc.want, output)
+		})
+	}
+}
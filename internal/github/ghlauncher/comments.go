@@ -1,86 +1,206 @@
 package ghlauncher
 
 import (
+	"context"
 	"fmt"
-	"strings"
-
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/github/ghapp"
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/prcomments"
 	"github.com/ergomake/ergomake/internal/transformer"
 )
 
-func createSuccessComment(env *transformer.Environment, frontendEnvLink string) string {
-	return fmt.Sprintf(`Hi 👋
+// postComment renders the comment template of the repository with data and upserts it
+// in the pull request of env, it returns nil when comments are disabled for the repository
+func postComment(
+	ctx context.Context,
+	ghApp ghapp.GHAppClient,
+	commentSettingsProvider prcomments.SettingsProvider,
+	env *database.Environment,
+	sha string,
+	data prcomments.Data,
+) (*github.IssueComment, error) {
+	settings, err := commentSettingsProvider.Get(ctx, env.Owner, env.Repo)
+	if err != nil {
+		logger.Ctx(ctx).Err(err).Msg("fail to get comment settings, using defaults")
+		settings = &prcomments.Settings{}
+	}
 
-Here's a preview environment 🚀
+	if settings.Disabled {
+		return nil, nil
+	}
 
-%s
+	template := settings.Template
+	// the template is read from the default branch, like pull_request_target
+	// workflows, so that pull requests from forks can't change what is posted
+	repoTemplate, err := ghApp.GetFileContent(ctx, env.Owner, env.Repo, "", prcomments.RepoTemplatePath)
+	if err != nil && !errors.Is(err, ghapp.FileNotFoundError) {
+		logger.Ctx(ctx).Err(err).Msg("fail to get comment template from repository")
+	}
+	if repoTemplate != "" {
+		template = repoTemplate
+	}
+	if template == "" {
+		template = prcomments.DefaultTemplate
+	}
 
-# Environment Summary 📑
+	fillCommentData(&data, env, sha)
 
-| Container | Source | URL |
-| - | - | - |
-%s
+	comment, err := prcomments.Render(template, data)
+	if err != nil {
+		logger.Ctx(ctx).Err(err).Msg("fail to render comment template, using default template")
 
-Here are your environment's [logs](%s).
+		comment, err = prcomments.Render(prcomments.DefaultTemplate, data)
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to render default comment template")
+		}
+	}
 
-For questions or comments, [join Discord](https://discord.gg/daGzchUGDt).
+	ghComment, err := ghApp.UpsertComment(ctx, env.Owner, env.Repo, int(env.PullRequest.Int32), env.GHCommentID, comment)
 
-[Click here](https://github.com/apps/ergomake) to disable Ergomake.`,
-		getMainServiceUrl(env),
-		getServiceTable(env),
-		frontendEnvLink,
-	)
+	return ghComment, errors.Wrap(err, "fail to upsert comment")
 }
 
-func getMainServiceUrl(env *transformer.Environment) string {
-	return getServiceUrl(env.FirstService())
+func fillCommentData(data *prcomments.Data, env *database.Environment, sha string) {
+	data.Owner = env.Owner
+	data.Repo = env.Repo
+	data.Branch = env.Branch.String
+	data.SHA = sha
+	data.ShortSHA = sha
+	if len(sha) > 7 {
+		data.ShortSHA = sha[:7]
+	}
+	data.PrNumber = int(env.PullRequest.Int32)
+	data.EnvironmentID = env.ID.String()
+
+	if !env.CreatedAt.IsZero() {
+		data.BuildDuration = time.Since(env.CreatedAt).Round(time.Second).String()
+	}
+}
+
+func createSuccessCommentData(env *transformer.Environment, frontendEnvLink string) prcomments.Data {
+	data := prcomments.NewData(prcomments.StatusSuccess)
+	data.LogsUrl = frontendEnvLink
+	data.MainServiceUrl = getServiceUrl(env.FirstService())
+	data.Services = getCommentServices(env)
+
+	return data
 }
 
-func createFailureComment(frontendLink string, validationError *transformer.ProjectValidationError) string {
-	reason := fmt.Sprintf(
+func createFailureCommentData(
+	db *database.DB,
+	env *database.Environment,
+	frontendLink string,
+	validationError *transformer.ProjectValidationError,
+	buildFailures []BuildFailure,
+) prcomments.Data {
+	data := prcomments.NewData(prcomments.StatusFailure)
+	data.LogsUrl = frontendLink
+	data.Reason = fmt.Sprintf(
 		`You can see your environment build logs [here](%s). Please double-check your `+"`docker-compose.yml`"+` file is valid.`,
 		frontendLink,
 	)
 
 	if validationError != nil {
-		reason = validationError.Message
+		data.Reason = validationError.Message
 	}
 
-	return fmt.Sprintf(`Hi 👋
-
-We couldn't create a preview environment for this pull-request 😥
+	services, err := db.FindServicesByEnvironment(env.ID)
+	if err == nil {
+		for _, svc := range services {
+			data.Services = append(data.Services, prcomments.Service{
+				Name:        svc.Name,
+				Source:      getSource(transformer.EnvironmentService{Image: svc.Image, Build: svc.Build}),
+				Url:         getServiceUrl(transformer.EnvironmentService{Url: svc.Url}),
+				Exposed:     svc.Url != "",
+				BuildStatus: svc.BuildStatus,
+			})
+
+			if data.FailingService == "" && svc.BuildStatus == "build-failed" {
+				data.FailingService = svc.Name
+			}
+		}
+	}
 
-%s
+	if len(buildFailures) > 0 {
+		data.FailingService = buildFailures[0].Service
+		data.Reason = fmt.Sprintf(
+			"We couldn't build `%s`. You can see the full build logs [here](%s).",
+			data.FailingService,
+			frontendLink,
+		)
+	}
 
-If you need help, email us at contact@getergomake.com or join [Discord](https://discord.gg/daGzchUGDt).
+	for _, failure := range buildFailures {
+		data.BuildFailures = append(data.BuildFailures, prcomments.BuildFailure{
+			Service:        failure.Service,
+			Classification: failure.Kind.Description(),
+			Step:           failure.Step,
+			Logs:           failure.Logs,
+		})
+	}
 
-[Click here](https://github.com/apps/ergomake) to disable Ergomake.`, reason)
+	return data
 }
 
-func createLimitedComment() string {
-	return `Hi there 👋
-
-You’ve just reached your simultaneous environments limit.
+func createLimitedCommentData() prcomments.Data {
+	return prcomments.NewData(prcomments.StatusLimited)
+}
 
-Please talk to us at contact@ergomake.dev to bump your limits.
+func getCommentServices(env *transformer.Environment) []prcomments.Service {
+	names := make([]string, 0, len(env.Services))
+	for name := range env.Services {
+		names = append(names, name)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return env.Services[names[i]].Index < env.Services[names[j]].Index
+	})
+
+	services := make([]prcomments.Service, 0, len(names))
+	for _, name := range names {
+		svc := env.Services[name]
+		services = append(services, prcomments.Service{
+			Name:    name,
+			Source:  getSource(svc),
+			Url:     getServiceUrl(svc),
+			Exposed: svc.Url != "",
+		})
+	}
 
-Alternatively, you can close a PR with an existing environment, and reopen this one to get a preview.
+	return services
+}
 
-Thanks for using Ergomake!
+func getCommentEndpoints(ctx context.Context, db *database.DB, env *database.Environment) []prcomments.Endpoint {
+	endpoints := []prcomments.Endpoint{}
 
-[Click here](https://github.com/apps/ergomake) to disable Ergomake.`
-}
+	dbEndpoints, err := db.FindEndpointsByEnvironment(env.ID)
+	if err != nil {
+		logger.Ctx(ctx).Err(err).Str("env", env.ID.String()).Msg("fail to find endpoints for comment")
+		return endpoints
+	}
 
-func getServiceTable(env *transformer.Environment) string {
-	rows := make([]string, len(env.Services))
-	for serviceName, serviceConfig := range env.Services {
-		rows[serviceConfig.Index] = fmt.Sprintf("| %s | %s | %s |", serviceName, getSource(serviceConfig), getServiceUrl(serviceConfig))
+	for _, endpoint := range dbEndpoints {
+		endpoints = append(endpoints, prcomments.Endpoint{
+			Service:  endpoint.Service,
+			Protocol: endpoint.Protocol,
+			Address:  net.JoinHostPort(endpoint.Host, strconv.Itoa(endpoint.PublicPort)),
+		})
 	}
-	return strings.Join(rows, "\n")
+
+	return endpoints
 }
 
 func getServiceUrl(svc transformer.EnvironmentService) string {
 	if svc.Url == "" {
-		return "[not exposed - internal service]"
+		return ""
 	}
 
 	return fmt.Sprintf("https://%s", svc.Url)
@@ -93,3 +213,12 @@ func getSource(svc transformer.EnvironmentService) string {
 
 	return svc.Image
 }
+
+func getMainServiceUrl(env *transformer.Environment) string {
+	url := getServiceUrl(env.FirstService())
+	if url == "" {
+		return "[not exposed - internal service]"
+	}
+
+	return url
+}
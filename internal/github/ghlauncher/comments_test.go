@@ -0,0 +1,36 @@
+package ghlauncher
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/prcomments"
+	ghAppMocks "github.com/ergomake/ergomake/mocks/github/ghapp"
+	prcommentsMocks "github.com/ergomake/ergomake/mocks/prcomments"
+)
+
+func TestPostComment_TemplateOfDefaultBranch(t *testing.T) {
+	env := &database.Environment{
+		Owner:       "acme",
+		Repo:        "api",
+		PullRequest: sql.NullInt32{Int32: 7, Valid: true},
+		GHCommentID: 3,
+	}
+
+	settingsProvider := prcommentsMocks.NewSettingsProvider(t)
+	settingsProvider.EXPECT().Get(mock.Anything, "acme", "api").Return(&prcomments.Settings{}, nil)
+
+	ghApp := ghAppMocks.NewGHAppClient(t)
+	ghApp.EXPECT().GetFileContent(mock.Anything, "acme", "api", "", prcomments.RepoTemplatePath).
+		Return("{{shortSha}} of the default branch template", nil)
+	ghApp.EXPECT().UpsertComment(mock.Anything, "acme", "api", 7, int64(3), "abc1234 of the default branch template").
+		Return(nil, nil)
+
+	_, err := postComment(context.Background(), ghApp, settingsProvider, env, "abc1234def", prcomments.NewData(prcomments.StatusSuccess))
+	require.NoError(t, err)
+}
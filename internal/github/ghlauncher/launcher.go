@@ -10,12 +10,20 @@ import (
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
+	"github.com/ergomake/ergomake/internal/audit"
 	"github.com/ergomake/ergomake/internal/cluster"
 	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/domains"
+	"github.com/ergomake/ergomake/internal/endpoints"
 	"github.com/ergomake/ergomake/internal/environments"
 	"github.com/ergomake/ergomake/internal/envvars"
+	"github.com/ergomake/ergomake/internal/forkpolicy"
 	"github.com/ergomake/ergomake/internal/github/ghapp"
+	"github.com/ergomake/ergomake/internal/github/ghdeployments"
 	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/notifications"
+	"github.com/ergomake/ergomake/internal/prcomments"
+	"github.com/ergomake/ergomake/internal/previewaccess"
 	"github.com/ergomake/ergomake/internal/privregistry"
 	"github.com/ergomake/ergomake/internal/transformer"
 )
@@ -27,8 +35,12 @@ type LaunchEnvironmentRequest struct {
 	Branch      string
 	SHA         string
 	PrNumber    *int
-	Author      string
-	IsPrivate   bool
+	// Author is who triggered the launch
+	Author string
+	// PrAuthor is who opened the pull request PrNumber, which is who wrote
+	// its code unlike Author, who may be a maintainer reopening it
+	PrAuthor  string
+	IsPrivate bool
 }
 type GHLauncher interface {
 	LaunchEnvironment(ctx context.Context, req LaunchEnvironmentRequest) error
@@ -40,7 +52,15 @@ type ghLauncher struct {
 	clusterClient           cluster.Client
 	envVarsProvider         envvars.EnvVarsProvider
 	privRegistryProvider    privregistry.PrivRegistryProvider
+	previewAccessProvider   previewaccess.AccessProvider
+	domainsProvider         domains.DomainsProvider
+	endpointsProvider       endpoints.EndpointsProvider
+	endpointsProxy          endpoints.Proxy
 	environmentsProvider    environments.EnvironmentsProvider
+	commentSettingsProvider prcomments.SettingsProvider
+	forkPolicyProvider      forkpolicy.PolicyProvider
+	notifier                notifications.Notifier
+	auditProvider           audit.AuditProvider
 	dockerhubPullSecretName string
 	frontendURL             string
 }
@@ -51,7 +71,15 @@ func NewGHLauncher(
 	clusterClient cluster.Client,
 	envVarsProvider envvars.EnvVarsProvider,
 	privRegistryProvider privregistry.PrivRegistryProvider,
+	previewAccessProvider previewaccess.AccessProvider,
+	domainsProvider domains.DomainsProvider,
+	endpointsProvider endpoints.EndpointsProvider,
+	endpointsProxy endpoints.Proxy,
 	environmentsProvider environments.EnvironmentsProvider,
+	commentSettingsProvider prcomments.SettingsProvider,
+	forkPolicyProvider forkpolicy.PolicyProvider,
+	notifier notifications.Notifier,
+	auditProvider audit.AuditProvider,
 	dockerhubPullSecretName string,
 	frontendURL string,
 ) *ghLauncher {
@@ -61,7 +89,15 @@ func NewGHLauncher(
 		clusterClient,
 		envVarsProvider,
 		privRegistryProvider,
+		previewAccessProvider,
+		domainsProvider,
+		endpointsProvider,
+		endpointsProxy,
 		environmentsProvider,
+		commentSettingsProvider,
+		forkPolicyProvider,
+		notifier,
+		auditProvider,
 		dockerhubPullSecretName,
 		frontendURL,
 	}
@@ -108,14 +144,37 @@ func (gh *ghLauncher) LaunchEnvironment(ctx context.Context, req LaunchEnvironme
 		return errors.Wrap(err, "fail to check if owner is limited")
 	}
 
+	envVarsProvider := gh.envVarsProvider
+	secretsAllowed, err := forkpolicy.SecretsAllowed(
+		ctx,
+		gh.forkPolicyProvider,
+		req.Owner,
+		req.BranchOwner,
+		req.Repo,
+		req.PrNumber,
+		req.SHA,
+	)
+	if err != nil {
+		return errors.Wrap(err, "fail to check if secrets are allowed")
+	}
+
+	if !secretsAllowed {
+		logger.Ctx(ctx).Info().Str("branchOwner", req.BranchOwner).
+			Msg("secrets are not allowed for fork pull request, only plain variables will be injected")
+		envVarsProvider = envvars.NewPlainEnvVarsProvider(envVarsProvider)
+	}
+
 	uid := uuid.New()
 
 	t := transformer.NewGitCompose(
 		gh.clusterClient,
 		gh.ghApp,
 		gh.db,
-		gh.envVarsProvider,
+		envVarsProvider,
 		gh.privRegistryProvider,
+		gh.previewAccessProvider,
+		gh.domainsProvider,
+		gh.endpointsProvider,
 		req.Owner,
 		req.BranchOwner,
 		req.Repo,
@@ -153,15 +212,19 @@ func (gh *ghLauncher) LaunchEnvironment(ctx context.Context, req LaunchEnvironme
 		env := prepare.Environment
 
 		if req.PrNumber != nil {
-			comment := createLimitedComment()
-			ghComment, err := gh.ghApp.UpsertComment(ctx, req.Owner, req.Repo, *req.PrNumber, previousCommentID, comment)
+			ghComment, err := postComment(ctx, gh.ghApp, gh.commentSettingsProvider, env, req.SHA, createLimitedCommentData())
 			if err != nil {
 				logger.Ctx(ctx).Err(err).Msg("fail to create gh comment for limited env")
-			} else {
+			} else if ghComment != nil {
 				env.GHCommentID = ghComment.GetID()
 			}
 		}
 
+		CompleteCheckRun(ctx, gh.ghApp, gh.db, envFrontendLink, env, req.SHA, "failure", &github.CheckRunOutput{
+			Title:   github.String("Environments limit reached"),
+			Summary: github.String("You've reached your simultaneous environments limit, please talk to us at contact@ergomake.dev to bump your limits."),
+		})
+
 		env.Status = database.EnvLimited
 
 		err = gh.db.Save(env).Error
@@ -179,8 +242,29 @@ func (gh *ghLauncher) LaunchEnvironment(ctx context.Context, req LaunchEnvironme
 		return nil
 	}
 
+	awaitsApproval, err := gh.awaitsApproval(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "fail to check if env awaits approval")
+	}
+
+	if awaitsApproval {
+		return gh.holdForApproval(ctx, env, req.SHA, envFrontendLink)
+	}
+
+	err = gh.auditProvider.Record(ctx, audit.Entry{
+		Actor:  req.Author,
+		Owner:  req.Owner,
+		Repo:   req.Repo,
+		Action: audit.ActionEnvironmentLaunch,
+		Target: env.ID.String(),
+		Diff:   audit.NewDiff(map[string]interface{}{"branch": req.Branch, "prNumber": req.PrNumber, "sha": req.SHA}),
+	})
+	if err != nil {
+		logger.Ctx(ctx).Err(err).Msg("fail to record environment launch in audit log")
+	}
+
 	if prepare.ValidationError != nil {
-		FailRun(ctx, gh.ghApp, gh.db, envFrontendLink, prepare.Environment, req.SHA, prepare.ValidationError)
+		FailRun(ctx, gh.ghApp, gh.db, gh.commentSettingsProvider, gh.notifier, envFrontendLink, prepare.Environment, req.SHA, prepare.ValidationError, nil)
 		return nil
 	}
 
@@ -189,15 +273,44 @@ func (gh *ghLauncher) LaunchEnvironment(ctx context.Context, req LaunchEnvironme
 		return errors.Wrap(err, "fail to create commit status")
 	}
 
+	StartCheckRun(ctx, gh.ghApp, gh.db, envFrontendLink, prepare.Environment, req.SHA)
+
+	err = ghdeployments.Create(ctx, gh.ghApp, gh.db, prepare.Environment, req.SHA)
+	if err != nil {
+		logger.Ctx(ctx).Err(err).Msg("fail to create deployment")
+	}
+
+	err = ghdeployments.SetStatus(ctx, gh.ghApp, prepare.Environment, ghdeployments.StateInProgress, "", envFrontendLink)
+	if err != nil {
+		logger.Ctx(ctx).Err(err).Msg("fail to set deployment status")
+	}
+
 	transformResult, err := t.Transform(ctx, uid)
 
 	if err != nil {
-		FailRun(ctx, gh.ghApp, gh.db, envFrontendLink, prepare.Environment, req.SHA, nil)
+		FailRun(ctx, gh.ghApp, gh.db, gh.commentSettingsProvider, gh.notifier, envFrontendLink, prepare.Environment, req.SHA, nil, nil)
 		return errors.Wrap(err, "fail to transform compose into cluster env")
 	}
 
+	if transformResult.ValidationError != nil {
+		FailRun(ctx, gh.ghApp, gh.db, gh.commentSettingsProvider, gh.notifier, envFrontendLink, prepare.Environment, req.SHA, transformResult.ValidationError, nil)
+		return nil
+	}
+
 	if transformResult.Failed() {
-		FailRun(ctx, gh.ghApp, gh.db, envFrontendLink, prepare.Environment, req.SHA, nil)
+		redactor, err := gh.makeBuildLogsRedactor(ctx, req, transformResult.Environment)
+		if err != nil {
+			logger.Ctx(ctx).Err(err).Msg("fail to make build logs redactor, build logs won't be shown")
+		}
+
+		buildFailures := CollectBuildFailures(
+			ctx,
+			gh.clusterClient,
+			transformResult.Environment,
+			transformResult.FailedJobs,
+			redactor,
+		)
+		FailRun(ctx, gh.ghApp, gh.db, gh.commentSettingsProvider, gh.notifier, envFrontendLink, prepare.Environment, req.SHA, nil, buildFailures)
 		return nil
 	}
 
@@ -211,29 +324,45 @@ func (gh *ghLauncher) LaunchEnvironment(ctx context.Context, req LaunchEnvironme
 			if err != nil {
 				logger.Ctx(ctx).Err(err).Str("conclusion", "failure").Msg("fail to create commit status")
 			}
+
+			CompleteCheckRun(ctx, gh.ghApp, gh.db, envFrontendLink, prepare.Environment, req.SHA, "cancelled", &github.CheckRunOutput{
+				Title:   github.String("Environment cancelled"),
+				Summary: github.String("The environment was terminated before it finished building."),
+			})
+
+			err = ghdeployments.SetStatus(ctx, gh.ghApp, prepare.Environment, ghdeployments.StateInactive, "", envFrontendLink)
+			if err != nil {
+				logger.Ctx(ctx).Err(err).Msg("fail to set deployment status")
+			}
 			return nil
 		}
 
-		FailRun(ctx, gh.ghApp, gh.db, envFrontendLink, prepare.Environment, req.SHA, nil)
+		FailRun(ctx, gh.ghApp, gh.db, gh.commentSettingsProvider, gh.notifier, envFrontendLink, prepare.Environment, req.SHA, nil, nil)
 		return errors.Wrap(err, "fail to check if env should still be launched")
 	}
 
 	err = cluster.Deploy(ctx, gh.clusterClient, transformResult.ClusterEnv)
 	if err != nil {
-		FailRun(ctx, gh.ghApp, gh.db, envFrontendLink, prepare.Environment, req.SHA, nil)
+		FailRun(ctx, gh.ghApp, gh.db, gh.commentSettingsProvider, gh.notifier, envFrontendLink, prepare.Environment, req.SHA, nil, nil)
 		return errors.Wrap(err, "fail to deploy cluster env to cluster")
 	}
 
+	err = gh.endpointsProxy.Expose(ctx, transformResult.ClusterEnv.Namespace, transformResult.Endpoints)
+	if err != nil {
+		FailRun(ctx, gh.ghApp, gh.db, gh.commentSettingsProvider, gh.notifier, envFrontendLink, prepare.Environment, req.SHA, nil, nil)
+		return errors.Wrap(err, "fail to expose endpoints")
+	}
+
 	if transformResult.IsCompose {
 		deploymentsCtx, cancel := context.WithTimeout(ctx, 15*time.Minute)
 		defer cancel()
 		err = gh.clusterClient.WaitDeployments(deploymentsCtx, transformResult.ClusterEnv.Namespace)
 		if err != nil {
-			FailRun(ctx, gh.ghApp, gh.db, envFrontendLink, prepare.Environment, req.SHA, nil)
+			FailRun(ctx, gh.ghApp, gh.db, gh.commentSettingsProvider, gh.notifier, envFrontendLink, prepare.Environment, req.SHA, nil, nil)
 			return errors.Wrap(err, "fail to wait for deployments")
 		}
 
-		SuccessRun(ctx, gh.ghApp, gh.db, envFrontendLink, transformResult.Environment, prepare.Environment, req.SHA)
+		SuccessRun(ctx, gh.ghApp, gh.db, gh.commentSettingsProvider, gh.notifier, envFrontendLink, transformResult.Environment, prepare.Environment, req.SHA)
 	}
 
 	return nil
@@ -243,19 +372,22 @@ func FailRun(
 	ctx context.Context,
 	ghApp ghapp.GHAppClient,
 	db *database.DB,
+	commentSettingsProvider prcomments.SettingsProvider,
+	notifier notifications.Notifier,
 	envFrontendLink string,
 	env *database.Environment,
 	sha string,
 	validationError *transformer.ProjectValidationError,
+	buildFailures []BuildFailure,
 ) {
 	log := logger.Ctx(ctx)
 
 	if env.PullRequest.Valid {
-		comment := createFailureComment(envFrontendLink, validationError)
-		ghComment, err := ghApp.UpsertComment(ctx, env.Owner, env.Repo, int(env.PullRequest.Int32), env.GHCommentID, comment)
+		data := createFailureCommentData(db, env, envFrontendLink, validationError, buildFailures)
+		ghComment, err := postComment(ctx, ghApp, commentSettingsProvider, env, sha, data)
 		if err != nil {
 			log.Err(err).Msg("fail to post failure comment")
-		} else {
+		} else if ghComment != nil {
 			env.GHCommentID = ghComment.GetID()
 			err := db.Save(&env).Error
 			if err != nil {
@@ -268,12 +400,24 @@ func FailRun(
 	if err != nil {
 		log.Err(err).Str("conclusion", "failure").Msg("fail to create commit status")
 	}
+
+	output := createFailureCheckRunOutput(getCheckRunServiceTable(db, env), envFrontendLink, validationError, buildFailures)
+	CompleteCheckRun(ctx, ghApp, db, envFrontendLink, env, sha, "failure", output)
+
+	err = ghdeployments.SetStatus(ctx, ghApp, env, ghdeployments.StateFailure, "", envFrontendLink)
+	if err != nil {
+		log.Err(err).Msg("fail to set deployment status")
+	}
+
+	notifier.Notify(ctx, notifications.NewEnvironmentEvent(notifications.EventFailed, env, sha, "", envFrontendLink))
 }
 
 func SuccessRun(
 	ctx context.Context,
 	ghApp ghapp.GHAppClient,
 	db *database.DB,
+	commentSettingsProvider prcomments.SettingsProvider,
+	notifier notifications.Notifier,
 	envFrontendLink string,
 	compose *transformer.Environment,
 	env *database.Environment,
@@ -282,11 +426,12 @@ func SuccessRun(
 	log := logger.Ctx(ctx)
 
 	if env.PullRequest.Valid {
-		comment := createSuccessComment(compose, envFrontendLink)
-		ghComment, err := ghApp.UpsertComment(ctx, env.Owner, env.Repo, int(env.PullRequest.Int32), env.GHCommentID, comment)
+		data := createSuccessCommentData(compose, envFrontendLink)
+		data.Endpoints = getCommentEndpoints(ctx, db, env)
+		ghComment, err := postComment(ctx, ghApp, commentSettingsProvider, env, sha, data)
 		if err != nil {
 			log.Err(err).Msg("fail to post success comment")
-		} else {
+		} else if ghComment != nil {
 			env.GHCommentID = ghComment.GetID()
 			err := db.Save(&env).Error
 			if err != nil {
@@ -299,4 +444,24 @@ func SuccessRun(
 	if err != nil {
 		log.Err(err).Str("conclusion", "success").Msg("fail to create commit status")
 	}
+
+	output := createSuccessCheckRunOutput(db, env, compose, envFrontendLink)
+	CompleteCheckRun(ctx, ghApp, db, envFrontendLink, env, sha, "success", output)
+
+	// the url must be the same one stale environments are woken up with
+	if len(env.Services) == 0 {
+		services, err := db.FindServicesByEnvironment(env.ID)
+		if err != nil {
+			log.Err(err).Msg("fail to find services of env for deployment url")
+		}
+		env.Services = services
+	}
+	environmentURL := ghdeployments.EnvironmentURL(env)
+
+	err = ghdeployments.SetStatus(ctx, ghApp, env, ghdeployments.StateSuccess, environmentURL, envFrontendLink)
+	if err != nil {
+		log.Err(err).Msg("fail to set deployment status")
+	}
+
+	notifier.Notify(ctx, notifications.NewEnvironmentEvent(notifications.EventReady, env, sha, environmentURL, envFrontendLink))
 }
@@ -16,6 +16,7 @@ type GHOAuthClient interface {
 	GetUser(ctx context.Context) (*github.User, *github.Response, error)
 	ListOrganizations(ctx context.Context) ([]*github.Organization, *github.Response, error)
 	ListOwnerRepos(ctx context.Context, owner string) ([]*github.Repository, error)
+	IsOrgMember(ctx context.Context, org, user string) (bool, error)
 }
 
 type ghOAuthClient struct {
@@ -56,6 +57,11 @@ func (c *ghOAuthClient) ListOrganizations(ctx context.Context) ([]*github.Organi
 	return orgs, nil, nil
 }
 
+func (c *ghOAuthClient) IsOrgMember(ctx context.Context, org, user string) (bool, error) {
+	isMember, _, err := c.Organizations.IsMember(ctx, org, user)
+	return isMember, err
+}
+
 func (c *ghOAuthClient) isOrg(ctx context.Context, owner string) (bool, error) {
 	_, res, err := c.Organizations.Get(ctx, owner)
 	if err != nil {
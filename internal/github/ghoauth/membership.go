@@ -0,0 +1,159 @@
+package ghoauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/logger"
+)
+
+type membershipEntry struct {
+	login     string
+	isMember  bool
+	fetchedAt time.Time
+}
+
+// MembershipCache caches which GitHub user a token belongs to and whether users are
+// members of organizations. Entries are refreshed after ttl, the GitHub requests made to
+// refresh them go through the client http cache so they are revalidated with ETags.
+// When GitHub can't be reached, entries that are younger than staleTTL are used instead.
+type MembershipCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	staleTTL time.Duration
+	logins   map[string]membershipEntry
+	members  map[string]membershipEntry
+	now      func() time.Time
+}
+
+func NewMembershipCache(ttl, staleTTL time.Duration) *MembershipCache {
+	return &MembershipCache{
+		ttl:      ttl,
+		staleTTL: staleTTL,
+		logins:   make(map[string]membershipEntry),
+		members:  make(map[string]membershipEntry),
+		now:      time.Now,
+	}
+}
+
+// memberships is per process, so an invalidation only reaches the replica that got
+// the webhook and the others keep removed members until ttl. Both windows are kept
+// short since a stale entry is someone who may no longer be a member.
+var memberships = NewMembershipCache(time.Minute, 10*time.Minute)
+
+// IsAuthorized checks if the owner of accessToken is owner or a member of the owner org
+func IsAuthorized(ctx context.Context, client GHOAuthClient, accessToken string, owner string) (bool, error) {
+	return memberships.IsAuthorized(ctx, client, accessToken, owner)
+}
+
+// GetLogin returns the login of the GitHub user accessToken belongs to
+func GetLogin(ctx context.Context, client GHOAuthClient, accessToken string) (string, error) {
+	login, err := memberships.getLogin(ctx, client, accessToken)
+	return login, errors.Wrap(err, "fail to get github authenticated user")
+}
+
+// InvalidateMembership drops the cached membership of login in org, in this process only
+func InvalidateMembership(org, login string) {
+	memberships.Invalidate(org, login)
+}
+
+func (mc *MembershipCache) IsAuthorized(
+	ctx context.Context,
+	client GHOAuthClient,
+	accessToken string,
+	owner string,
+) (bool, error) {
+	login, err := mc.getLogin(ctx, client, accessToken)
+	if err != nil {
+		return false, errors.Wrap(err, "fail to get github authenticated user")
+	}
+
+	if strings.EqualFold(login, owner) {
+		return true, nil
+	}
+
+	isMember, err := mc.isMember(ctx, client, owner, login)
+	if err != nil {
+		return false, errors.Wrapf(err, "fail to check if user %s is member of org %s", login, owner)
+	}
+
+	return isMember, nil
+}
+
+func (mc *MembershipCache) Invalidate(org, login string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	delete(mc.members, membershipKey(org, login))
+}
+
+func (mc *MembershipCache) getLogin(ctx context.Context, client GHOAuthClient, accessToken string) (string, error) {
+	sum := sha256.Sum256([]byte(accessToken))
+	key := hex.EncodeToString(sum[:])
+
+	entry, err := mc.get(ctx, mc.logins, key, func() (membershipEntry, error) {
+		user, _, err := client.GetUser(ctx)
+		if err != nil {
+			return membershipEntry{}, err
+		}
+
+		return membershipEntry{login: user.GetLogin()}, nil
+	})
+
+	return entry.login, err
+}
+
+func (mc *MembershipCache) isMember(ctx context.Context, client GHOAuthClient, org, login string) (bool, error) {
+	entry, err := mc.get(ctx, mc.members, membershipKey(org, login), func() (membershipEntry, error) {
+		isMember, err := client.IsOrgMember(ctx, org, login)
+		return membershipEntry{isMember: isMember}, err
+	})
+
+	return entry.isMember, err
+}
+
+func (mc *MembershipCache) get(
+	ctx context.Context,
+	entries map[string]membershipEntry,
+	key string,
+	fetch func() (membershipEntry, error),
+) (membershipEntry, error) {
+	mc.mu.Lock()
+	entry, ok := entries[key]
+	mc.mu.Unlock()
+
+	age := mc.now().Sub(entry.fetchedAt)
+	if ok && age < mc.ttl {
+		return entry, nil
+	}
+
+	fresh, err := fetch()
+	if err != nil {
+		if ok && age < mc.staleTTL {
+			logger.Ctx(ctx).Warn().AnErr("err", err).Dur("age", age).
+				Msg("fail to reach github, using cached membership")
+			return entry, nil
+		}
+
+		return membershipEntry{}, err
+	}
+
+	fresh.fetchedAt = mc.now()
+
+	mc.mu.Lock()
+	entries[key] = fresh
+	mc.mu.Unlock()
+
+	return fresh, nil
+}
+
+func membershipKey(org, login string) string {
+	return strings.ToLower(fmt.Sprintf("%s/%s", org, login))
+}
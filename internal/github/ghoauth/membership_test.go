@@ -0,0 +1,91 @@
+package ghoauth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ergomake/ergomake/internal/github/ghoauth"
+	ghoauthMocks "github.com/ergomake/ergomake/mocks/github/ghoauth"
+)
+
+func TestMembershipCache_IsAuthorized(t *testing.T) {
+	ctx := context.Background()
+	user := &github.User{Login: github.String("user")}
+
+	t.Run("owner is the user", func(t *testing.T) {
+		client := ghoauthMocks.NewGHOAuthClient(t)
+		client.EXPECT().GetUser(mock.Anything).Return(user, nil, nil).Once()
+
+		mc := ghoauth.NewMembershipCache(time.Minute, time.Hour)
+		isAuthorized, err := mc.IsAuthorized(ctx, client, "token", "user")
+		require.NoError(t, err)
+		assert.True(t, isAuthorized)
+	})
+
+	t.Run("caches memberships", func(t *testing.T) {
+		client := ghoauthMocks.NewGHOAuthClient(t)
+		client.EXPECT().GetUser(mock.Anything).Return(user, nil, nil).Once()
+		client.EXPECT().IsOrgMember(mock.Anything, "org", "user").Return(true, nil).Once()
+
+		mc := ghoauth.NewMembershipCache(time.Minute, time.Hour)
+		for i := 0; i < 3; i++ {
+			isAuthorized, err := mc.IsAuthorized(ctx, client, "token", "org")
+			require.NoError(t, err)
+			assert.True(t, isAuthorized)
+		}
+	})
+
+	t.Run("invalidate refetches membership", func(t *testing.T) {
+		client := ghoauthMocks.NewGHOAuthClient(t)
+		client.EXPECT().GetUser(mock.Anything).Return(user, nil, nil).Once()
+		client.EXPECT().IsOrgMember(mock.Anything, "org", "user").Return(true, nil).Once()
+		client.EXPECT().IsOrgMember(mock.Anything, "org", "user").Return(false, nil).Once()
+
+		mc := ghoauth.NewMembershipCache(time.Minute, time.Hour)
+		isAuthorized, err := mc.IsAuthorized(ctx, client, "token", "org")
+		require.NoError(t, err)
+		assert.True(t, isAuthorized)
+
+		mc.Invalidate("Org", "User")
+
+		isAuthorized, err = mc.IsAuthorized(ctx, client, "token", "org")
+		require.NoError(t, err)
+		assert.False(t, isAuthorized)
+	})
+
+	t.Run("falls back to stale entries when github is unreachable", func(t *testing.T) {
+		client := ghoauthMocks.NewGHOAuthClient(t)
+		client.EXPECT().GetUser(mock.Anything).Return(user, nil, nil).Once()
+		client.EXPECT().IsOrgMember(mock.Anything, "org", "user").Return(true, nil).Once()
+
+		// a zero ttl makes every entry expire right away
+		mc := ghoauth.NewMembershipCache(0, time.Hour)
+		isAuthorized, err := mc.IsAuthorized(ctx, client, "token", "org")
+		require.NoError(t, err)
+		assert.True(t, isAuthorized)
+
+		githubErr := errors.New("github is down")
+		client.EXPECT().GetUser(mock.Anything).Return(nil, nil, githubErr)
+		client.EXPECT().IsOrgMember(mock.Anything, "org", "user").Return(false, githubErr)
+
+		isAuthorized, err = mc.IsAuthorized(ctx, client, "token", "org")
+		require.NoError(t, err)
+		assert.True(t, isAuthorized)
+	})
+
+	t.Run("fails when github is unreachable and nothing is cached", func(t *testing.T) {
+		client := ghoauthMocks.NewGHOAuthClient(t)
+		client.EXPECT().GetUser(mock.Anything).Return(nil, nil, errors.New("github is down"))
+
+		mc := ghoauth.NewMembershipCache(time.Minute, time.Hour)
+		_, err := mc.IsAuthorized(ctx, client, "token", "org")
+		assert.Error(t, err)
+	})
+}
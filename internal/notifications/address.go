@@ -0,0 +1,41 @@
+package notifications
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/publicnet"
+)
+
+// SlackWebhookHost is the only host slack channels deliver to
+const SlackWebhookHost = "hooks.slack.com"
+
+var ErrInvalidChannelURL = errors.New("invalid notification channel url")
+
+// ErrForbiddenAddress is returned when deliveries would reach the cluster or the
+// network it runs in
+var ErrForbiddenAddress = publicnet.ErrForbiddenAddress
+
+// ValidateChannelURL tells whether deliveries of a channel of kind can be sent
+// to rawURL. Only https is allowed and slack channels must be slack webhooks.
+// Hosts are only resolved when delivering, so that they can't be changed to
+// resolve to internal addresses after being validated.
+func ValidateChannelURL(kind string, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(ErrInvalidChannelURL, err.Error())
+	}
+
+	if u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+		return errors.Wrapf(ErrInvalidChannelURL, "%s is not an https url", rawURL)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if kind == ChannelKindSlack && host != SlackWebhookHost {
+		return errors.Wrapf(ErrInvalidChannelURL, "slack channels must be webhooks of %s", SlackWebhookHost)
+	}
+
+	return publicnet.CheckHost(host)
+}
@@ -0,0 +1,192 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/crypto"
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+type notificationChannel struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	Owner     string         `gorm:"index"`
+	Kind      string
+	URL       string
+	Secret    string
+}
+
+type notificationDelivery struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt     time.Time
+	ChannelID     uuid.UUID `gorm:"index"`
+	Event         string
+	EnvironmentID *uuid.UUID
+	Attempt       int
+	StatusCode    int
+	Error         string
+	Success       bool
+}
+
+type dbChannelsProvider struct {
+	db     *database.DB
+	secret string
+}
+
+// NewDBChannelsProvider stores channels in the database, urls and secrets are encrypted with secret
+func NewDBChannelsProvider(db *database.DB, secret string) *dbChannelsProvider {
+	return &dbChannelsProvider{db, secret}
+}
+
+func (cp *dbChannelsProvider) List(ctx context.Context, owner string) ([]Channel, error) {
+	var dbChannels []notificationChannel
+	err := cp.db.Table("notification_channels").
+		Order("created_at ASC").
+		Find(&dbChannels, map[string]string{"owner": owner}).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list notification channels of owner %s", owner)
+	}
+
+	channels := make([]Channel, 0, len(dbChannels))
+	for _, dbChannel := range dbChannels {
+		channel, err := cp.decrypt(dbChannel)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to decrypt notification channel %s", dbChannel.ID)
+		}
+
+		channels = append(channels, channel)
+	}
+
+	return channels, nil
+}
+
+func (cp *dbChannelsProvider) Create(ctx context.Context, channel Channel) (*Channel, error) {
+	url, err := crypto.Encrypt(cp.secret, channel.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to encrypt notification channel url")
+	}
+
+	secret := ""
+	if channel.Secret != "" {
+		secret, err = crypto.Encrypt(cp.secret, channel.Secret)
+		if err != nil {
+			return nil, errors.Wrap(err, "fail to encrypt notification channel secret")
+		}
+	}
+
+	dbChannel := notificationChannel{
+		Owner:  channel.Owner,
+		Kind:   channel.Kind,
+		URL:    url,
+		Secret: secret,
+	}
+	err = cp.db.Table("notification_channels").Create(&dbChannel).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to create notification channel for owner %s", channel.Owner)
+	}
+
+	channel.ID = dbChannel.ID
+	channel.CreatedAt = dbChannel.CreatedAt
+
+	return &channel, nil
+}
+
+func (cp *dbChannelsProvider) Delete(ctx context.Context, owner string, id uuid.UUID) error {
+	res := cp.db.Table("notification_channels").
+		Where(map[string]interface{}{"owner": owner, "id": id}).
+		Delete(&notificationChannel{})
+	if res.Error != nil {
+		return errors.Wrapf(res.Error, "fail to delete notification channel %s", id)
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrChannelNotFound
+	}
+
+	return nil
+}
+
+func (cp *dbChannelsProvider) ListDeliveries(ctx context.Context, owner string, channelID uuid.UUID) ([]Delivery, error) {
+	var channel notificationChannel
+	err := cp.db.Table("notification_channels").
+		First(&channel, map[string]interface{}{"owner": owner, "id": channelID}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrChannelNotFound
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to find notification channel %s", channelID)
+	}
+
+	var dbDeliveries []notificationDelivery
+	err = cp.db.Table("notification_deliveries").
+		Order("created_at DESC").
+		Limit(100).
+		Find(&dbDeliveries, map[string]interface{}{"channel_id": channelID}).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list deliveries of notification channel %s", channelID)
+	}
+
+	deliveries := make([]Delivery, 0, len(dbDeliveries))
+	for _, d := range dbDeliveries {
+		deliveries = append(deliveries, Delivery{
+			ID:            d.ID,
+			CreatedAt:     d.CreatedAt,
+			ChannelID:     d.ChannelID,
+			Event:         d.Event,
+			EnvironmentID: d.EnvironmentID,
+			Attempt:       d.Attempt,
+			StatusCode:    d.StatusCode,
+			Error:         d.Error,
+			Success:       d.Success,
+		})
+	}
+
+	return deliveries, nil
+}
+
+func (cp *dbChannelsProvider) SaveDelivery(ctx context.Context, delivery Delivery) error {
+	err := cp.db.Table("notification_deliveries").Create(&notificationDelivery{
+		ChannelID:     delivery.ChannelID,
+		Event:         delivery.Event,
+		EnvironmentID: delivery.EnvironmentID,
+		Attempt:       delivery.Attempt,
+		StatusCode:    delivery.StatusCode,
+		Error:         delivery.Error,
+		Success:       delivery.Success,
+	}).Error
+
+	return errors.Wrapf(err, "fail to save delivery of notification channel %s", delivery.ChannelID)
+}
+
+func (cp *dbChannelsProvider) decrypt(dbChannel notificationChannel) (Channel, error) {
+	channel := Channel{
+		ID:        dbChannel.ID,
+		CreatedAt: dbChannel.CreatedAt,
+		Owner:     dbChannel.Owner,
+		Kind:      dbChannel.Kind,
+	}
+
+	url, err := crypto.Decrypt(cp.secret, dbChannel.URL)
+	if err != nil {
+		return channel, errors.Wrap(err, "fail to decrypt url")
+	}
+	channel.URL = url
+
+	if dbChannel.Secret != "" {
+		secret, err := crypto.Decrypt(cp.secret, dbChannel.Secret)
+		if err != nil {
+			return channel, errors.Wrap(err, "fail to decrypt secret")
+		}
+		channel.Secret = secret
+	}
+
+	return channel, nil
+}
@@ -0,0 +1,95 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+const (
+	ChannelKindSlack   = "slack"
+	ChannelKindWebhook = "webhook"
+)
+
+const (
+	EventReady      = "environment.ready"
+	EventFailed     = "environment.failed"
+	EventStale      = "environment.stale"
+	EventTerminated = "environment.terminated"
+)
+
+type Channel struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	Owner     string    `json:"owner"`
+	Kind      string    `json:"kind"`
+	URL       string    `json:"url"`
+	// Secret is used to sign the payloads of webhook channels
+	Secret string `json:"-"`
+}
+
+type Delivery struct {
+	ID            uuid.UUID  `json:"id"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	ChannelID     uuid.UUID  `json:"channelId"`
+	Event         string     `json:"event"`
+	EnvironmentID *uuid.UUID `json:"environmentId"`
+	Attempt       int        `json:"attempt"`
+	StatusCode    int        `json:"statusCode"`
+	Error         string     `json:"error"`
+	Success       bool       `json:"success"`
+}
+
+// Event is the JSON payload sent to webhook channels
+type Event struct {
+	Type           string    `json:"type"`
+	Timestamp      time.Time `json:"timestamp"`
+	Owner          string    `json:"owner"`
+	Repo           string    `json:"repo"`
+	Branch         string    `json:"branch"`
+	PrNumber       *int      `json:"prNumber"`
+	SHA            string    `json:"sha,omitempty"`
+	EnvironmentID  uuid.UUID `json:"environmentId"`
+	EnvironmentURL string    `json:"environmentUrl,omitempty"`
+	LogsURL        string    `json:"logsUrl,omitempty"`
+}
+
+func NewEnvironmentEvent(eventType string, env *database.Environment, sha, environmentURL, logsURL string) Event {
+	event := Event{
+		Type:           eventType,
+		Timestamp:      time.Now(),
+		Owner:          env.Owner,
+		Repo:           env.Repo,
+		Branch:         env.Branch.String,
+		SHA:            sha,
+		EnvironmentID:  env.ID,
+		EnvironmentURL: environmentURL,
+		LogsURL:        logsURL,
+	}
+
+	if env.PullRequest.Valid {
+		prNumber := int(env.PullRequest.Int32)
+		event.PrNumber = &prNumber
+	}
+
+	return event
+}
+
+type ChannelsProvider interface {
+	List(ctx context.Context, owner string) ([]Channel, error)
+	Create(ctx context.Context, channel Channel) (*Channel, error)
+	Delete(ctx context.Context, owner string, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, owner string, channelID uuid.UUID) ([]Delivery, error)
+	SaveDelivery(ctx context.Context, delivery Delivery) error
+}
+
+var ErrChannelNotFound = errors.New("notification channel not found")
+
+type Notifier interface {
+	// Notify delivers event to every channel of the event owner in background
+	Notify(ctx context.Context, event Event)
+}
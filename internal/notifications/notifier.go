@@ -0,0 +1,201 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/publicnet"
+)
+
+const (
+	EventHeader     = "X-Ergomake-Event"
+	DeliveryHeader  = "X-Ergomake-Delivery"
+	SignatureHeader = "X-Ergomake-Signature"
+)
+
+const maxDeliveryAttempts = 3
+
+type notifier struct {
+	channelsProvider ChannelsProvider
+	client           *http.Client
+	backoff          time.Duration
+	validateURL      func(kind string, rawURL string) error
+}
+
+func NewNotifier(channelsProvider ChannelsProvider) *notifier {
+	return &notifier{channelsProvider, publicnet.NewClient(), time.Second, ValidateChannelURL}
+}
+
+func (n *notifier) Notify(ctx context.Context, event Event) {
+	log := logger.Ctx(ctx).With().
+		Str("owner", event.Owner).
+		Str("event", event.Type).
+		Str("env", event.EnvironmentID.String()).
+		Logger()
+
+	channels, err := n.channelsProvider.List(ctx, event.Owner)
+	if err != nil {
+		log.Err(err).Msg("fail to list notification channels")
+		return
+	}
+
+	for _, channel := range channels {
+		// deliveries outlive the context of whoever fired the event
+		go n.deliver(log.WithContext(context.Background()), channel, event)
+	}
+}
+
+// deliver sends event to channel retrying with exponential backoff, every attempt is
+// recorded in the delivery log
+func (n *notifier) deliver(ctx context.Context, channel Channel, event Event) bool {
+	log := logger.Ctx(ctx).With().Str("channel", channel.ID.String()).Logger()
+
+	body, err := buildPayload(channel, event)
+	if err != nil {
+		log.Err(err).Msg("fail to build notification payload")
+		return false
+	}
+
+	// channels may have been created before their urls were restricted
+	err = n.validateURL(channel.Kind, channel.URL)
+	if err != nil {
+		log.Warn().AnErr("err", err).Msg("notification not delivered to invalid channel url")
+		n.saveDelivery(ctx, channel, event, Delivery{Attempt: 1, Error: err.Error()})
+		return false
+	}
+
+	deliveryID := uuid.New().String()
+	backoff := n.backoff
+	for attempt := 1; attempt <= maxDeliveryAttempts; attempt++ {
+		statusCode, err := n.send(ctx, channel, event, deliveryID, body)
+
+		delivery := Delivery{
+			Attempt:    attempt,
+			StatusCode: statusCode,
+			Success:    err == nil,
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		n.saveDelivery(ctx, channel, event, delivery)
+
+		if err == nil {
+			return true
+		}
+
+		log.Warn().AnErr("err", err).Int("attempt", attempt).Msg("fail to deliver notification")
+
+		if attempt < maxDeliveryAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	return false
+}
+
+// saveDelivery records delivery of event to channel in the delivery log
+func (n *notifier) saveDelivery(ctx context.Context, channel Channel, event Event, delivery Delivery) {
+	delivery.ChannelID = channel.ID
+	delivery.Event = event.Type
+	if event.EnvironmentID != uuid.Nil {
+		delivery.EnvironmentID = &event.EnvironmentID
+	}
+
+	err := n.channelsProvider.SaveDelivery(ctx, delivery)
+	if err != nil {
+		logger.Ctx(ctx).Err(err).Str("channel", channel.ID.String()).Msg("fail to save notification delivery")
+	}
+}
+
+func (n *notifier) send(ctx context.Context, channel Channel, event Event, deliveryID string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.Wrap(err, "fail to create request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Ergomake-Notifications")
+	if channel.Kind == ChannelKindWebhook {
+		req.Header.Set(EventHeader, event.Type)
+		req.Header.Set(DeliveryHeader, deliveryID)
+		if channel.Secret != "" {
+			req.Header.Set(SignatureHeader, Sign(channel.Secret, body))
+		}
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "fail to send request")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, errors.Errorf("got unexpected status code %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// Sign returns the value of the signature header of body, receivers should compute the
+// HMAC-SHA256 of the raw request body with their secret and compare it with the header
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func buildPayload(channel Channel, event Event) ([]byte, error) {
+	switch channel.Kind {
+	case ChannelKindSlack:
+		b, err := json.Marshal(map[string]string{"text": slackText(event)})
+		return b, errors.Wrap(err, "fail to marshal slack payload")
+	case ChannelKindWebhook:
+		b, err := json.Marshal(event)
+		return b, errors.Wrap(err, "fail to marshal webhook payload")
+	}
+
+	return nil, errors.Errorf("unknown notification channel kind %s", channel.Kind)
+}
+
+func slackText(event Event) string {
+	target := fmt.Sprintf("*%s/%s* branch `%s`", event.Owner, event.Repo, event.Branch)
+	if event.PrNumber != nil {
+		target = fmt.Sprintf("*%s/%s#%d*", event.Owner, event.Repo, *event.PrNumber)
+	}
+
+	var text string
+	switch event.Type {
+	case EventReady:
+		text = fmt.Sprintf(":white_check_mark: The preview environment of %s is ready", target)
+		if event.EnvironmentURL != "" {
+			text = fmt.Sprintf("%s: %s", text, event.EnvironmentURL)
+		}
+	case EventFailed:
+		text = fmt.Sprintf(":x: The preview environment of %s failed", target)
+	case EventStale:
+		text = fmt.Sprintf(":zzz: The preview environment of %s went stale", target)
+	case EventTerminated:
+		text = fmt.Sprintf(":wastebasket: The preview environment of %s was terminated", target)
+	default:
+		text = fmt.Sprintf("%s: %s", event.Type, target)
+	}
+
+	if event.LogsURL != "" {
+		text = fmt.Sprintf("%s\n<%s|See logs>", text, event.LogsURL)
+	}
+
+	return text
+}
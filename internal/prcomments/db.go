@@ -0,0 +1,58 @@
+package prcomments
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+type commentSettings struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	Owner     string
+	Repo      string
+	Disabled  bool
+	Template  string
+}
+
+type dbSettingsProvider struct {
+	db *database.DB
+}
+
+func NewDBSettingsProvider(db *database.DB) *dbSettingsProvider {
+	return &dbSettingsProvider{db}
+}
+
+func (sp *dbSettingsProvider) Get(ctx context.Context, owner, repo string) (*Settings, error) {
+	var settings commentSettings
+	err := sp.db.Table("comment_settings").First(&settings, map[string]string{"owner": owner, "repo": repo}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &Settings{}, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to find comment settings of repo %s/%s", owner, repo)
+	}
+
+	return &Settings{Disabled: settings.Disabled, Template: settings.Template}, nil
+}
+
+func (sp *dbSettingsProvider) Upsert(ctx context.Context, owner, repo string, settings Settings) error {
+	var dbSettings commentSettings
+	err := sp.db.Table("comment_settings").Where(map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+	}).Assign(map[string]interface{}{
+		"disabled": settings.Disabled,
+		"template": settings.Template,
+	}).FirstOrCreate(&dbSettings).Error
+
+	return errors.Wrapf(err, "fail to upsert comment settings of repo %s/%s", owner, repo)
+}
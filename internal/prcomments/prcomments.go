@@ -0,0 +1,196 @@
+package prcomments
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cbroglie/mustache"
+	"github.com/pkg/errors"
+)
+
+// RepoTemplatePath is where repositories can override the comment template, it
+// is read from the default branch
+const RepoTemplatePath = ".ergomake/comment.md"
+
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+	StatusLimited = "limited"
+	// StatusAwaitingApproval is a pull request of an external contributor that
+	// is only built after a maintainer approves it
+	StatusAwaitingApproval = "awaiting-approval"
+)
+
+type Settings struct {
+	Disabled bool   `json:"disabled"`
+	Template string `json:"template"`
+}
+
+type SettingsProvider interface {
+	Get(ctx context.Context, owner, repo string) (*Settings, error)
+	Upsert(ctx context.Context, owner, repo string, settings Settings) error
+}
+
+type Service struct {
+	Name        string `json:"name"`
+	Source      string `json:"source"`
+	Url         string `json:"url"`
+	Exposed     bool   `json:"exposed"`
+	BuildStatus string `json:"buildStatus"`
+}
+
+// Endpoint is a grpc, tcp or udp port of a service, credentials are never
+// part of comments since they may be public
+type Endpoint struct {
+	Service  string `json:"service"`
+	Protocol string `json:"protocol"`
+	Address  string `json:"address"`
+}
+
+type BuildFailure struct {
+	Service        string `json:"service"`
+	Classification string `json:"classification"`
+	Step           string `json:"step"`
+	Logs           string `json:"logs"`
+}
+
+// Data is what comment templates have access to, keys are the json names of each field
+type Data struct {
+	Status           string         `json:"status"`
+	Success          bool           `json:"success"`
+	Failure          bool           `json:"failure"`
+	Limited          bool           `json:"limited"`
+	AwaitingApproval bool           `json:"awaitingApproval"`
+	Owner            string         `json:"owner"`
+	Repo             string         `json:"repo"`
+	Branch           string         `json:"branch"`
+	SHA              string         `json:"sha"`
+	ShortSHA         string         `json:"shortSha"`
+	PrNumber         int            `json:"prNumber"`
+	EnvironmentID    string         `json:"environmentId"`
+	LogsUrl          string         `json:"logsUrl"`
+	MainServiceUrl   string         `json:"mainServiceUrl"`
+	Services         []Service      `json:"services"`
+	Endpoints        []Endpoint     `json:"endpoints"`
+	BuildDuration    string         `json:"buildDuration"`
+	FailingService   string         `json:"failingService"`
+	BuildFailures    []BuildFailure `json:"buildFailures"`
+	Reason           string         `json:"reason"`
+}
+
+func NewData(status string) Data {
+	return Data{
+		Status:           status,
+		Success:          status == StatusSuccess,
+		Failure:          status == StatusFailure,
+		Limited:          status == StatusLimited,
+		AwaitingApproval: status == StatusAwaitingApproval,
+		Services:         make([]Service, 0),
+		Endpoints:        make([]Endpoint, 0),
+		BuildFailures:    make([]BuildFailure, 0),
+	}
+}
+
+func (d Data) toMap() map[string]interface{} {
+	b, _ := json.Marshal(d)
+	var m map[string]interface{}
+	_ = json.Unmarshal(b, &m)
+
+	m["hasEndpoints"] = len(d.Endpoints) > 0
+
+	return m
+}
+
+// Render renders template with data, values are not html escaped since comments are markdown
+func Render(template string, data Data) (string, error) {
+	comment, err := mustache.RenderRaw(template, true, data.toMap())
+
+	return comment, errors.Wrap(err, "fail to render comment template")
+}
+
+// Validate checks that template is a valid mustache template
+func Validate(template string) error {
+	_, err := mustache.ParseStringRaw(template, true)
+
+	return errors.Wrap(err, "fail to parse comment template")
+}
+
+const DefaultTemplate = `{{#success}}
+Hi 👋
+
+Here's a preview environment 🚀
+
+{{#mainServiceUrl}}{{mainServiceUrl}}{{/mainServiceUrl}}{{^mainServiceUrl}}[not exposed - internal service]{{/mainServiceUrl}}
+
+# Environment Summary 📑
+
+| Container | Source | URL |
+| - | - | - |
+{{#services}}
+| {{name}} | {{source}} | {{#exposed}}{{url}}{{/exposed}}{{^exposed}}[not exposed - internal service]{{/exposed}} |
+{{/services}}
+{{#hasEndpoints}}
+
+| Container | Protocol | Endpoint |
+| - | - | - |
+{{#endpoints}}
+| {{service}} | {{protocol}} | ` + "`{{address}}`" + ` |
+{{/endpoints}}
+
+Credentials for these endpoints are available to the repository developers.
+{{/hasEndpoints}}
+
+Here are your environment's [logs]({{logsUrl}}).
+
+For questions or comments, [join Discord](https://discord.gg/daGzchUGDt).
+{{/success}}
+{{#failure}}
+Hi 👋
+
+We couldn't create a preview environment for this pull-request 😥
+
+{{reason}}
+{{#buildFailures}}
+
+### ❌ ` + "`{{service}}`" + ` failed to build
+{{#classification}}
+
+**{{classification}}**
+{{/classification}}
+{{#step}}
+
+Failing step: ` + "`{{step}}`" + `
+{{/step}}
+
+<details>
+<summary>Build logs</summary>
+
+` + "```" + `
+{{logs}}
+` + "```" + `
+
+</details>
+{{/buildFailures}}
+
+If you need help, email us at contact@getergomake.com or join [Discord](https://discord.gg/daGzchUGDt).
+{{/failure}}
+{{#limited}}
+Hi there 👋
+
+You’ve just reached your simultaneous environments limit.
+
+Please talk to us at contact@ergomake.dev to bump your limits.
+
+Alternatively, you can close a PR with an existing environment, and reopen this one to get a preview.
+
+Thanks for using Ergomake!
+{{/limited}}
+{{#awaitingApproval}}
+Hi 👋
+
+Thanks for your contribution! Previews of pull requests from external contributors are only built after a maintainer approves them.
+
+Maintainers can approve this commit by commenting ` + "`/ergomake approve {{shortSha}}`" + ` or by adding the ` + "`ergomake:approved`" + ` label. New commits need to be approved again.
+{{/awaitingApproval}}
+
+[Click here](https://github.com/apps/ergomake) to disable Ergomake.`
@@ -0,0 +1,145 @@
+package prcomments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender_DefaultTemplate(t *testing.T) {
+	tt := []struct {
+		name string
+		data func() Data
+		want string
+	}{
+		{
+			name: "success",
+			data: func() Data {
+				data := NewData(StatusSuccess)
+				data.MainServiceUrl = "https://web.env.ergomake.link"
+				data.LogsUrl = "https://app.ergomake.dev/gh/owner/repos/repo/envs/id"
+				data.Services = []Service{
+					{Name: "web", Source: "Dockerfile", Url: "https://web.env.ergomake.link", Exposed: true},
+					{Name: "db", Source: "mongo"},
+				}
+				return data
+			},
+			want: `Hi 👋
+
+Here's a preview environment 🚀
+
+https://web.env.ergomake.link
+
+# Environment Summary 📑
+
+| Container | Source | URL |
+| - | - | - |
+| web | Dockerfile | https://web.env.ergomake.link |
+| db | mongo | [not exposed - internal service] |
+
+Here are your environment's [logs](https://app.ergomake.dev/gh/owner/repos/repo/envs/id).
+
+For questions or comments, [join Discord](https://discord.gg/daGzchUGDt).
+
+[Click here](https://github.com/apps/ergomake) to disable Ergomake.`,
+		},
+		{
+			name: "failure",
+			data: func() Data {
+				data := NewData(StatusFailure)
+				data.Reason = "Compose has no `services` defined."
+				return data
+			},
+			want: `Hi 👋
+
+We couldn't create a preview environment for this pull-request 😥
+
+Compose has no ` + "`services`" + ` defined.
+
+If you need help, email us at contact@getergomake.com or join [Discord](https://discord.gg/daGzchUGDt).
+
+[Click here](https://github.com/apps/ergomake) to disable Ergomake.`,
+		},
+		{
+			name: "failure with build failures",
+			data: func() Data {
+				data := NewData(StatusFailure)
+				data.Reason = "We couldn't build `web`."
+				data.BuildFailures = []BuildFailure{
+					{
+						Service:        "web",
+						Classification: "OOM-killed: the build ran out of memory.",
+						Step:           "RUN npm install",
+						Logs:           "INFO[0001] RUN npm install\nKilled",
+					},
+				}
+				return data
+			},
+			want: `Hi 👋
+
+We couldn't create a preview environment for this pull-request 😥
+
+We couldn't build ` + "`web`" + `.
+
+### ❌ ` + "`web`" + ` failed to build
+
+**OOM-killed: the build ran out of memory.**
+
+Failing step: ` + "`RUN npm install`" + `
+
+<details>
+<summary>Build logs</summary>
+
+` + "```" + `
+INFO[0001] RUN npm install
+Killed
+` + "```" + `
+
+</details>
+
+If you need help, email us at contact@getergomake.com or join [Discord](https://discord.gg/daGzchUGDt).
+
+[Click here](https://github.com/apps/ergomake) to disable Ergomake.`,
+		},
+		{
+			name: "awaiting approval",
+			data: func() Data {
+				data := NewData(StatusAwaitingApproval)
+				data.ShortSHA = "1a2b3c4"
+				return data
+			},
+			want: `Hi 👋
+
+Thanks for your contribution! Previews of pull requests from external contributors are only built after a maintainer approves them.
+
+Maintainers can approve this commit by commenting ` + "`/ergomake approve 1a2b3c4`" + ` or by adding the ` + "`ergomake:approved`" + ` label. New commits need to be approved again.
+
+[Click here](https://github.com/apps/ergomake) to disable Ergomake.`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			comment, err := Render(DefaultTemplate, tc.data())
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, comment)
+		})
+	}
+}
+
+func TestRender_CustomTemplate(t *testing.T) {
+	data := NewData(StatusFailure)
+	data.ShortSHA = "abc1234"
+	data.FailingService = "web"
+	data.BuildDuration = "1m30s"
+
+	comment, err := Render(
+		"{{#failure}}`{{failingService}}` failed at {{shortSha}} after {{buildDuration}}{{/failure}}",
+		data,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "`web` failed at abc1234 after 1m30s", comment)
+}
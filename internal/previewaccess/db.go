@@ -0,0 +1,286 @@
+package previewaccess
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+type previewAccessSettings struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	Owner        string
+	Repo         string
+	Mode         string
+	Username     string
+	PasswordHash string
+}
+
+type previewBypassToken struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+	Owner      string
+	Repo       string
+	Name       string
+	Hash       string
+	Hint       string
+	LastUsedAt *time.Time
+}
+
+type previewShareLink struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	EnvironmentID uuid.UUID      `gorm:"type:uuid"`
+	Owner         string
+	Repo          string
+	Name          string
+	CreatedBy     string
+	ExpiresAt     time.Time
+	MaxUses       *int
+	Uses          int
+}
+
+type dbAccessProvider struct {
+	db *database.DB
+}
+
+func NewDBAccessProvider(db *database.DB) *dbAccessProvider {
+	return &dbAccessProvider{db}
+}
+
+func (ap *dbAccessProvider) GetSettings(ctx context.Context, owner, repo string) (*Settings, error) {
+	var dbSettings previewAccessSettings
+	err := ap.db.Table("preview_access_settings").
+		First(&dbSettings, map[string]string{"owner": owner, "repo": repo}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		settings := DefaultSettings(owner, repo)
+		return &settings, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to find preview access settings of repo %s/%s", owner, repo)
+	}
+
+	return &Settings{
+		Owner:        dbSettings.Owner,
+		Repo:         dbSettings.Repo,
+		Mode:         Mode(dbSettings.Mode),
+		Username:     dbSettings.Username,
+		PasswordHash: dbSettings.PasswordHash,
+	}, nil
+}
+
+func (ap *dbAccessProvider) UpsertSettings(ctx context.Context, settings Settings) error {
+	var dbSettings previewAccessSettings
+	err := ap.db.Table("preview_access_settings").Where(map[string]interface{}{
+		"owner": settings.Owner,
+		"repo":  settings.Repo,
+	}).Assign(map[string]interface{}{
+		"mode":          string(settings.Mode),
+		"username":      settings.Username,
+		"password_hash": settings.PasswordHash,
+	}).FirstOrCreate(&dbSettings).Error
+
+	return errors.Wrapf(err, "fail to upsert preview access settings of repo %s/%s", settings.Owner, settings.Repo)
+}
+
+func (ap *dbAccessProvider) CreateBypassToken(ctx context.Context, owner, repo, name string) (*BypassToken, string, error) {
+	value, err := generate()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fail to generate bypass token")
+	}
+
+	dbToken := previewBypassToken{
+		Owner: owner,
+		Repo:  repo,
+		Name:  name,
+		Hash:  hash(value),
+		Hint:  hint(value),
+	}
+	err = ap.db.Table("preview_bypass_tokens").Create(&dbToken).Error
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "fail to create bypass token for repo %s/%s", owner, repo)
+	}
+
+	return fromDB(dbToken), value, nil
+}
+
+func (ap *dbAccessProvider) ListBypassTokens(ctx context.Context, owner, repo string) ([]BypassToken, error) {
+	var dbTokens []previewBypassToken
+	err := ap.db.Table("preview_bypass_tokens").
+		Order("created_at ASC").
+		Find(&dbTokens, map[string]string{"owner": owner, "repo": repo}).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list bypass tokens of repo %s/%s", owner, repo)
+	}
+
+	tokens := make([]BypassToken, 0, len(dbTokens))
+	for _, dbToken := range dbTokens {
+		tokens = append(tokens, *fromDB(dbToken))
+	}
+
+	return tokens, nil
+}
+
+func (ap *dbAccessProvider) RevokeBypassToken(ctx context.Context, owner, repo string, id uuid.UUID) error {
+	res := ap.db.Table("preview_bypass_tokens").
+		Where(map[string]interface{}{"owner": owner, "repo": repo, "id": id}).
+		Delete(&previewBypassToken{})
+	if res.Error != nil {
+		return errors.Wrapf(res.Error, "fail to revoke bypass token %s", id)
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrBypassTokenNotFound
+	}
+
+	return nil
+}
+
+func (ap *dbAccessProvider) AuthenticateBypassToken(
+	ctx context.Context,
+	owner, repo, value string,
+) (*BypassToken, error) {
+	if !looksLikeToken(value) {
+		return nil, ErrBypassTokenNotFound
+	}
+
+	var dbToken previewBypassToken
+	err := ap.db.Table("preview_bypass_tokens").
+		First(&dbToken, map[string]string{"owner": owner, "repo": repo, "hash": hash(value)}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBypassTokenNotFound
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to find bypass token")
+	}
+
+	now := time.Now()
+	err = ap.db.Table("preview_bypass_tokens").Where("id = ?", dbToken.ID).UpdateColumn("last_used_at", now).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to update last usage of bypass token %s", dbToken.ID)
+	}
+	dbToken.LastUsedAt = &now
+
+	return fromDB(dbToken), nil
+}
+
+func fromDB(dbToken previewBypassToken) *BypassToken {
+	return &BypassToken{
+		ID:         dbToken.ID,
+		CreatedAt:  dbToken.CreatedAt,
+		Owner:      dbToken.Owner,
+		Repo:       dbToken.Repo,
+		Name:       dbToken.Name,
+		Hint:       dbToken.Hint,
+		LastUsedAt: dbToken.LastUsedAt,
+	}
+}
+
+func (ap *dbAccessProvider) CreateShareLink(ctx context.Context, link ShareLink) (*ShareLink, error) {
+	dbLink := previewShareLink{
+		EnvironmentID: link.EnvironmentID,
+		Owner:         link.Owner,
+		Repo:          link.Repo,
+		Name:          link.Name,
+		CreatedBy:     link.CreatedBy,
+		ExpiresAt:     link.ExpiresAt,
+		MaxUses:       link.MaxUses,
+	}
+	err := ap.db.Table("preview_share_links").Create(&dbLink).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to create share link for environment %s", link.EnvironmentID)
+	}
+
+	return shareLinkFromDB(dbLink), nil
+}
+
+func (ap *dbAccessProvider) ListShareLinks(ctx context.Context, environmentID uuid.UUID) ([]ShareLink, error) {
+	var dbLinks []previewShareLink
+	err := ap.db.Table("preview_share_links").
+		Order("created_at ASC").
+		Find(&dbLinks, map[string]interface{}{"environment_id": environmentID}).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list share links of environment %s", environmentID)
+	}
+
+	links := make([]ShareLink, 0, len(dbLinks))
+	for _, dbLink := range dbLinks {
+		links = append(links, *shareLinkFromDB(dbLink))
+	}
+
+	return links, nil
+}
+
+func (ap *dbAccessProvider) GetShareLink(ctx context.Context, id uuid.UUID) (*ShareLink, error) {
+	var dbLink previewShareLink
+	err := ap.db.Table("preview_share_links").First(&dbLink, map[string]interface{}{"id": id}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrShareLinkNotFound
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to find share link %s", id)
+	}
+
+	return shareLinkFromDB(dbLink), nil
+}
+
+func (ap *dbAccessProvider) RevokeShareLink(ctx context.Context, environmentID, id uuid.UUID) error {
+	res := ap.db.Table("preview_share_links").
+		Where(map[string]interface{}{"environment_id": environmentID, "id": id}).
+		Delete(&previewShareLink{})
+	if res.Error != nil {
+		return errors.Wrapf(res.Error, "fail to revoke share link %s", id)
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrShareLinkNotFound
+	}
+
+	return nil
+}
+
+func (ap *dbAccessProvider) RedeemShareLink(ctx context.Context, id uuid.UUID) (*ShareLink, error) {
+	// a single conditional update so concurrent requests can't go over the limit
+	res := ap.db.Table("preview_share_links").
+		Where("id = ? AND deleted_at IS NULL AND expires_at > NOW()", id).
+		Where("max_uses IS NULL OR uses < max_uses").
+		UpdateColumn("uses", gorm.Expr("uses + 1"))
+	if res.Error != nil {
+		return nil, errors.Wrapf(res.Error, "fail to redeem share link %s", id)
+	}
+
+	if res.RowsAffected == 0 {
+		return nil, ErrShareLinkNotFound
+	}
+
+	return ap.GetShareLink(ctx, id)
+}
+
+func shareLinkFromDB(dbLink previewShareLink) *ShareLink {
+	return &ShareLink{
+		ID:            dbLink.ID,
+		CreatedAt:     dbLink.CreatedAt,
+		EnvironmentID: dbLink.EnvironmentID,
+		Owner:         dbLink.Owner,
+		Repo:          dbLink.Repo,
+		Name:          dbLink.Name,
+		CreatedBy:     dbLink.CreatedBy,
+		ExpiresAt:     dbLink.ExpiresAt,
+		MaxUses:       dbLink.MaxUses,
+		Uses:          dbLink.Uses,
+	}
+}
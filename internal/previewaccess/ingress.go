@@ -0,0 +1,38 @@
+package previewaccess
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const (
+	authURLAnnotation             = "nginx.ingress.kubernetes.io/auth-url"
+	authSigninAnnotation          = "nginx.ingress.kubernetes.io/auth-signin"
+	authAlwaysSetCookieAnnotation = "nginx.ingress.kubernetes.io/auth-always-set-cookie"
+)
+
+// ApplyToIngress makes ingress-nginx ask authURL whether each request can go through.
+// Annotations of a previous mode are removed, so it is safe to call on live ingresses.
+// Basic auth doesn't get a sign in URL because nginx would redirect the 401 instead
+// of passing the WWW-Authenticate challenge on to the browser. Members mode sets the
+// cookies of denied requests too, the session handover relies on it.
+func ApplyToIngress(ingress *networkingv1.Ingress, mode Mode, authURL, signinURL string) {
+	// annotations are often the same map as the labels, so never change it in place
+	annotations := make(map[string]string, len(ingress.Annotations)+2)
+	for k, v := range ingress.Annotations {
+		if k == authURLAnnotation || k == authSigninAnnotation || k == authAlwaysSetCookieAnnotation {
+			continue
+		}
+
+		annotations[k] = v
+	}
+
+	if mode != ModePublic && authURL != "" {
+		annotations[authURLAnnotation] = authURL
+		if mode == ModeMembers && signinURL != "" {
+			annotations[authSigninAnnotation] = signinURL
+			annotations[authAlwaysSetCookieAnnotation] = "true"
+		}
+	}
+
+	ingress.Annotations = annotations
+}
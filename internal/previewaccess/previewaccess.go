@@ -0,0 +1,124 @@
+package previewaccess
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Mode string
+
+const (
+	// ModePublic lets anyone who knows the URL see the preview
+	ModePublic Mode = "public"
+	// ModeBasicAuth asks for a username and password shared by the repo
+	ModeBasicAuth Mode = "basic-auth"
+	// ModeMembers only lets in Ergomake users who are members of the owner
+	ModeMembers Mode = "members"
+)
+
+func (m Mode) IsValid() bool {
+	switch m {
+	case ModePublic, ModeBasicAuth, ModeMembers:
+		return true
+	}
+
+	return false
+}
+
+// BypassTokenPrefix makes bypass tokens easy to recognize by secret scanners
+const BypassTokenPrefix = "ergo_preview_"
+
+// BypassTokenHeader is the header automated tests send the bypass token in
+const BypassTokenHeader = "X-Ergomake-Bypass-Token"
+
+var ErrBypassTokenNotFound = errors.New("preview bypass token not found")
+
+type Settings struct {
+	Owner    string `json:"owner"`
+	Repo     string `json:"repo"`
+	Mode     Mode   `json:"mode"`
+	Username string `json:"username"`
+	// PasswordHash is the bcrypt hash of the basic auth password
+	PasswordHash string `json:"-"`
+}
+
+func DefaultSettings(owner, repo string) Settings {
+	return Settings{Owner: owner, Repo: repo, Mode: ModePublic}
+}
+
+// CheckBasicAuth tells whether username and password are the credentials of settings
+func (s *Settings) CheckBasicAuth(username, password string) bool {
+	if s.Username == "" || s.PasswordHash == "" || username != s.Username {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(s.PasswordHash), []byte(password)) == nil
+}
+
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), errors.Wrap(err, "fail to hash basic auth password")
+}
+
+type BypassToken struct {
+	ID         uuid.UUID  `json:"id"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	Owner      string     `json:"owner"`
+	Repo       string     `json:"repo"`
+	Name       string     `json:"name"`
+	Hint       string     `json:"hint"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
+
+type AccessProvider interface {
+	// GetSettings returns the public settings when the repo never configured them
+	GetSettings(ctx context.Context, owner, repo string) (*Settings, error)
+	UpsertSettings(ctx context.Context, settings Settings) error
+
+	// CreateBypassToken returns the created token and its plain text value, which is never stored
+	CreateBypassToken(ctx context.Context, owner, repo, name string) (*BypassToken, string, error)
+	ListBypassTokens(ctx context.Context, owner, repo string) ([]BypassToken, error)
+	RevokeBypassToken(ctx context.Context, owner, repo string, id uuid.UUID) error
+	// AuthenticateBypassToken finds the token of owner/repo whose plain text value is value,
+	// it returns ErrBypassTokenNotFound if there is none or if it was revoked
+	AuthenticateBypassToken(ctx context.Context, owner, repo, value string) (*BypassToken, error)
+
+	CreateShareLink(ctx context.Context, link ShareLink) (*ShareLink, error)
+	ListShareLinks(ctx context.Context, environmentID uuid.UUID) ([]ShareLink, error)
+	// GetShareLink returns ErrShareLinkNotFound if the link was revoked
+	GetShareLink(ctx context.Context, id uuid.UUID) (*ShareLink, error)
+	RevokeShareLink(ctx context.Context, environmentID, id uuid.UUID) error
+	// RedeemShareLink counts one more use of the link, it returns ErrShareLinkNotFound
+	// if the link was revoked, is expired or has no uses left
+	RedeemShareLink(ctx context.Context, id uuid.UUID) (*ShareLink, error)
+}
+
+func generate() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "fail to generate random token")
+	}
+
+	return BypassTokenPrefix + hex.EncodeToString(b), nil
+}
+
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func hint(value string) string {
+	return "..." + value[len(value)-4:]
+}
+
+func looksLikeToken(value string) bool {
+	return strings.HasPrefix(value, BypassTokenPrefix) && len(value) == len(BypassTokenPrefix)+64
+}
@@ -0,0 +1,113 @@
+package previewaccess
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckBasicAuth(t *testing.T) {
+	hash, err := HashPassword("hunter2")
+	require.NoError(t, err)
+
+	settings := Settings{Mode: ModeBasicAuth, Username: "qa", PasswordHash: hash}
+	assert.True(t, settings.CheckBasicAuth("qa", "hunter2"))
+	assert.False(t, settings.CheckBasicAuth("qa", "wrong"))
+	assert.False(t, settings.CheckBasicAuth("other", "hunter2"))
+
+	noPassword := Settings{Mode: ModeBasicAuth, Username: "qa"}
+	assert.False(t, noPassword.CheckBasicAuth("qa", ""))
+}
+
+func TestSession(t *testing.T) {
+	session, err := NewSession("secret", "web-owner-repo-1.env.ergomake.test", "octocat", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	login, err := ParseSession("secret", "web-owner-repo-1.env.ergomake.test", session)
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", login)
+
+	_, err = ParseSession("secret", "web-owner-repo-2.env.ergomake.test", session)
+	assert.ErrorIs(t, err, ErrInvalidSession)
+
+	_, err = ParseSession("other-secret", "web-owner-repo-1.env.ergomake.test", session)
+	assert.ErrorIs(t, err, ErrInvalidSession)
+
+	// sessions aren't signed with the secret dashboard JWTs are signed with
+	_, err = jwt.Parse(session, func(*jwt.Token) (interface{}, error) { return []byte("secret"), nil })
+	assert.Error(t, err)
+}
+
+func TestApplyToIngress(t *testing.T) {
+	labels := map[string]string{"preview.ergomake.dev/service": "web"}
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Labels: labels, Annotations: labels}}
+
+	ApplyToIngress(ingress, ModeMembers, "http://auth/verify", "https://api/signin")
+	assert.Equal(t, "http://auth/verify", ingress.Annotations[authURLAnnotation])
+	assert.Equal(t, "https://api/signin", ingress.Annotations[authSigninAnnotation])
+	assert.Equal(t, "true", ingress.Annotations[authAlwaysSetCookieAnnotation])
+	assert.Equal(t, "web", ingress.Annotations["preview.ergomake.dev/service"])
+	assert.NotContains(t, labels, authURLAnnotation, "must not change the labels")
+
+	ApplyToIngress(ingress, ModeBasicAuth, "http://auth/verify", "https://api/signin")
+	assert.Equal(t, "http://auth/verify", ingress.Annotations[authURLAnnotation])
+	assert.NotContains(t, ingress.Annotations, authSigninAnnotation)
+	assert.NotContains(t, ingress.Annotations, authAlwaysSetCookieAnnotation)
+
+	ApplyToIngress(ingress, ModePublic, "http://auth/verify", "https://api/signin")
+	assert.NotContains(t, ingress.Annotations, authURLAnnotation)
+	assert.Equal(t, "web", ingress.Annotations["preview.ergomake.dev/service"])
+}
+
+func TestLooksLikeToken(t *testing.T) {
+	value, err := generate()
+	require.NoError(t, err)
+
+	assert.True(t, looksLikeToken(value))
+	assert.False(t, looksLikeToken("ergo_"+strings.Repeat("a", 64)))
+}
+
+func TestShareToken(t *testing.T) {
+	id := uuid.New()
+	token := ShareToken("secret", id)
+
+	parsed, err := ParseShareToken("secret", token)
+	require.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	_, err = ParseShareToken("other-secret", token)
+	assert.ErrorIs(t, err, ErrInvalidShareToken)
+
+	forged := uuid.New().String() + token[strings.Index(token, "."):]
+	_, err = ParseShareToken("secret", forged)
+	assert.ErrorIs(t, err, ErrInvalidShareToken)
+
+	_, err = ParseShareToken("secret", "garbage")
+	assert.ErrorIs(t, err, ErrInvalidShareToken)
+
+	subjectID, ok := ShareLinkIDFromSubject(ShareSubject(id))
+	assert.True(t, ok)
+	assert.Equal(t, id, subjectID)
+
+	_, ok = ShareLinkIDFromSubject("octocat")
+	assert.False(t, ok)
+}
+
+func TestShareLinkSessionExpiry(t *testing.T) {
+	now := time.Now()
+
+	soon := ShareLink{ExpiresAt: now.Add(time.Hour)}
+	assert.Equal(t, soon.ExpiresAt, soon.SessionExpiry(now))
+	assert.False(t, soon.IsExpired(now))
+	assert.True(t, soon.IsExpired(now.Add(time.Hour)))
+
+	later := ShareLink{ExpiresAt: now.Add(DefaultShareDuration)}
+	assert.Equal(t, now.Add(SessionDuration), later.SessionExpiry(now))
+}
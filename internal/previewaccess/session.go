@@ -0,0 +1,67 @@
+package previewaccess
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+// SessionCookieName is the cookie that keeps members signed in to a preview host
+const SessionCookieName = "ergomake_preview_session"
+
+// SessionQueryParam is how the sign in endpoint hands the session over to the
+// preview host. The auth endpoint turns it into a cookie and denies the request,
+// so that the URL with the session never reaches the preview, and the sign in
+// endpoint then sends the browser to the URL without it.
+const SessionQueryParam = "ergomake_preview_session"
+
+const SessionDuration = time.Hour * 12
+
+var ErrInvalidSession = errors.New("invalid preview session")
+
+// sessionKey derives the key sessions are signed with from secret. Previews can
+// read their own session cookie, so sessions must never be accepted where the
+// dashboard JWTs signed with secret itself are.
+func sessionKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("preview-session"))
+	return mac.Sum(nil)
+}
+
+// NewSession signs a session of subject that is only valid on host, subject is
+// either the login of a member or the ShareSubject of a share link
+func NewSession(secret, host, subject string, expiresAt time.Time) (string, error) {
+	claims := jwt.StandardClaims{
+		Audience:  host,
+		Subject:   subject,
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(sessionKey(secret))
+	return token, errors.Wrap(err, "fail to sign preview session")
+}
+
+// ParseSession returns the subject of a session created for host
+func ParseSession(secret, host, session string) (string, error) {
+	token, err := jwt.ParseWithClaims(session, &jwt.StandardClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return sessionKey(secret), nil
+	})
+	if err != nil || !token.Valid {
+		return "", ErrInvalidSession
+	}
+
+	claims, ok := token.Claims.(*jwt.StandardClaims)
+	if !ok || !claims.VerifyAudience(host, true) || claims.Subject == "" {
+		return "", ErrInvalidSession
+	}
+
+	return claims.Subject, nil
+}
@@ -0,0 +1,103 @@
+package previewaccess
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// ShareQueryParam is the query param of preview URLs that carries a share token
+const ShareQueryParam = "ergomake_share"
+
+const (
+	DefaultShareDuration = time.Hour * 24 * 7
+	MaxShareDuration     = time.Hour * 24 * 30
+)
+
+// sessions of people who came in through a share link have this subject prefix,
+// followed by the id of the link, so they stop working when the link is revoked
+const shareSubjectPrefix = "share:"
+
+var ErrShareLinkNotFound = errors.New("preview share link not found")
+var ErrInvalidShareToken = errors.New("invalid preview share token")
+
+// ShareLink lets people outside of the owner see the previews of one environment
+type ShareLink struct {
+	ID            uuid.UUID `json:"id"`
+	CreatedAt     time.Time `json:"createdAt"`
+	EnvironmentID uuid.UUID `json:"environmentId"`
+	Owner         string    `json:"owner"`
+	Repo          string    `json:"repo"`
+	Name          string    `json:"name"`
+	CreatedBy     string    `json:"createdBy"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	// MaxUses is how many times the link can be opened, nil means no limit
+	MaxUses *int `json:"maxUses"`
+	Uses    int  `json:"uses"`
+}
+
+func (l *ShareLink) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// SessionExpiry is when the session created by opening the link must expire,
+// which is never after the link itself
+func (l *ShareLink) SessionExpiry(now time.Time) time.Time {
+	expiry := now.Add(SessionDuration)
+	if l.ExpiresAt.Before(expiry) {
+		return l.ExpiresAt
+	}
+
+	return expiry
+}
+
+// ShareToken signs the id of a share link, so forged tokens are rejected before
+// hitting the database
+func ShareToken(secret string, id uuid.UUID) string {
+	return id.String() + "." + shareSignature(secret, id)
+}
+
+// ParseShareToken returns the id of the share link of a token signed by ShareToken
+func ParseShareToken(secret, token string) (uuid.UUID, error) {
+	rawID, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, ErrInvalidShareToken
+	}
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return uuid.Nil, ErrInvalidShareToken
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(shareSignature(secret, id))) {
+		return uuid.Nil, ErrInvalidShareToken
+	}
+
+	return id, nil
+}
+
+func shareSignature(secret string, id uuid.UUID) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(shareSubjectPrefix + id.String()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func ShareSubject(id uuid.UUID) string {
+	return shareSubjectPrefix + id.String()
+}
+
+// ShareLinkIDFromSubject returns the id of the share link of a session subject,
+// it returns false for sessions of members
+func ShareLinkIDFromSubject(subject string) (uuid.UUID, bool) {
+	if !strings.HasPrefix(subject, shareSubjectPrefix) {
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(strings.TrimPrefix(subject, shareSubjectPrefix))
+	return id, err == nil
+}
@@ -0,0 +1,81 @@
+package publicnet
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrForbiddenAddress is returned when a request would reach the cluster or the
+// network it runs in, addresses set by owners must not be a way of reaching
+// internal services
+var ErrForbiddenAddress = errors.New("address is not allowed")
+
+// sharedAddressSpace is used by carrier-grade NATs and by the pod networks of
+// some clusters, net.IP.IsPrivate doesn't include it
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// CheckHost refuses localhost and literal ips that aren't public. Other hosts
+// are only resolved when dialing, so that they can't be changed to resolve to
+// internal addresses after being checked.
+func CheckHost(host string) error {
+	host = strings.ToLower(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.Wrapf(ErrForbiddenAddress, "%s", host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return errors.Wrapf(ErrForbiddenAddress, "%s", host)
+	}
+
+	return nil
+}
+
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsPrivate() &&
+		!ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// checkDialAddress is called with the resolved address of every connection
+// the client opens, so that hosts resolving to internal addresses are refused
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrapf(ErrForbiddenAddress, "%s", address)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return errors.Wrapf(ErrForbiddenAddress, "%s", host)
+	}
+
+	return nil
+}
+
+// NewClient makes a client for requests to addresses set by owners, it only
+// connects to public addresses, doesn't use proxies and doesn't follow redirects
+func NewClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: checkDialAddress}
+
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			ForceAttemptHTTP2:   true,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package publicnet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient_RefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached a loopback address")
+	}))
+	defer server.Close()
+
+	_, err := NewClient().Get(server.URL)
+	assert.True(t, errors.Is(err, ErrForbiddenAddress), err)
+}
+
+func TestCheckHost(t *testing.T) {
+	tt := []struct {
+		host    string
+		wantErr bool
+	}{
+		{host: "hooks.acme.dev"},
+		{host: "8.8.8.8"},
+		{host: "localhost", wantErr: true},
+		{host: "vault.localhost", wantErr: true},
+		{host: "127.0.0.1", wantErr: true},
+		{host: "10.0.0.12", wantErr: true},
+		{host: "169.254.169.254", wantErr: true},
+		{host: "100.64.1.1", wantErr: true},
+		{host: "::1", wantErr: true},
+		{host: "fd00::1", wantErr: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.host, func(t *testing.T) {
+			err := CheckHost(tc.host)
+			if tc.wantErr {
+				assert.True(t, errors.Is(err, ErrForbiddenAddress), err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package rbac
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/github/ghapp"
+)
+
+type authorizer struct {
+	rbacProvider RBACProvider
+	ghApp        ghapp.GHAppClient
+}
+
+func NewAuthorizer(rbacProvider RBACProvider, ghApp ghapp.GHAppClient) *authorizer {
+	return &authorizer{rbacProvider, ghApp}
+}
+
+func (a *authorizer) IsMember(ctx context.Context, owner, login string) (bool, error) {
+	if strings.EqualFold(owner, login) {
+		return true, nil
+	}
+
+	isMember, err := a.ghApp.IsOrgMember(ctx, owner, login)
+	if err != nil {
+		return false, errors.Wrapf(err, "fail to check membership of %s in %s", login, owner)
+	}
+
+	return isMember, nil
+}
+
+// GetRole expects login to already be a member of owner. Personal accounts are admins
+// of themselves, other members get the highest role among their user assignment, the
+// assignments of their teams and their repository permission, falling back to the
+// owner default role when none of them apply.
+func (a *authorizer) GetRole(ctx context.Context, owner, repo, login string) (Role, error) {
+	if strings.EqualFold(owner, login) {
+		return RoleAdmin, nil
+	}
+
+	assignments, err := a.rbacProvider.ListAssignments(ctx, owner)
+	if err != nil {
+		return RoleNone, errors.Wrapf(err, "fail to list role assignments of owner %s", owner)
+	}
+
+	settings, err := a.rbacProvider.GetSettings(ctx, owner)
+	if err != nil {
+		return RoleNone, errors.Wrapf(err, "fail to get rbac settings of owner %s", owner)
+	}
+
+	role := RoleNone
+	for _, assignment := range assignments {
+		switch assignment.Kind {
+		case AssignmentKindUser:
+			if strings.EqualFold(assignment.Subject, login) {
+				role = maxRole(role, assignment.Role)
+			}
+		case AssignmentKindTeam:
+			if !role.Allows(assignment.Role) {
+				isMember, err := a.ghApp.IsTeamMember(ctx, owner, assignment.Subject, login)
+				if err != nil {
+					return RoleNone, errors.Wrapf(err, "fail to check membership of team %s", assignment.Subject)
+				}
+
+				if isMember {
+					role = maxRole(role, assignment.Role)
+				}
+			}
+		}
+	}
+
+	if settings.UseRepoPermissions && repo != "" {
+		permission, err := a.ghApp.GetRepoPermissionLevel(ctx, owner, repo, login)
+		if err != nil {
+			return RoleNone, errors.Wrapf(err, "fail to get permission level on repo %s/%s", owner, repo)
+		}
+
+		role = maxRole(role, FromRepoPermission(permission))
+	}
+
+	if role == RoleNone {
+		role = settings.DefaultRole
+	}
+
+	return role, nil
+}
@@ -0,0 +1,123 @@
+package rbac_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ergomake/ergomake/internal/rbac"
+	ghAppMocks "github.com/ergomake/ergomake/mocks/github/ghapp"
+	rbacMocks "github.com/ergomake/ergomake/mocks/rbac"
+)
+
+func TestAuthorizer_GetRole(t *testing.T) {
+	tt := []struct {
+		name        string
+		login       string
+		repo        string
+		assignments []rbac.Assignment
+		settings    rbac.Settings
+		teams       map[string]bool
+		permission  string
+		want        rbac.Role
+	}{
+		{
+			name:     "personal account is admin",
+			login:    "org",
+			settings: rbac.Settings{DefaultRole: rbac.RoleViewer},
+			want:     rbac.RoleAdmin,
+		},
+		{
+			name:     "no assignments keeps everyone admin",
+			login:    "user",
+			settings: rbac.DefaultSettings(),
+			want:     rbac.RoleAdmin,
+		},
+		{
+			name:  "user assignment",
+			login: "user",
+			assignments: []rbac.Assignment{
+				{Kind: rbac.AssignmentKindUser, Subject: "User", Role: rbac.RoleDeveloper},
+			},
+			settings: rbac.Settings{DefaultRole: rbac.RoleViewer},
+			want:     rbac.RoleDeveloper,
+		},
+		{
+			name:  "highest of user and team assignments",
+			login: "user",
+			assignments: []rbac.Assignment{
+				{Kind: rbac.AssignmentKindUser, Subject: "user", Role: rbac.RoleViewer},
+				{Kind: rbac.AssignmentKindTeam, Subject: "ops", Role: rbac.RoleAdmin},
+				{Kind: rbac.AssignmentKindTeam, Subject: "devs", Role: rbac.RoleDeveloper},
+			},
+			settings: rbac.Settings{DefaultRole: rbac.RoleViewer},
+			teams:    map[string]bool{"ops": false, "devs": true},
+			want:     rbac.RoleDeveloper,
+		},
+		{
+			name:       "repo permission",
+			login:      "user",
+			repo:       "repo",
+			settings:   rbac.Settings{DefaultRole: rbac.RoleViewer, UseRepoPermissions: true},
+			permission: "write",
+			want:       rbac.RoleDeveloper,
+		},
+		{
+			name:     "default role",
+			login:    "user",
+			settings: rbac.Settings{DefaultRole: rbac.RoleViewer},
+			want:     rbac.RoleViewer,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := rbacMocks.NewRBACProvider(t)
+			ghApp := ghAppMocks.NewGHAppClient(t)
+
+			if tc.login != "org" {
+				provider.EXPECT().ListAssignments(mock.Anything, "org").Return(tc.assignments, nil)
+				settings := tc.settings
+				provider.EXPECT().GetSettings(mock.Anything, "org").Return(&settings, nil)
+			}
+
+			for team, isMember := range tc.teams {
+				ghApp.EXPECT().IsTeamMember(mock.Anything, "org", team, tc.login).Return(isMember, nil)
+			}
+
+			if tc.permission != "" {
+				ghApp.EXPECT().GetRepoPermissionLevel(mock.Anything, "org", tc.repo, tc.login).Return(tc.permission, nil)
+			}
+
+			role, err := rbac.NewAuthorizer(provider, ghApp).GetRole(context.Background(), "org", tc.repo, tc.login)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, role)
+		})
+	}
+}
+
+func TestRole_Allows(t *testing.T) {
+	assert.True(t, rbac.RoleAdmin.Allows(rbac.RoleDeveloper))
+	assert.True(t, rbac.RoleDeveloper.Allows(rbac.RoleDeveloper))
+	assert.False(t, rbac.RoleViewer.Allows(rbac.RoleDeveloper))
+	assert.False(t, rbac.RoleNone.Allows(rbac.RoleViewer))
+	assert.False(t, rbac.RoleNone.IsValid())
+	assert.True(t, rbac.RoleViewer.IsValid())
+}
+
+func TestAuthorizer_IsMember(t *testing.T) {
+	ghApp := ghAppMocks.NewGHAppClient(t)
+	ghApp.EXPECT().IsOrgMember(mock.Anything, "org", "member").Return(true, nil)
+	ghApp.EXPECT().IsOrgMember(mock.Anything, "org", "removed").Return(false, nil)
+
+	authorizer := rbac.NewAuthorizer(rbacMocks.NewRBACProvider(t), ghApp)
+
+	for login, want := range map[string]bool{"Org": true, "member": true, "removed": false} {
+		isMember, err := authorizer.IsMember(context.Background(), "org", login)
+		require.NoError(t, err)
+		assert.Equal(t, want, isMember, login)
+	}
+}
@@ -0,0 +1,129 @@
+package rbac
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+type roleAssignment struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	Owner     string         `gorm:"index"`
+	Kind      string
+	Subject   string
+	Role      string
+}
+
+type rbacSettings struct {
+	ID                 uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
+	Owner              string
+	DefaultRole        string
+	UseRepoPermissions bool
+}
+
+type dbRBACProvider struct {
+	db *database.DB
+}
+
+func NewDBRBACProvider(db *database.DB) *dbRBACProvider {
+	return &dbRBACProvider{db}
+}
+
+func (rp *dbRBACProvider) ListAssignments(ctx context.Context, owner string) ([]Assignment, error) {
+	var dbAssignments []roleAssignment
+	err := rp.db.Table("role_assignments").
+		Order("created_at ASC").
+		Find(&dbAssignments, map[string]string{"owner": owner}).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list role assignments of owner %s", owner)
+	}
+
+	assignments := make([]Assignment, 0, len(dbAssignments))
+	for _, a := range dbAssignments {
+		assignments = append(assignments, Assignment{
+			ID:        a.ID,
+			CreatedAt: a.CreatedAt,
+			Owner:     a.Owner,
+			Kind:      a.Kind,
+			Subject:   a.Subject,
+			Role:      Role(a.Role),
+		})
+	}
+
+	return assignments, nil
+}
+
+func (rp *dbRBACProvider) UpsertAssignment(ctx context.Context, assignment Assignment) (*Assignment, error) {
+	var dbAssignment roleAssignment
+	err := rp.db.Table("role_assignments").Where(map[string]interface{}{
+		"owner":   assignment.Owner,
+		"kind":    assignment.Kind,
+		"subject": assignment.Subject,
+	}).Assign(map[string]interface{}{
+		"role": string(assignment.Role),
+	}).FirstOrCreate(&dbAssignment).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to upsert role assignment of %s %s", assignment.Kind, assignment.Subject)
+	}
+
+	assignment.ID = dbAssignment.ID
+	assignment.CreatedAt = dbAssignment.CreatedAt
+
+	return &assignment, nil
+}
+
+func (rp *dbRBACProvider) DeleteAssignment(ctx context.Context, owner string, id uuid.UUID) error {
+	res := rp.db.Table("role_assignments").
+		Where(map[string]interface{}{"owner": owner, "id": id}).
+		Delete(&roleAssignment{})
+	if res.Error != nil {
+		return errors.Wrapf(res.Error, "fail to delete role assignment %s", id)
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrAssignmentNotFound
+	}
+
+	return nil
+}
+
+func (rp *dbRBACProvider) GetSettings(ctx context.Context, owner string) (*Settings, error) {
+	var dbSettings rbacSettings
+	err := rp.db.Table("rbac_settings").First(&dbSettings, map[string]string{"owner": owner}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		settings := DefaultSettings()
+		return &settings, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to find rbac settings of owner %s", owner)
+	}
+
+	return &Settings{
+		DefaultRole:        Role(dbSettings.DefaultRole),
+		UseRepoPermissions: dbSettings.UseRepoPermissions,
+	}, nil
+}
+
+func (rp *dbRBACProvider) UpsertSettings(ctx context.Context, owner string, settings Settings) error {
+	var dbSettings rbacSettings
+	err := rp.db.Table("rbac_settings").Where(map[string]interface{}{
+		"owner": owner,
+	}).Assign(map[string]interface{}{
+		"default_role":         string(settings.DefaultRole),
+		"use_repo_permissions": settings.UseRepoPermissions,
+	}).FirstOrCreate(&dbSettings).Error
+
+	return errors.Wrapf(err, "fail to upsert rbac settings of owner %s", owner)
+}
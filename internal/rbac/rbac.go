@@ -0,0 +1,102 @@
+package rbac
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+type Role string
+
+const (
+	RoleNone      Role = ""
+	RoleViewer    Role = "viewer"
+	RoleDeveloper Role = "developer"
+	RoleAdmin     Role = "admin"
+)
+
+var roleLevels = map[Role]int{
+	RoleNone:      0,
+	RoleViewer:    1,
+	RoleDeveloper: 2,
+	RoleAdmin:     3,
+}
+
+func (r Role) IsValid() bool {
+	_, ok := roleLevels[r]
+	return ok && r != RoleNone
+}
+
+// Allows checks if r is the same or a higher role than required
+func (r Role) Allows(required Role) bool {
+	return roleLevels[r] >= roleLevels[required]
+}
+
+func maxRole(a, b Role) Role {
+	if roleLevels[a] >= roleLevels[b] {
+		return a
+	}
+
+	return b
+}
+
+// FromRepoPermission maps a GitHub repository permission level to a role
+func FromRepoPermission(permission string) Role {
+	switch permission {
+	case "admin":
+		return RoleAdmin
+	case "maintain", "write":
+		return RoleDeveloper
+	case "triage", "read":
+		return RoleViewer
+	}
+
+	return RoleNone
+}
+
+const (
+	AssignmentKindUser = "user"
+	AssignmentKindTeam = "team"
+)
+
+// Assignment gives Role to a GitHub user login or to the members of a GitHub team slug
+type Assignment struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	Owner     string    `json:"owner"`
+	Kind      string    `json:"kind"`
+	Subject   string    `json:"subject"`
+	Role      Role      `json:"role"`
+}
+
+// Settings control the role of members that have no assignment. The default role is
+// admin so owners that never configured roles keep the access they always had.
+type Settings struct {
+	DefaultRole        Role `json:"defaultRole"`
+	UseRepoPermissions bool `json:"useRepoPermissions"`
+}
+
+func DefaultSettings() Settings {
+	return Settings{DefaultRole: RoleAdmin}
+}
+
+var ErrAssignmentNotFound = errors.New("role assignment not found")
+
+type RBACProvider interface {
+	ListAssignments(ctx context.Context, owner string) ([]Assignment, error)
+	UpsertAssignment(ctx context.Context, assignment Assignment) (*Assignment, error)
+	DeleteAssignment(ctx context.Context, owner string, id uuid.UUID) error
+	GetSettings(ctx context.Context, owner string) (*Settings, error)
+	UpsertSettings(ctx context.Context, owner string, settings Settings) error
+}
+
+type Authorizer interface {
+	// GetRole returns the role login has on owner, repo is optional and is only used
+	// when the owner maps roles from repository permissions
+	GetRole(ctx context.Context, owner, repo, login string) (Role, error)
+	// IsMember checks login is owner or a member of the owner org, it is for
+	// logins that aren't making the request themselves, such as token creators
+	IsMember(ctx context.Context, owner, login string) (bool, error)
+}
@@ -0,0 +1,55 @@
+package secretstores
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pkg/errors"
+)
+
+type awsSecretsManagerStore struct {
+	client *secretsmanager.SecretsManager
+}
+
+func NewAWSSecretsManagerStore(region, accessKeyID, secretAccessKey string) (*awsSecretsManagerStore, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(region),
+		Credentials: credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to create AWS session")
+	}
+
+	return &awsSecretsManagerStore{secretsmanager.New(sess)}, nil
+}
+
+// Read returns the keys of a JSON secret, plain text secrets are returned
+// under the empty key
+func (as *awsSecretsManagerStore) Read(ctx context.Context, path string) (map[string]string, error) {
+	result, err := as.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(path),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+		return nil, errors.Wrapf(ErrSecretNotFound, "aws secret `%s`", path)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to read %s from aws secrets manager", path)
+	}
+
+	if result.SecretString == nil {
+		return nil, errors.Errorf("aws secret `%s` is binary, only text secrets are supported", path)
+	}
+
+	var values map[string]string
+	if json.Unmarshal([]byte(*result.SecretString), &values) != nil {
+		values = map[string]string{"": *result.SecretString}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,104 @@
+package secretstores
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/crypto"
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+type secretStore struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	Owner       string
+	Kind        string
+	Address     string
+	Region      string
+	Credentials string
+	Path        string
+}
+
+type dbConfigProvider struct {
+	db     *database.DB
+	secret string
+}
+
+// NewDBConfigProvider stores secret store configs in the database, credentials are encrypted with secret
+func NewDBConfigProvider(db *database.DB, secret string) *dbConfigProvider {
+	return &dbConfigProvider{db, secret}
+}
+
+func (cp *dbConfigProvider) Get(ctx context.Context, owner string) (*Config, error) {
+	var dbStore secretStore
+	err := cp.db.Table("secret_stores").First(&dbStore, map[string]string{"owner": owner}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrConfigNotFound
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to find secret store of owner %s", owner)
+	}
+
+	return cp.fromDB(dbStore)
+}
+
+func (cp *dbConfigProvider) Upsert(ctx context.Context, config Config) (*Config, error) {
+	credentials, err := crypto.Encrypt(cp.secret, config.Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to encrypt credentials")
+	}
+
+	var dbStore secretStore
+	err = cp.db.Table("secret_stores").Where(map[string]interface{}{
+		"owner": config.Owner,
+	}).Assign(map[string]interface{}{
+		"kind":        string(config.Kind),
+		"address":     config.Address,
+		"region":      config.Region,
+		"credentials": credentials,
+		"path":        config.Path,
+	}).FirstOrCreate(&dbStore).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to upsert secret store of owner %s", config.Owner)
+	}
+
+	return cp.fromDB(dbStore)
+}
+
+func (cp *dbConfigProvider) Delete(ctx context.Context, owner string) error {
+	res := cp.db.Table("secret_stores").
+		Where(map[string]interface{}{"owner": owner}).
+		Delete(&secretStore{})
+	if res.Error != nil {
+		return errors.Wrapf(res.Error, "fail to delete secret store of owner %s", owner)
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrConfigNotFound
+	}
+
+	return nil
+}
+
+func (cp *dbConfigProvider) fromDB(dbStore secretStore) (*Config, error) {
+	credentials, err := crypto.Decrypt(cp.secret, dbStore.Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to decrypt credentials")
+	}
+
+	return &Config{
+		Owner:       dbStore.Owner,
+		Kind:        Kind(dbStore.Kind),
+		Address:     dbStore.Address,
+		Region:      dbStore.Region,
+		Credentials: credentials,
+		Path:        dbStore.Path,
+	}, nil
+}
@@ -0,0 +1,196 @@
+package secretstores
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cbroglie/mustache"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/publicnet"
+)
+
+type Kind string
+
+const (
+	KindVault             Kind = "vault"
+	KindAWSSecretsManager Kind = "aws-secrets-manager"
+)
+
+const (
+	referenceSeparator        = "://"
+	vaultReferenceScheme      = "vault"
+	awsSecretsReferenceScheme = "awssm"
+)
+
+func (k Kind) IsValid() bool {
+	switch k {
+	case KindVault, KindAWSSecretsManager:
+		return true
+	}
+
+	return false
+}
+
+var ErrConfigNotFound = errors.New("secret store not configured")
+var ErrSecretNotFound = errors.New("secret not found")
+var ErrKeyNotFound = errors.New("key not found in secret")
+var ErrInvalidAddress = errors.New("invalid secret store address")
+var ErrInvalidPath = errors.New("invalid secret path")
+
+// Config is how the secret store of an owner is reached
+type Config struct {
+	Owner string `json:"owner"`
+	Kind  Kind   `json:"kind"`
+	// Address is the url of the vault server
+	Address string `json:"address"`
+	// Region is the aws region of secrets manager
+	Region string `json:"region"`
+	// Credentials is a vault token or, for aws, `<access key id>:<secret access key>`
+	Credentials string `json:"-"`
+	// Path is a secret whose keys are used as variables that aren't set in
+	// ergomake, it can use {{owner}}, {{repo}} and {{branch}}
+	Path string `json:"path"`
+}
+
+// DefaultsPath renders Path for a branch, it is empty when there is no Path
+func (c *Config) DefaultsPath(repo, branch string) (string, error) {
+	if c.Path == "" {
+		return "", nil
+	}
+
+	path, err := mustache.Render(c.Path, map[string]string{
+		"owner":  c.Owner,
+		"repo":   repo,
+		"branch": branch,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "fail to render secret store path")
+	}
+
+	// branch names are chosen by whoever pushes, they must not be a way of
+	// reading paths outside of the ones Path points to
+	if _, err := splitPath(path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// splitPath returns the segments of a secret path, refusing empty, `.` and
+// `..` segments
+func splitPath(path string) ([]string, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			return nil, errors.Wrapf(ErrInvalidPath, "`%s`", path)
+		}
+	}
+
+	return segments, nil
+}
+
+type ConfigProvider interface {
+	Get(ctx context.Context, owner string) (*Config, error)
+	Upsert(ctx context.Context, config Config) (*Config, error)
+	Delete(ctx context.Context, owner string) error
+}
+
+// Store reads secrets, which are sets of key value pairs, by path
+type Store interface {
+	Read(ctx context.Context, path string) (map[string]string, error)
+}
+
+// ValidateVaultAddress tells whether credentials can be sent to address, it
+// must be https and must not be reachable only from inside the cluster
+func ValidateVaultAddress(address string) error {
+	u, err := url.Parse(address)
+	if err != nil {
+		return errors.Wrap(ErrInvalidAddress, err.Error())
+	}
+
+	if u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+		return errors.Wrapf(ErrInvalidAddress, "%s is not an https url", address)
+	}
+
+	return publicnet.CheckHost(u.Hostname())
+}
+
+// NewStore returns a client for the secret store described by config, vault
+// clients should be made with publicnet.NewClient since addresses are set by owners
+func NewStore(config Config, httpClient *http.Client) (Store, error) {
+	switch config.Kind {
+	case KindVault:
+		if err := ValidateVaultAddress(config.Address); err != nil {
+			return nil, err
+		}
+
+		return NewVaultStore(httpClient, config.Address, config.Credentials), nil
+	case KindAWSSecretsManager:
+		accessKeyID, secretAccessKey, ok := strings.Cut(config.Credentials, ":")
+		if !ok {
+			return nil, errors.New("aws credentials must be `<access key id>:<secret access key>`")
+		}
+
+		return NewAWSSecretsManagerStore(config.Region, accessKeyID, secretAccessKey)
+	}
+
+	return nil, errors.Errorf("unknown secret store kind %s", config.Kind)
+}
+
+// Reference points to a key of a secret, it is written as
+// vault://kv/app#DB_PASSWORD or awssm://prod/app#DB_PASSWORD. The key can be
+// left out of aws references to secrets that are plain text.
+type Reference struct {
+	Kind Kind
+	Path string
+	Key  string
+}
+
+// ParseReference returns false when value isn't a reference, which means it
+// is a plain value
+func ParseReference(value string) (*Reference, bool) {
+	scheme, rest, ok := strings.Cut(value, referenceSeparator)
+	if !ok {
+		return nil, false
+	}
+
+	var kind Kind
+	switch scheme {
+	case vaultReferenceScheme:
+		kind = KindVault
+	case awsSecretsReferenceScheme:
+		kind = KindAWSSecretsManager
+	default:
+		return nil, false
+	}
+
+	path, key, _ := strings.Cut(rest, "#")
+	if path == "" || (kind == KindVault && key == "") {
+		return nil, false
+	}
+
+	return &Reference{Kind: kind, Path: path, Key: key}, true
+}
+
+// Lookup gets the key of ref out of a secret
+func (r *Reference) Lookup(secret map[string]string) (string, error) {
+	value, ok := secret[r.Key]
+	if !ok {
+		return "", errors.Wrapf(ErrKeyNotFound, "key `%s` of `%s`", r.Key, r.Path)
+	}
+
+	return value, nil
+}
+
+// StoreFactory returns a client for the secret store of an owner
+type StoreFactory func(config Config) (Store, error)
+
+// NewStoreFactory returns a StoreFactory whose vault clients use httpClient
+func NewStoreFactory(httpClient *http.Client) StoreFactory {
+	return func(config Config) (Store, error) {
+		return NewStore(config, httpClient)
+	}
+}
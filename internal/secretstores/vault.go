@@ -0,0 +1,89 @@
+package secretstores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type vaultStore struct {
+	httpClient *http.Client
+	address    string
+	token      string
+}
+
+// NewVaultStore reads secrets from a KV version 2 secrets engine, the first
+// segment of paths is the mount of the engine, as in kv/app
+func NewVaultStore(httpClient *http.Client, address, token string) *vaultStore {
+	return &vaultStore{httpClient, strings.TrimSuffix(address, "/"), token}
+}
+
+type vaultKVResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+func (vs *vaultStore) Read(ctx context.Context, path string) (map[string]string, error) {
+	segments, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(segments) < 2 {
+		return nil, errors.Errorf("vault path `%s` must be `<mount>/<secret>`", path)
+	}
+
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	mount, secret := segments[0], strings.Join(segments[1:], "/")
+	reqURL := fmt.Sprintf("%s/v1/%s/data/%s", vs.address, mount, secret)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to create vault request")
+	}
+	req.Header.Set("X-Vault-Token", vs.token)
+
+	res, err := vs.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to read %s from vault", path)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, errors.Wrapf(ErrSecretNotFound, "vault path `%s`", path)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fail to read %s from vault, got status %d", path, res.StatusCode)
+	}
+
+	var body vaultKVResponse
+	err = json.NewDecoder(res.Body).Decode(&body)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to decode vault response")
+	}
+
+	values := make(map[string]string, len(body.Data.Data))
+	for k, v := range body.Data.Data {
+		if s, ok := v.(string); ok {
+			values[k] = s
+			continue
+		}
+
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to encode key %s of %s", k, path)
+		}
+		values[k] = string(raw)
+	}
+
+	return values, nil
+}
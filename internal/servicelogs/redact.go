@@ -0,0 +1,95 @@
+package servicelogs
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/envvars"
+)
+
+// RedactedValue replaces secrets in log messages
+const RedactedValue = "********"
+
+// minSecretLength keeps values such as 1 or true, which are unlikely secrets,
+// from redacting most of the logs
+const minSecretLength = 4
+
+type Redactor struct {
+	replacer *strings.Replacer
+}
+
+// NewRedactor makes a redactor of secrets, each line of multiline secrets is
+// redacted by itself since logs are split in lines
+func NewRedactor(secrets []string) *Redactor {
+	values := map[string]struct{}{}
+	for _, secret := range secrets {
+		for _, value := range append([]string{secret}, strings.Split(secret, "\n")...) {
+			value = strings.TrimSpace(value)
+			if len(value) >= minSecretLength {
+				values[value] = struct{}{}
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(values))
+	for value := range values {
+		sorted = append(sorted, value)
+	}
+
+	// the replacer tries values in order, longer ones go first so that secrets
+	// that contain other secrets are fully redacted
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+
+		return sorted[i] < sorted[j]
+	})
+
+	oldnew := make([]string, 0, len(sorted)*2)
+	for _, value := range sorted {
+		oldnew = append(oldnew, value, RedactedValue)
+	}
+
+	return &Redactor{strings.NewReplacer(oldnew...)}
+}
+
+// NewEnvRedactor makes a redactor of the current values of the secret variables
+// services of owner/repo get when deployed for target, builds get the same
+// variables. Service of target is ignored.
+func NewEnvRedactor(
+	ctx context.Context,
+	envVarsProvider envvars.EnvVarsProvider,
+	owner, repo string,
+	target envvars.Target,
+	services []string,
+) (*Redactor, error) {
+	secrets := []string{}
+	for _, service := range services {
+		target.Service = service
+		vars, err := envVarsProvider.ListEffective(ctx, owner, repo, target)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to list env vars of service %s", service)
+		}
+
+		for _, v := range vars {
+			if v.IsSecret() {
+				secrets = append(secrets, v.Value)
+			}
+		}
+	}
+
+	return NewRedactor(secrets), nil
+}
+
+func (r *Redactor) Redact(entry LogEntry) LogEntry {
+	entry.Message = r.RedactText(entry.Message)
+	return entry
+}
+
+func (r *Redactor) RedactText(text string) string {
+	return r.replacer.Replace(text)
+}
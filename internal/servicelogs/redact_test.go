@@ -0,0 +1,66 @@
+package servicelogs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ergomake/ergomake/internal/envvars"
+	envvarsMocks "github.com/ergomake/ergomake/mocks/envvars"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	t.Parallel()
+
+	redactor := NewRedactor([]string{
+		"hunter2",
+		"hunter2-extended",
+		"yes",
+		"-----BEGIN KEY-----\nMIIEvQIBADANBgkqhkiG9w0BAQEFAASC\n-----END KEY-----",
+	})
+
+	tt := []struct {
+		message string
+		want    string
+	}{
+		{message: "password is hunter2", want: "password is ********"},
+		{message: "token=hunter2-extended;", want: "token=********;"},
+		{message: "yes, short values are kept", want: "yes, short values are kept"},
+		{message: "  MIIEvQIBADANBgkqhkiG9w0BAQEFAASC", want: "  ********"},
+		{message: "nothing to hide", want: "nothing to hide"},
+	}
+
+	for _, tc := range tt {
+		got := redactor.Redact(LogEntry{ServiceID: "api", Message: tc.message})
+		assert.Equal(t, LogEntry{ServiceID: "api", Message: tc.want}, got)
+	}
+}
+
+func TestNewEnvRedactor(t *testing.T) {
+	t.Parallel()
+
+	target := envvars.Target{Branch: "fix", EnvironmentType: envvars.EnvironmentTypePullRequest}
+	apiTarget := target
+	apiTarget.Service = "api"
+	webTarget := target
+	webTarget.Service = "web"
+
+	envVarsProvider := envvarsMocks.NewEnvVarsProvider(t)
+	envVarsProvider.EXPECT().ListEffective(mock.Anything, "acme", "shop", apiTarget).Return([]envvars.EnvVar{
+		{Name: "API_URL", Value: "https://api.acme.dev", Plain: true},
+		{Name: "DB_PASSWORD", Value: "hunter2"},
+		{Name: "VAULT_TOKEN", Value: "s.vaulttoken", Plain: true, External: true},
+	}, nil)
+	envVarsProvider.EXPECT().ListEffective(mock.Anything, "acme", "shop", webTarget).Return([]envvars.EnvVar{
+		{Name: "SENTRY_DSN", Value: "https://sentry.acme.dev", Plain: true, Group: "shared"},
+	}, nil)
+
+	redactor, err := NewEnvRedactor(context.Background(), envVarsProvider, "acme", "shop", target, []string{"api", "web"})
+	require.NoError(t, err)
+
+	got := redactor.RedactText("https://api.acme.dev hunter2 s.vaulttoken https://sentry.acme.dev")
+	assert.Equal(t, "https://api.acme.dev ******** ******** ********", got)
+}
@@ -0,0 +1,67 @@
+package slug
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLabelLength is the maximum length of a DNS-1123 label
+const MaxLabelLength = 63
+
+// hashLength is how many hex chars of the hash are kept when truncating
+const hashLength = 8
+
+var nonAlphanumericRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+var labelRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Slugify lowercases s, strips accents and replaces every run of chars that
+// are not allowed in a DNS label with a single dash
+func Slugify(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return strings.Trim(nonAlphanumericRegex.ReplaceAllString(b.String(), "-"), "-")
+}
+
+// Label slugifies and joins parts with dashes into a DNS-1123 label,
+// truncating it with Truncate when it is too long
+func Label(parts ...string) string {
+	slugs := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if s := Slugify(part); s != "" {
+			slugs = append(slugs, s)
+		}
+	}
+
+	return Truncate(strings.Join(slugs, "-"))
+}
+
+// Truncate makes label fit in MaxLabelLength by replacing its tail with a hash
+// of the whole label, so that long labels sharing a prefix stay distinct
+func Truncate(label string) string {
+	if len(label) <= MaxLabelLength {
+		return label
+	}
+
+	sum := sha256.Sum256([]byte(label))
+	hash := hex.EncodeToString(sum[:])[:hashLength]
+	prefix := strings.TrimRight(label[:MaxLabelLength-hashLength-1], "-")
+
+	return prefix + "-" + hash
+}
+
+// IsValidLabel tells whether label is a valid DNS-1123 label
+func IsValidLabel(label string) bool {
+	return labelRegex.MatchString(label)
+}
@@ -0,0 +1,60 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	tt := []struct {
+		input string
+		want  string
+	}{
+		{"Ergomake", "ergomake"},
+		{"my_repo", "my-repo"},
+		{"feat/new.thing", "feat-new-thing"},
+		{"--weird__name--", "weird-name"},
+		{"café-crème", "cafe-creme"},
+		{"日本語", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			assert.Equal(t, tc.want, Slugify(tc.input))
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	assert.Equal(t, "web-ergomake-my-repo-42", Label("web", "ergomake", "my_repo", "42"))
+	assert.Equal(t, "web-ergomake-feat-x", Label("web", "ergomake", "", "feat/x"))
+	assert.NotEqual(t, Label("web", "owner", "my_repo", "1"), Label("web", "owner", "myrepo", "1"))
+
+	long := strings.Repeat("very-long-branch-name-", 5)
+	a := Label("web", "owner", "repo", long+"a")
+	b := Label("web", "owner", "repo", long+"b")
+	assert.LessOrEqual(t, len(a), MaxLabelLength)
+	assert.True(t, IsValidLabel(a))
+	assert.True(t, IsValidLabel(b))
+	assert.NotEqual(t, a, b)
+	assert.Equal(t, a, Label("web", "owner", "repo", long+"a"))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", Truncate("short"))
+
+	label := strings.Repeat("a", 54) + "-" + strings.Repeat("b", 20)
+	truncated := Truncate(label)
+	assert.LessOrEqual(t, len(truncated), MaxLabelLength)
+	assert.True(t, IsValidLabel(truncated))
+	assert.True(t, strings.HasPrefix(truncated, strings.Repeat("a", 54)+"-"))
+}
+
+func TestIsValidLabel(t *testing.T) {
+	assert.True(t, IsValidLabel("web-1"))
+	assert.False(t, IsValidLabel("-web"))
+	assert.False(t, IsValidLabel("web.app"))
+	assert.False(t, IsValidLabel(strings.Repeat("a", 64)))
+}
@@ -16,7 +16,10 @@ import (
 	"github.com/ergomake/ergomake/internal/cluster"
 	"github.com/ergomake/ergomake/internal/database"
 	"github.com/ergomake/ergomake/internal/environments"
+	"github.com/ergomake/ergomake/internal/github/ghapp"
+	"github.com/ergomake/ergomake/internal/github/ghdeployments"
 	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/notifications"
 	"github.com/ergomake/ergomake/internal/payment"
 )
 
@@ -24,6 +27,8 @@ type server struct {
 	*gin.Engine
 	clusterClient        cluster.Client
 	environmentsProvider environments.EnvironmentsProvider
+	ghApp                ghapp.GHAppClient
+	notifier             notifications.Notifier
 	paymentProvider      payment.PaymentProvider
 	frontendURL          string
 	timeoutToStale       time.Duration
@@ -34,6 +39,8 @@ type server struct {
 func NewServer(
 	clusterClient cluster.Client,
 	environmentsProvider environments.EnvironmentsProvider,
+	ghApp ghapp.GHAppClient,
+	notifier notifications.Notifier,
 	paymentProvider payment.PaymentProvider,
 	frontendURL string,
 	timeoutToStale time.Duration,
@@ -46,6 +53,8 @@ func NewServer(
 		router,
 		clusterClient,
 		environmentsProvider,
+		ghApp,
+		notifier,
 		paymentProvider,
 		frontendURL,
 		timeoutToStale,
@@ -99,8 +108,8 @@ func (s *server) handle(c *gin.Context) {
 				return
 			}
 
-			if len(ingress.Spec.Rules) > 0 {
-				ingress.Spec.Rules[0].Host = svc.Url
+			for i, rule := range ingress.Spec.Rules {
+				ingress.Spec.Rules[i].Host = strings.TrimPrefix(rule.Host, "stale-")
 			}
 
 			err = s.clusterClient.UpdateIngress(c, ingress)
@@ -129,6 +138,18 @@ func (s *server) handle(c *gin.Context) {
 			logger.Ctx(ctx).Err(err).Str("env", env.ID.String()).Msg("fail to set env status to success")
 			return
 		}
+
+		err = ghdeployments.SetStatus(
+			ctx,
+			s.ghApp,
+			env,
+			ghdeployments.StateSuccess,
+			ghdeployments.EnvironmentURL(env),
+			s.envFrontendLink(env),
+		)
+		if err != nil {
+			logger.Ctx(ctx).Err(err).Str("env", env.ID.String()).Msg("fail to set deployment status to success")
+		}
 	}()
 
 	c.Redirect(
@@ -137,6 +158,10 @@ func (s *server) handle(c *gin.Context) {
 	)
 }
 
+func (s *server) envFrontendLink(env *database.Environment) string {
+	return fmt.Sprintf("%s/gh/%s/repos/%s/envs/%s", s.frontendURL, env.Owner, env.Repo, env.ID)
+}
+
 func (s *server) Listen(ctx context.Context, addr string) error {
 	return s.Run(addr)
 }
@@ -353,7 +378,9 @@ func (s *server) MonitorStaleServices(ctx context.Context) {
 					continue
 				}
 
-				ingress.Spec.Rules[0].Host = fmt.Sprintf("stale-%s", ingress.Spec.Rules[0].Host)
+				for i, rule := range ingress.Spec.Rules {
+					ingress.Spec.Rules[i].Host = fmt.Sprintf("stale-%s", rule.Host)
+				}
 				err = s.clusterClient.UpdateIngress(ctx, ingress)
 				if err != nil {
 					logger.Ctx(ctx).Err(err).Str("service", svc.Name).Str("env", ns).Msg("fail to update ingress to stale environment")
@@ -364,7 +391,22 @@ func (s *server) MonitorStaleServices(ctx context.Context) {
 			err = s.environmentsProvider.SaveEnvironment(ctx, env)
 			if err != nil {
 				logger.Ctx(ctx).Err(err).Str("env", ns).Str("status", string(env.Status)).Msg("fail to update environment status")
+				continue
 			}
+
+			if env.Status != database.EnvStale {
+				continue
+			}
+
+			err = ghdeployments.SetStatus(ctx, s.ghApp, env, ghdeployments.StateInactive, "", s.envFrontendLink(env))
+			if err != nil {
+				logger.Ctx(ctx).Err(err).Str("env", ns).Msg("fail to set deployment of stale environment as inactive")
+			}
+
+			s.notifier.Notify(
+				ctx,
+				notifications.NewEnvironmentEvent(notifications.EventStale, env, "", "", s.envFrontendLink(env)),
+			)
 		}
 	}
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/ergomake/ergomake/internal/database"
 	"github.com/ergomake/ergomake/internal/envvars"
+	"github.com/ergomake/ergomake/internal/slug"
 
 	kpackBuild "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	kpackCore "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -101,7 +102,7 @@ func (c *gitCompose) buildImagesWithBuildpacks(ctx context.Context, namespace st
 
 			cloneTokenSecret := &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      strings.ReplaceAll(strings.ToLower(fmt.Sprintf("%s-%s", repo, namespace)), "_", ""),
+					Name:      slug.Label(repo, namespace),
 					Namespace: "kpack",
 					Annotations: map[string]string{
 						"kpack.io/git": "https://github.com",
@@ -153,9 +154,9 @@ func (c *gitCompose) buildImagesWithBuildpacks(ctx context.Context, namespace st
 			return nil, errors.Wrapf(err, "fail to create service account to build service %s", service.ID)
 		}
 
-		vars, err := c.envVarsProvider.ListByRepoBranch(ctx, c.owner, repo, branch)
+		vars, err := c.listEnvVars(ctx, repo, branch, serviceName)
 		if err != nil {
-			return nil, errors.Wrap(err, "fail to list env vars by repo")
+			return nil, err
 		}
 
 		addedVariables := make(map[string]struct{})
@@ -271,9 +272,9 @@ func (c *gitCompose) buildImagesWithKaniko(ctx context.Context, namespace string
 			branch = defaultBranch
 		}
 
-		vars, err := c.envVarsProvider.ListByRepoBranch(ctx, c.owner, repo, branch)
+		vars, err := c.listEnvVars(ctx, repo, branch, k)
 		if err != nil {
-			return nil, errors.Wrap(err, "fail to list env vars by repo")
+			return nil, err
 		}
 
 		spec := c.makeJobSpec(c.environment.Services[k].ID, k, service, buildPath, vars)
@@ -301,7 +302,7 @@ func (c *gitCompose) buildImagesWithKaniko(ctx context.Context, namespace string
 func makeCloneTokenSecret(namespace, repo, token string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ReplaceAll(strings.ToLower(fmt.Sprintf("%s-%s", repo, namespace)), "_", ""),
+			Name:      slug.Label(repo, namespace),
 			Namespace: "preview-builds",
 		},
 		Data: map[string][]byte{
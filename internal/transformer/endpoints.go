@@ -0,0 +1,99 @@
+package transformer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ergomake/ergomake/internal/endpoints"
+	"github.com/ergomake/ergomake/internal/slug"
+)
+
+const endpointsSecretName = "endpoint-credentials"
+
+// allocateEndpoints gets a public address and a credential for every grpc and
+// stream route of the environment
+func (c *gitCompose) allocateEndpoints(ctx context.Context, envID uuid.UUID) error {
+	names := make([]string, 0, len(c.environment.Services))
+	for name := range c.environment.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, route := range c.environment.Services[name].Routes {
+			if route.Protocol == endpoints.ProtocolHTTP {
+				continue
+			}
+
+			endpoint, err := c.endpointsProvider.Allocate(ctx, endpoints.AllocateRequest{
+				EnvironmentID: envID,
+				Service:       name,
+				Protocol:      route.Protocol,
+				TargetPort:    route.Port,
+				Host:          route.Host,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "fail to allocate %s endpoint for port %d of %s", route.Protocol, route.Port, name)
+			}
+
+			c.endpoints = append(c.endpoints, *endpoint)
+		}
+	}
+
+	return nil
+}
+
+func endpointSecretKey(endpoint endpoints.Endpoint) string {
+	return fmt.Sprintf("%s.%s", slug.Label(endpoint.Service), endpoint.CredentialEnvVar())
+}
+
+// endpointsSecret holds the endpoint credentials, it is nil when there are no endpoints
+func (c *gitCompose) endpointsSecret(namespace string) *corev1.Secret {
+	if len(c.endpoints) == 0 {
+		return nil
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      endpointsSecretName,
+			Namespace: namespace,
+		},
+		StringData: map[string]string{},
+	}
+	for _, endpoint := range c.endpoints {
+		secret.StringData[endpointSecretKey(endpoint)] = endpoint.Credential
+	}
+
+	return secret
+}
+
+// endpointsEnv returns the credential env vars of service, they must come first
+// in the container so that other vars can reference them as $(VAR)
+func (c *gitCompose) endpointsEnv(service string) []corev1.EnvVar {
+	env := []corev1.EnvVar{}
+	for _, endpoint := range c.endpoints {
+		if endpoint.Service != service {
+			continue
+		}
+
+		env = append(env, corev1.EnvVar{
+			Name: endpoint.CredentialEnvVar(),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: endpointsSecretName,
+					},
+					Key: endpointSecretKey(endpoint),
+				},
+			},
+		})
+	}
+
+	return env
+}
@@ -1,7 +1,6 @@
 package transformer
 
 import (
-	"encoding/json"
 	"strings"
 	"unicode"
 
@@ -15,6 +14,7 @@ type EnvironmentService struct {
 	Build         string            `json:"build"`
 	Index         int               `json:"index"`
 	PublicPort    string            `json:"-"`
+	Routes        []Route           `json:"-"`
 	InternalPorts []string          `json:"-"`
 	Env           map[string]string `json:"-"`
 }
@@ -22,16 +22,7 @@ type EnvironmentService struct {
 type Environment struct {
 	Services   map[string]EnvironmentService `json:"services"`
 	RawContent string                        `json:"-"`
-}
-
-func (c *Environment) ToMap() map[string]interface{} {
-	b, _ := json.Marshal(c)
-	var m map[string]interface{}
-	_ = json.Unmarshal(b, &m)
-
-	m["apps"] = m["services"]
-
-	return m
+	ConfigPath string                        `json:"-"`
 }
 
 func EnvironmentFromDB(env *database.Environment) *Environment {
@@ -124,3 +115,16 @@ func (c *Environment) FirstService() EnvironmentService {
 
 	return service
 }
+
+// ServiceLine returns the line where service is declared inside RawContent,
+// it returns 0 when it can't be found
+func (c *Environment) ServiceLine(service string) int {
+	content := []byte(c.RawContent)
+
+	line := FindYAMLKeyLine(content, "services", service)
+	if line == 0 {
+		line = FindYAMLKeyLine(content, "apps", service)
+	}
+
+	return line
+}
@@ -19,13 +19,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 
-	"github.com/cbroglie/mustache"
 	"github.com/google/uuid"
 	"github.com/kubernetes/kompose/pkg/kobject"
 	"github.com/kubernetes/kompose/pkg/loader"
 	"github.com/kubernetes/kompose/pkg/transformer/kubernetes"
 	"github.com/pkg/errors"
-	networkingv1 "k8s.io/api/networking/v1"
+	"gorm.io/gorm"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -33,21 +32,28 @@ import (
 
 	"github.com/ergomake/ergomake/internal/cluster"
 	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/domains"
+	"github.com/ergomake/ergomake/internal/endpoints"
 	"github.com/ergomake/ergomake/internal/envvars"
 	"github.com/ergomake/ergomake/internal/ergopack"
 	"github.com/ergomake/ergomake/internal/git"
 	"github.com/ergomake/ergomake/internal/logger"
+	"github.com/ergomake/ergomake/internal/previewaccess"
 	"github.com/ergomake/ergomake/internal/privregistry"
+	"github.com/ergomake/ergomake/internal/slug"
 )
 
 var clusterDomain string
 var userlandRegistry string
 var insecureRegistry string
+var previewAuthURL string
+var previewAuthSigninURL string
 
 func init() {
 	setInsecureRegistry()
 	setUserlandRegistry()
 	setDomain()
+	setPreviewAuth()
 }
 
 func setInsecureRegistry() {
@@ -84,12 +90,22 @@ func setDomain() {
 	}
 }
 
+// setPreviewAuth reads where ingress-nginx should check access to protected
+// previews, when not set every preview is public
+func setPreviewAuth() {
+	previewAuthURL = os.Getenv("PREVIEW_AUTH_URL")
+	previewAuthSigninURL = os.Getenv("PREVIEW_AUTH_SIGNIN_URL")
+}
+
 type gitCompose struct {
 	clusterClient        cluster.Client
 	gitClient            git.RemoteGitClient
 	db                   *database.DB
 	envVarsProvider      envvars.EnvVarsProvider
 	privRegistryProvider privregistry.PrivRegistryProvider
+	accessProvider       previewaccess.AccessProvider
+	domainsProvider      domains.DomainsProvider
+	endpointsProvider    endpoints.EndpointsProvider
 
 	owner       string
 	branchOwner string
@@ -106,8 +122,15 @@ type gitCompose struct {
 	environment    *Environment
 	isCompose      bool
 	komposeObject  *kobject.KomposeObject
+	accessMode     previewaccess.Mode
+	domain         *domains.Domain
+	endpoints      []endpoints.Endpoint
 	cleanup        func()
 
+	templateContext map[string]interface{}
+	// envVars are the rendered variables by repo, branch and service, see listEnvVars
+	envVars map[string][]envvars.EnvVar
+
 	prepared                bool
 	dockerhubPullSecretName string
 }
@@ -118,6 +141,9 @@ func NewGitCompose(
 	db *database.DB,
 	envVarsProvider envvars.EnvVarsProvider,
 	privRegistryProvider privregistry.PrivRegistryProvider,
+	accessProvider previewaccess.AccessProvider,
+	domainsProvider domains.DomainsProvider,
+	endpointsProvider endpoints.EndpointsProvider,
 	owner string,
 	branchOwner string,
 	repo string,
@@ -134,6 +160,9 @@ func NewGitCompose(
 		db:                      db,
 		envVarsProvider:         envVarsProvider,
 		privRegistryProvider:    privRegistryProvider,
+		accessProvider:          accessProvider,
+		domainsProvider:         domainsProvider,
+		endpointsProvider:       endpointsProvider,
 		owner:                   owner,
 		branchOwner:             branchOwner,
 		repo:                    repo,
@@ -149,8 +178,12 @@ func NewGitCompose(
 type TransformResult struct {
 	ClusterEnv  *cluster.ClusterEnv
 	Environment *Environment
+	Endpoints   []endpoints.Endpoint
 	FailedJobs  []*batchv1.Job
 	IsCompose   bool
+	// ValidationError is set when a url was claimed by another environment
+	// after Prepare checked it
+	ValidationError *ProjectValidationError
 }
 
 func (tr *TransformResult) Failed() bool {
@@ -182,6 +215,15 @@ func (c *gitCompose) Prepare(ctx context.Context, id uuid.UUID) (*PrepareResult,
 
 	c.dbEnvironment = dbEnv
 
+	// the domain must be known before loading the ergopack, that is when urls are made
+	domain, err := c.domainsProvider.Get(ctx, c.owner)
+	if err != nil && !errors.Is(err, domains.ErrDomainNotFound) {
+		return nil, c.fail(errors.Wrap(err, "fail to get custom domain"))
+	}
+	if domain != nil && domain.IsVerified() {
+		c.domain = domain
+	}
+
 	loadErgopackResult, err := c.loadErgopack(ctx, namespace)
 	if err != nil {
 		return nil, c.fail(errors.Wrap(err, "fail to load ergopack"))
@@ -244,11 +286,31 @@ func (c *gitCompose) Transform(ctx context.Context, id uuid.UUID) (*TransformRes
 	namespace := id.String()
 	result := &TransformResult{IsCompose: c.isCompose}
 
-	err := c.saveServices(ctx, id, c.environment)
+	validationErr, err := c.saveServices(ctx, id, c.environment)
 	if err != nil {
 		return nil, c.fail(errors.Wrap(err, "fail to save services"))
 	}
 
+	if validationErr != nil {
+		reason, err := json.Marshal(validationErr)
+		if err != nil {
+			return nil, c.fail(errors.Wrap(err, "fail to marshal validation error"))
+		}
+
+		err = c.db.Model(&c.dbEnvironment).Update("degraded_reason", reason).Error
+		if err != nil {
+			return nil, c.fail(errors.Wrap(err, "fail to save degraded reason to db"))
+		}
+
+		result.ValidationError = validationErr
+		return result, c.fail(nil)
+	}
+
+	err = c.allocateEndpoints(ctx, id)
+	if err != nil {
+		return nil, c.fail(errors.Wrap(err, "fail to allocate endpoints"))
+	}
+
 	buildImagesRes, err := c.buildImages(ctx, namespace)
 	if err != nil {
 		return nil, c.fail(errors.Wrap(err, "fail to build images"))
@@ -256,9 +318,16 @@ func (c *gitCompose) Transform(ctx context.Context, id uuid.UUID) (*TransformRes
 
 	if buildImagesRes.Failed() {
 		result.FailedJobs = buildImagesRes.FailedJobs
+		result.Environment = c.environment
 		return result, c.fail(nil)
 	}
 
+	accessSettings, err := c.accessProvider.GetSettings(ctx, c.owner, c.repo)
+	if err != nil {
+		return nil, c.fail(errors.Wrap(err, "fail to get preview access settings"))
+	}
+	c.accessMode = accessSettings.Mode
+
 	var objects []runtime.Object
 	if c.isCompose {
 		objs, err := c.transformCompose(ctx, namespace)
@@ -284,16 +353,12 @@ func (c *gitCompose) Transform(ctx context.Context, id uuid.UUID) (*TransformRes
 		Objects:   objects,
 	}
 	result.Environment = c.environment
+	result.Endpoints = c.endpoints
 
 	return result, nil
 }
 
 func (c *gitCompose) makeClusterObjects(ctx context.Context, namespace string) ([]runtime.Object, error) {
-	vars, err := c.envVarsProvider.ListByRepoBranch(ctx, c.owner, c.repo, c.branch)
-	if err != nil {
-		return nil, errors.Wrap(err, "fail to list env vars by repo")
-	}
-
 	secret, err := c.clusterClient.CopySecret(ctx, "kpack", namespace, "kpack-registry-credentials")
 	if err != nil {
 		return nil, errors.Wrap(err, "fail to copy registry credentials")
@@ -301,36 +366,26 @@ func (c *gitCompose) makeClusterObjects(ctx context.Context, namespace string) (
 
 	objs := []runtime.Object{secret}
 
-	envVarsSecret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "env-vars",
-			Namespace: namespace,
-		},
-		StringData: map[string]string{},
+	if tlsSecret := domains.NewTLSSecret(namespace, c.domain); tlsSecret != nil {
+		objs = append(objs, tlsSecret)
 	}
-	env := []corev1.EnvVar{}
-
-	dbVars := make(map[string]struct{})
-	for _, v := range vars {
-		envVarsSecret.StringData[v.Name] = v.Value
 
-		env = append(env, corev1.EnvVar{
-			Name: v.Name,
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: "env-vars",
-					},
-					Key: v.Name,
-				},
-			},
-		})
-		dbVars[v.Name] = struct{}{}
+	if endpointsSecret := c.endpointsSecret(namespace); endpointsSecret != nil {
+		objs = append(objs, endpointsSecret)
 	}
 
-	objs = append(objs, envVarsSecret)
-
 	for serviceName, envService := range c.environment.Services {
+		envVarsSecret, env, err := c.makeEnvVars(ctx, c.repo, serviceName, envService.ID, namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to make env vars of service %s", serviceName)
+		}
+		objs = append(objs, envVarsSecret)
+
+		dbVars := make(map[string]struct{}, len(env))
+		for _, v := range env {
+			dbVars[v.Name] = struct{}{}
+		}
+
 		for k, v := range envService.Env {
 			if _, ok := dbVars[k]; ok {
 				continue
@@ -344,7 +399,15 @@ func (c *gitCompose) makeClusterObjects(ctx context.Context, namespace string) (
 		containerPorts := []corev1.ContainerPort{}
 		servicePorts := []corev1.ServicePort{}
 		ports := map[int]struct{}{}
-		for _, strPort := range append(envService.InternalPorts, envService.PublicPort) {
+		strPorts := append([]string{}, envService.InternalPorts...)
+		udpPorts := map[int]struct{}{}
+		for _, route := range envService.Routes {
+			strPorts = append(strPorts, strconv.Itoa(route.Port))
+			if route.Protocol == endpoints.ProtocolUDP {
+				udpPorts[route.Port] = struct{}{}
+			}
+		}
+		for _, strPort := range strPorts {
 			if strPort == "" {
 				continue
 			}
@@ -360,12 +423,21 @@ func (c *gitCompose) makeClusterObjects(ctx context.Context, namespace string) (
 			if _, ok := ports[port]; ok {
 				continue
 			}
+			ports[port] = struct{}{}
+
+			protocol := corev1.ProtocolTCP
+			if _, ok := udpPorts[port]; ok {
+				protocol = corev1.ProtocolUDP
+			}
 
 			containerPorts = append(containerPorts, corev1.ContainerPort{
 				ContainerPort: int32(port),
+				Protocol:      protocol,
 			})
 			servicePorts = append(servicePorts, corev1.ServicePort{
-				Port: int32(port),
+				Name:     fmt.Sprintf("%s-%d", strings.ToLower(string(protocol)), port),
+				Protocol: protocol,
+				Port:     int32(port),
 				TargetPort: intstr.IntOrString{
 					Type:   intstr.Int,
 					IntVal: int32(port),
@@ -377,7 +449,7 @@ func (c *gitCompose) makeClusterObjects(ctx context.Context, namespace string) (
 			Name:  serviceName,
 			Image: envService.Image,
 			Ports: containerPorts,
-			Env:   env,
+			Env:   append(c.endpointsEnv(serviceName), env...),
 			Resources: corev1.ResourceRequirements{
 				Limits: corev1.ResourceList{
 					corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
@@ -452,50 +524,9 @@ func (c *gitCompose) makeClusterObjects(ctx context.Context, namespace string) (
 		}
 		objs = append(objs, service)
 
-		if envService.PublicPort != "" {
-			port, err := strconv.Atoi(envService.PublicPort)
-			if err != nil {
-				logger.Ctx(ctx).Warn().AnErr("err", err).Str("strPort", envService.PublicPort).
-					Msg("fail to convert PublicPort to int")
-				continue
-			}
-
-			pathType := networkingv1.PathTypePrefix
-			ingress := &networkingv1.Ingress{
-				TypeMeta: metav1.TypeMeta{},
-				ObjectMeta: metav1.ObjectMeta{
-					Name:        serviceName,
-					Namespace:   namespace,
-					Labels:      labels,
-					Annotations: labels,
-				},
-				Spec: networkingv1.IngressSpec{
-					IngressClassName: pointer.String("nginx"),
-					Rules: []networkingv1.IngressRule{
-						{
-							Host: envService.Url,
-							IngressRuleValue: networkingv1.IngressRuleValue{
-								HTTP: &networkingv1.HTTPIngressRuleValue{
-									Paths: []networkingv1.HTTPIngressPath{
-										{
-											Path:     "/",
-											PathType: &pathType,
-											Backend: networkingv1.IngressBackend{
-												Service: &networkingv1.IngressServiceBackend{
-													Name: serviceName,
-													Port: networkingv1.ServiceBackendPort{
-														Number: int32(port),
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			}
+		for _, ingress := range makeIngresses(serviceName, namespace, labels, envService.Routes) {
+			previewaccess.ApplyToIngress(ingress, c.accessMode, previewAuthURL, previewAuthSigninURL)
+			domains.ApplyTLS(ingress, c.domain)
 			objs = append(objs, ingress)
 		}
 	}
@@ -503,7 +534,13 @@ func (c *gitCompose) makeClusterObjects(ctx context.Context, namespace string) (
 	return objs, nil
 }
 
-func (c *gitCompose) saveServices(ctx context.Context, envID uuid.UUID, compose *Environment) error {
+// saveServices claims the urls of the services as it saves them, another
+// environment may have taken one of them since validateURLs ran in Prepare
+func (c *gitCompose) saveServices(
+	ctx context.Context,
+	envID uuid.UUID,
+	compose *Environment,
+) (*ProjectValidationError, error) {
 	var services []database.Service
 	for name, service := range compose.Services {
 		buildStatus := "image"
@@ -516,6 +553,7 @@ func (c *gitCompose) saveServices(ctx context.Context, envID uuid.UUID, compose
 			Name:          name,
 			EnvironmentID: envID,
 			Url:           service.Url,
+			Hosts:         service.Hosts(),
 			Build:         service.Build,
 			BuildStatus:   buildStatus,
 			Image:         service.Image,
@@ -526,33 +564,63 @@ func (c *gitCompose) saveServices(ctx context.Context, envID uuid.UUID, compose
 	}
 
 	if len(services) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	return c.db.Create(&services).Error
+	var validationErr *ProjectValidationError
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		hosts := environmentHosts(compose)
+		err := lockHosts(tx, hosts)
+		if err != nil {
+			return err
+		}
+
+		conflict, err := c.findURLConflict(tx, hosts)
+		if err != nil {
+			return err
+		}
+
+		if conflict != nil {
+			validationErr = conflict.validationError()
+			return nil
+		}
+
+		return tx.Create(&services).Error
+	})
+
+	return validationErr, err
 }
 
 // returns empty when service should not be exposed
 func (c *gitCompose) getUrl(service kobject.ServiceConfig) string {
-	for _, port := range service.Port {
-		suffix := c.branch
-		if c.prNumber != nil {
-			suffix = strconv.Itoa(*c.prNumber)
-		}
+	return primaryURL(c.resolveRoutes(service.Name, composeRouteSpecs(service)))
+}
 
-		if port.HostPort > 0 {
-			return strings.ToLower(fmt.Sprintf(
-				"%s-%s-%s-%s.%s",
-				service.Name,
-				c.owner,
-				strings.ReplaceAll(c.repo, "_", ""),
-				suffix,
-				clusterDomain,
-			))
+// serviceHost returns the host a public service is exposed at, under the custom
+// domain of the owner when it has a verified one
+func (c *gitCompose) serviceHost(service string) string {
+	if c.domain != nil {
+		host, err := c.domain.Host(domains.URLParams{
+			Service:  service,
+			Owner:    c.owner,
+			Repo:     c.repo,
+			Branch:   c.branch,
+			PrNumber: c.prNumber,
+		})
+		if err == nil {
+			return host
 		}
+
+		logger.Get().Warn().AnErr("err", err).Str("owner", c.owner).Str("service", service).
+			Msg("fail to render custom domain url template, falling back to cluster domain")
+	}
+
+	suffix := c.branch
+	if c.prNumber != nil {
+		suffix = strconv.Itoa(*c.prNumber)
 	}
 
-	return ""
+	return fmt.Sprintf("%s.%s", slug.Label(service, c.owner, c.repo, suffix), clusterDomain)
 }
 
 func (c *gitCompose) fixComposeObject(projectPath, namespace string) error {
@@ -567,12 +635,10 @@ func (c *gitCompose) fixComposeObject(projectPath, namespace string) error {
 			service.Build = strings.Replace(service.Build, projectPath, "", 1)
 		}
 
-		service.ExposeService = c.getUrl(service)
-		if service.ExposeService != "" {
-			service.ExposeServiceIngressClassName = "nginx"
-		}
+		// ingresses are made out of the routes of the environment in fixOutput
+		service.ExposeService = ""
 
-		err := evaluateLabels(&service, c.environment)
+		err := evaluateLabels(&service, c.templateContext)
 		if err != nil {
 			return errors.Wrap(err, "fail to evaluate ergomake specific labels")
 		}
@@ -669,6 +735,7 @@ func (c *gitCompose) loadErgopack(ctx context.Context, namespace string) (*LoadE
 			komposeObject.ServiceConfigs,
 			configStr,
 		)
+		c.templateContext = c.makeTemplateContext(namespace)
 
 		err = c.fixComposeObject(projectPath, namespace)
 		if err != nil {
@@ -678,26 +745,53 @@ func (c *gitCompose) loadErgopack(ctx context.Context, namespace string) (*LoadE
 		var pack ergopack.Ergopack
 		err := yaml.Unmarshal(configBytes, &pack)
 		if err != nil {
-			relativePath, err := filepath.Rel(projectPath, c.configFilePath)
-			if err != nil {
-				relativePath = c.configFilePath
-			}
-
 			return &LoadErgopackResult{
 				Skip: false,
 				ValidationError: &ProjectValidationError{
 					T:       "invalid-ergopack",
-					Message: fmt.Sprintf("Ergopack file has syntax error\n```\n%s: %s\n```", relativePath, err.Error()),
+					Message: fmt.Sprintf("Ergopack file has syntax error\n```\n%s: %s\n```", c.relativeConfigFilePath(), err.Error()),
+					Path:    c.relativeConfigFilePath(),
+					Line:    yamlErrorLine(err),
 				},
 			}, nil
 		}
 
-		c.environment = c.makeEnvironmentFromErgopack(ctx, &pack, string(configBytes))
+		c.environment = c.makeEnvironmentFromErgopack(&pack, string(configBytes))
+		c.templateContext = c.makeTemplateContext(namespace)
+	}
+
+	c.environment.ConfigPath = c.relativeConfigFilePath()
+
+	validationErr, err = c.validateURLs(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to validate urls")
+	}
+
+	if validationErr != nil {
+		return &LoadErgopackResult{Skip: false, ValidationError: validationErr}, nil
+	}
+
+	validationErr, err = c.renderEnvTemplates(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to render env templates")
+	}
+
+	if validationErr != nil {
+		return &LoadErgopackResult{Skip: false, ValidationError: validationErr}, nil
 	}
 
 	return &LoadErgopackResult{}, nil
 }
 
+func (c *gitCompose) relativeConfigFilePath() string {
+	relativePath, err := filepath.Rel(c.projectPath, c.configFilePath)
+	if err != nil {
+		return c.configFilePath
+	}
+
+	return relativePath
+}
+
 func (c *gitCompose) transformCompose(ctx context.Context, namespace string) ([]runtime.Object, error) {
 	// Create the options for the conversion to Kubernetes objects.
 	convertOptions := kobject.ConvertOptions{
@@ -747,37 +841,24 @@ func (c *gitCompose) cloneRepo(ctx context.Context, namespace string) (string, e
 func (c *gitCompose) makeEnvironmentFromKObjectServices(komposeServices map[string]kobject.ServiceConfig, rawCompose string) *Environment {
 	services := map[string]EnvironmentService{}
 	for _, service := range komposeServices {
+		routes := c.resolveRoutes(service.Name, composeRouteSpecs(service))
 		services[service.Name] = EnvironmentService{
-			ID:    uuid.NewString(),
-			Url:   c.getUrl(service),
-			Image: service.Image,
-			Build: service.Build,
+			ID:     uuid.NewString(),
+			Url:    primaryURL(routes),
+			Routes: routes,
+			Image:  service.Image,
+			Build:  service.Build,
 		}
 	}
 
 	return NewEnvironment(services, rawCompose)
 }
 
-func (c *gitCompose) makeEnvironmentFromErgopack(ctx context.Context, pack *ergopack.Ergopack, rawFile string) *Environment {
+func (c *gitCompose) makeEnvironmentFromErgopack(pack *ergopack.Ergopack, rawFile string) *Environment {
 	services := map[string]EnvironmentService{}
 	i := 0
 	for name, service := range pack.Apps {
-		url := ""
-		if service.PublicPort != "" {
-			suffix := c.branch
-			if c.prNumber != nil {
-				suffix = strconv.Itoa(*c.prNumber)
-			}
-
-			url = strings.ToLower(fmt.Sprintf(
-				"%s-%s-%s-%s.%s",
-				name,
-				c.owner,
-				strings.ReplaceAll(c.repo, "_", ""),
-				suffix,
-				clusterDomain,
-			))
-		}
+		routes := c.resolveRoutes(name, ergopackRouteSpecs(service))
 
 		id := uuid.NewString()
 		image := service.Image
@@ -787,7 +868,8 @@ func (c *gitCompose) makeEnvironmentFromErgopack(ctx context.Context, pack *ergo
 
 		services[name] = EnvironmentService{
 			ID:            id,
-			Url:           url,
+			Url:           primaryURL(routes),
+			Routes:        routes,
 			Image:         image,
 			Build:         service.Path,
 			PublicPort:    service.PublicPort,
@@ -798,38 +880,16 @@ func (c *gitCompose) makeEnvironmentFromErgopack(ctx context.Context, pack *ergo
 		i += 1
 	}
 
-	env := NewEnvironment(services, rawFile)
-
-	mustache.AllowMissingVariables = false
-	templateContext := env.ToMap()
-
-	for name, service := range env.Services {
-		for k, v := range service.Env {
-			newV, err := mustache.Render(v, templateContext)
-			if err != nil {
-				logger.Ctx(ctx).Err(err).Str("var", k).Msg("fail to render env var")
-			}
-
-			service.Env[k] = newV
-		}
-
-		env.Services[name] = service
-	}
-
-	return env
+	return NewEnvironment(services, rawFile)
 }
 
-func evaluateLabels(service *kobject.ServiceConfig, env *Environment) error {
-	mustache.AllowMissingVariables = false
-
-	templateContext := env.ToMap()
-
+func evaluateLabels(service *kobject.ServiceConfig, templateContext map[string]interface{}) error {
 	for label, value := range service.Labels {
 		replaceArgLabel := "dev.ergomake.env.replace-arg."
 
 		if strings.HasPrefix(label, replaceArgLabel) {
 			varName := strings.TrimPrefix(label, replaceArgLabel)
-			replacedValue, err := mustache.Render(value, templateContext)
+			replacedValue, err := renderTemplate(value, templateContext)
 			if err != nil {
 				return errors.Wrapf(
 					err,
@@ -852,6 +912,9 @@ func evaluateLabels(service *kobject.ServiceConfig, env *Environment) error {
 
 func (c *gitCompose) fixOutput(ctx context.Context, objs *[]runtime.Object, namespace string) ([]runtime.Object, error) {
 	extraObjs := []runtime.Object{}
+	if tlsSecret := domains.NewTLSSecret(namespace, c.domain); tlsSecret != nil {
+		extraObjs = append(extraObjs, tlsSecret)
+	}
 
 	for _, obj := range *objs {
 		c.fixNamespace(obj, namespace)
@@ -871,6 +934,18 @@ func (c *gitCompose) fixOutput(ctx context.Context, objs *[]runtime.Object, name
 		}
 	}
 
+	if endpointsSecret := c.endpointsSecret(namespace); endpointsSecret != nil {
+		extraObjs = append(extraObjs, endpointsSecret)
+	}
+
+	for name, envService := range c.environment.Services {
+		for _, ingress := range makeIngresses(name, namespace, c.getLabels(envService.ID, name), envService.Routes) {
+			previewaccess.ApplyToIngress(ingress, c.accessMode, previewAuthURL, previewAuthSigninURL)
+			domains.ApplyTLS(ingress, c.domain)
+			extraObjs = append(extraObjs, ingress)
+		}
+	}
+
 	return extraObjs, nil
 }
 
@@ -889,6 +964,7 @@ func (c *gitCompose) fixDeployment(ctx context.Context, obj runtime.Object) ([]r
 	c.fixPullPolicy(deployment)
 	c.addResourceLimits(deployment)
 	c.removeHostPort(deployment)
+	c.addEndpointsEnv(deployment)
 
 	envVarsSecret, err := c.addEnvVars(ctx, deployment)
 	if err != nil {
@@ -901,6 +977,18 @@ func (c *gitCompose) fixDeployment(ctx context.Context, obj runtime.Object) ([]r
 	return extraObjs, nil
 }
 
+func (c *gitCompose) addEndpointsEnv(deployment *appsv1.Deployment) {
+	env := c.endpointsEnv(deployment.GetLabels()["io.kompose.service"])
+	if len(env) == 0 {
+		return
+	}
+
+	podSpec := &deployment.Spec.Template.Spec
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = append(append([]corev1.EnvVar{}, env...), podSpec.Containers[i].Env...)
+	}
+}
+
 func (c *gitCompose) fixNamespace(obj runtime.Object, namespace string) {
 	objMeta := obj.(metav1.Object)
 	objMeta.SetNamespace(namespace)
@@ -1013,18 +1101,48 @@ func (c *gitCompose) addLabels(deployment *appsv1.Deployment) {
 }
 
 func (c *gitCompose) addEnvVars(ctx context.Context, deployment *appsv1.Deployment) (*corev1.Secret, error) {
-	service := c.environment.Services[deployment.GetLabels()["io.kompose.service"]]
+	serviceName := deployment.GetLabels()["io.kompose.service"]
+	service := c.environment.Services[serviceName]
 	repo, _ := c.computeRepoAndBuildPath(service.Build, c.repo)
 
-	vars, err := c.envVarsProvider.ListByRepoBranch(ctx, c.owner, repo, c.branch)
+	secret, envVars, err := c.makeEnvVars(ctx, repo, serviceName, service.ID, deployment.GetNamespace())
 	if err != nil {
-		return nil, errors.Wrap(err, "fail to list env vars by repo")
+		return nil, err
+	}
+
+	podSpec := &deployment.Spec.Template.Spec
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, envVars...)
+	}
+
+	return secret, nil
+}
+
+// envVarsTarget is what the variables of service are listed for
+func (c *gitCompose) envVarsTarget(branch, service string) envvars.Target {
+	environmentType := envvars.EnvironmentTypePermanentBranch
+	if c.prNumber != nil {
+		environmentType = envvars.EnvironmentTypePullRequest
+	}
+
+	return envvars.Target{Branch: branch, Service: service, EnvironmentType: environmentType}
+}
+
+// makeEnvVars returns a secret with the variables that apply to service and
+// the container env that reads them from it
+func (c *gitCompose) makeEnvVars(
+	ctx context.Context,
+	repo, serviceName, serviceID, namespace string,
+) (*corev1.Secret, []corev1.EnvVar, error) {
+	vars, err := c.listEnvVars(ctx, repo, c.branch, serviceName)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-env-vars-secret", service.ID),
-			Namespace: deployment.GetNamespace(),
+			Name:      fmt.Sprintf("%s-env-vars-secret", serviceID),
+			Namespace: namespace,
 		},
 		Data: map[string][]byte{},
 	}
@@ -1046,12 +1164,7 @@ func (c *gitCompose) addEnvVars(ctx context.Context, deployment *appsv1.Deployme
 		})
 	}
 
-	podSpec := &deployment.Spec.Template.Spec
-	for i := range podSpec.Containers {
-		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, envVars...)
-	}
-
-	return secret, nil
+	return secret, envVars, nil
 }
 
 func (c *gitCompose) getSecretForImage(
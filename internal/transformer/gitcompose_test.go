@@ -25,10 +25,16 @@ import (
 	"github.com/ergomake/ergomake/e2e/testutils"
 	"github.com/ergomake/ergomake/internal/cluster"
 	"github.com/ergomake/ergomake/internal/database"
+	"github.com/ergomake/ergomake/internal/domains"
+	"github.com/ergomake/ergomake/internal/endpoints"
+	"github.com/ergomake/ergomake/internal/previewaccess"
 	"github.com/ergomake/ergomake/internal/privregistry"
 	clusterMock "github.com/ergomake/ergomake/mocks/cluster"
+	domainsMocks "github.com/ergomake/ergomake/mocks/domains"
+	endpointsMocks "github.com/ergomake/ergomake/mocks/endpoints"
 	envvarsMocks "github.com/ergomake/ergomake/mocks/envvars"
 	gitMock "github.com/ergomake/ergomake/mocks/git"
+	previewaccessMocks "github.com/ergomake/ergomake/mocks/previewaccess"
 	privregistryMock "github.com/ergomake/ergomake/mocks/privregistry"
 )
 
@@ -51,10 +57,16 @@ func TestGitCompose_Prepare(t *testing.T) {
 				gitClient.EXPECT().CloneRepo(ctx, "owner", "repo", "branch", mock.AnythingOfType("string"), true).
 					Return(errors.New("rip"))
 
+				domainsProvider := domainsMocks.NewDomainsProvider(t)
+				domainsProvider.EXPECT().Get(mock.Anything, "owner").Return(nil, domains.ErrDomainNotFound)
+
 				return NewGitCompose(
 					clusterClient, gitClient, db,
 					envvarsMocks.NewEnvVarsProvider(t),
 					privregistryMock.NewPrivRegistryProvider(t),
+					previewaccessMocks.NewAccessProvider(t),
+					domainsProvider,
+					endpointsMocks.NewEndpointsProvider(t),
 					"owner", "owner", "repo", "branch", "sha", pointer.Int(1337), "author", true, "hub-secret",
 				)
 			},
@@ -124,15 +136,20 @@ func TestGitCompose_Transform(t *testing.T) {
 				require.NoError(t, err)
 
 				envVarsProvider := envvarsMocks.NewEnvVarsProvider(t)
-				envVarsProvider.EXPECT().ListByRepoBranch(mock.Anything, "owner", "repo", "branch").Return(nil, nil)
+				envVarsProvider.EXPECT().ListEffective(mock.Anything, "owner", "repo", mock.Anything).Return(nil, nil)
 
 				privRegistryProvider := privregistryMock.NewPrivRegistryProvider(t)
 				privRegistryProvider.EXPECT().FetchCreds(mock.Anything, "owner", "mongo").Return(nil, privregistry.ErrRegistryNotFound)
 				privRegistryProvider.EXPECT().FetchCreds(mock.Anything, "owner", "willbuild").Return(nil, privregistry.ErrRegistryNotFound)
 
+				accessProvider := previewaccessMocks.NewAccessProvider(t)
+				accessProvider.EXPECT().GetSettings(mock.Anything, "owner", "repo").
+					Return(&previewaccess.Settings{Mode: previewaccess.ModePublic}, nil)
+
 				gc := NewGitCompose(
 					clusterClient, gitClient, db, envVarsProvider,
-					privRegistryProvider,
+					privRegistryProvider, accessProvider, domainsMocks.NewDomainsProvider(t),
+					endpointsMocks.NewEndpointsProvider(t),
 					"owner", "owner", "repo", "branch", "sha", pointer.Int(1337), "author", false, "hub-secret",
 				)
 				gc.komposeObject = &kobject.KomposeObject{
@@ -207,6 +224,9 @@ services:
 					clusterClient, gitClient, &database.DB{},
 					envvarsMocks.NewEnvVarsProvider(t),
 					privregistryMock.NewPrivRegistryProvider(t),
+					previewaccessMocks.NewAccessProvider(t),
+					domainsMocks.NewDomainsProvider(t),
+					endpointsMocks.NewEndpointsProvider(t),
 					"owner", "owner", repo, "branch", "sha", pointer.Int(1337), "author", true, "hub-secret",
 				)
 			},
@@ -296,6 +316,9 @@ services:
 					Build: "path/to/build",
 					Image: "",
 					Url:   "service1-owner-repo-1337.env.ergomake.test",
+					Routes: []Route{
+						{Host: "service1-owner-repo-1337.env.ergomake.test", Path: "/", Port: 8080, Protocol: endpoints.ProtocolHTTP},
+					},
 					Index: 1,
 				},
 				"service2": {
@@ -320,6 +343,9 @@ services:
 				clusterClient, gitClient, &database.DB{},
 				envvarsMocks.NewEnvVarsProvider(t),
 				privregistryMock.NewPrivRegistryProvider(t),
+				previewaccessMocks.NewAccessProvider(t),
+				domainsMocks.NewDomainsProvider(t),
+				endpointsMocks.NewEndpointsProvider(t),
 				"owner", "owner", "repo", "branch", "sha", pointer.Int(1337), "author", true, "hub-secret",
 			)
 			env := gc.makeEnvironmentFromKObjectServices(tc.services, tc.rawCompose)
@@ -475,3 +501,21 @@ func TestGitCompose_getUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestGitCompose_serviceHost(t *testing.T) {
+	t.Parallel()
+
+	branchURL := func(repo, branch string) string {
+		c := &gitCompose{owner: "myowner", repo: repo, branch: branch}
+		return c.serviceHost("web")
+	}
+
+	assert.Equal(t, fmt.Sprintf("web-myowner-my-repo-feat-x.%s", clusterDomain), branchURL("my_repo", "feat/x"))
+	assert.NotEqual(t, branchURL("my_repo", "main"), branchURL("myrepo", "main"))
+
+	long := strings.Repeat("a-very-long-branch-name/", 4)
+	host := branchURL("myrepo", long+"one")
+	label := strings.TrimSuffix(host, "."+clusterDomain)
+	assert.LessOrEqual(t, len(label), 63)
+	assert.NotEqual(t, host, branchURL("myrepo", long+"two"))
+}
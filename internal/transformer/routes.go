@@ -0,0 +1,323 @@
+package transformer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes/kompose/pkg/kobject"
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/ergomake/ergomake/internal/endpoints"
+	"github.com/ergomake/ergomake/internal/ergopack"
+	"github.com/ergomake/ergomake/internal/slug"
+)
+
+// routeLabelPrefix is the compose label that declares routes, as in
+// dev.ergomake.route.api: "port=8080,path=/api,host=web,protocol=grpc"
+const routeLabelPrefix = "dev.ergomake.route."
+
+// Route is a port of a service that is public at a path of a hostname,
+// stream routes have neither since they go through the stream proxy
+type Route struct {
+	Host     string
+	Path     string
+	Port     int
+	Protocol endpoints.Protocol
+}
+
+// routeSpec is a route as written by users, Host is a name that turns into a hostname
+type routeSpec struct {
+	Port     string
+	Path     string
+	Host     string
+	Protocol string
+}
+
+func (s routeSpec) protocol() endpoints.Protocol {
+	if s.Protocol == "" {
+		return endpoints.ProtocolHTTP
+	}
+
+	return endpoints.Protocol(strings.ToLower(s.Protocol))
+}
+
+func (s routeSpec) validate() error {
+	port, err := strconv.Atoi(s.Port)
+	if err != nil || port < 1 || port > 65535 {
+		return errors.Errorf("`%s` is not a valid port", s.Port)
+	}
+
+	if !s.protocol().IsValid() {
+		return errors.Errorf("protocol `%s` is not one of http, grpc, tcp or udp", s.Protocol)
+	}
+
+	if s.protocol().IsStream() && (s.Path != "" || s.Host != "") {
+		return errors.Errorf("%s routes can't have a path or host", s.protocol())
+	}
+
+	if s.Path != "" && !strings.HasPrefix(s.Path, "/") {
+		return errors.Errorf("path `%s` must start with `/`", s.Path)
+	}
+
+	if strings.ContainsAny(s.Path, " ?#") {
+		return errors.Errorf("path `%s` must not have spaces, query or fragment", s.Path)
+	}
+
+	if s.Host != "" && slug.Slugify(s.Host) == "" {
+		return errors.Errorf("host `%s` has no valid hostname characters", s.Host)
+	}
+
+	return nil
+}
+
+// ergopackRouteSpecs returns the routes of an ergopack app, publicPort being
+// the same as a route to / of the app own host
+func ergopackRouteSpecs(app ergopack.ErgopackApp) []routeSpec {
+	specs := []routeSpec{}
+	if app.PublicPort != "" {
+		specs = append(specs, routeSpec{Port: app.PublicPort})
+	}
+
+	for _, route := range app.Routes {
+		specs = append(specs, routeSpec(route))
+	}
+
+	return specs
+}
+
+// parseRouteLabels reads the routes declared through compose labels, sorted by
+// route name, it returns nil when there are none
+func parseRouteLabels(labels map[string]string) ([]routeSpec, error) {
+	names := []string{}
+	for label := range labels {
+		if strings.HasPrefix(label, routeLabelPrefix) {
+			names = append(names, strings.TrimPrefix(label, routeLabelPrefix))
+		}
+	}
+	sort.Strings(names)
+
+	var specs []routeSpec
+	for _, name := range names {
+		var spec routeSpec
+		for _, field := range strings.Split(labels[routeLabelPrefix+name], ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+			if !ok {
+				return nil, errors.Errorf("route `%s` has malformed field `%s`, expected key=value", name, field)
+			}
+
+			switch strings.TrimSpace(key) {
+			case "port":
+				spec.Port = strings.TrimSpace(value)
+			case "path":
+				spec.Path = strings.TrimSpace(value)
+			case "host":
+				spec.Host = strings.TrimSpace(value)
+			case "protocol":
+				spec.Protocol = strings.TrimSpace(value)
+			default:
+				return nil, errors.Errorf("route `%s` has unknown field `%s`", name, key)
+			}
+		}
+
+		err := spec.validate()
+		if err != nil {
+			return nil, errors.Wrapf(err, "route `%s` is invalid", name)
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+// composeRouteSpecs returns the routes declared through labels or, when there
+// are none, one route for each published port. The first one is exposed at
+// the service host and the others at a host suffixed with the port.
+func composeRouteSpecs(service kobject.ServiceConfig) []routeSpec {
+	specs, err := parseRouteLabels(service.Labels)
+	if err == nil && specs != nil {
+		return specs
+	}
+
+	specs = []routeSpec{}
+	seen := map[int32]struct{}{}
+	for _, port := range service.Port {
+		if port.HostPort <= 0 {
+			continue
+		}
+
+		if _, ok := seen[port.HostPort]; ok {
+			continue
+		}
+		seen[port.HostPort] = struct{}{}
+
+		host := ""
+		if len(specs) > 0 {
+			host = fmt.Sprintf("%s-%d", service.Name, port.HostPort)
+		}
+
+		specs = append(specs, routeSpec{Port: strconv.Itoa(int(port.HostPort)), Host: host})
+	}
+
+	return specs
+}
+
+// resolveRoutes turns the routes of service into hostnames, invalid routes are
+// skipped as they are reported when validating the project
+func (c *gitCompose) resolveRoutes(service string, specs []routeSpec) []Route {
+	var routes []Route
+	for _, spec := range specs {
+		if spec.validate() != nil {
+			continue
+		}
+
+		port, _ := strconv.Atoi(spec.Port)
+
+		if spec.protocol().IsStream() {
+			routes = append(routes, Route{Port: port, Protocol: spec.protocol()})
+			continue
+		}
+
+		host := spec.Host
+		if host == "" {
+			host = service
+		}
+
+		path := spec.Path
+		if path == "" {
+			path = "/"
+		}
+
+		routes = append(routes, Route{Host: c.serviceHost(host), Path: path, Port: port, Protocol: spec.protocol()})
+	}
+
+	return routes
+}
+
+// primaryURL is the host where a service is served at the root, services that
+// only have routes under paths of other hosts have no url of their own
+func primaryURL(routes []Route) string {
+	for _, route := range routes {
+		if route.Path == "/" && route.Protocol == endpoints.ProtocolHTTP {
+			return route.Host
+		}
+	}
+
+	return ""
+}
+
+// Hosts returns every hostname the service is reachable at
+func (s EnvironmentService) Hosts() []string {
+	hosts := []string{}
+	seen := map[string]struct{}{}
+	if s.Url != "" {
+		hosts = append(hosts, s.Url)
+		seen[s.Url] = struct{}{}
+	}
+
+	for _, route := range s.Routes {
+		if route.Host == "" {
+			continue
+		}
+
+		if _, ok := seen[route.Host]; ok {
+			continue
+		}
+		seen[route.Host] = struct{}{}
+		hosts = append(hosts, route.Host)
+	}
+
+	return hosts
+}
+
+// makeIngresses returns the ingresses for the http and grpc routes of a
+// service, grpc ones need their own since the backend protocol is set per
+// ingress. Stream routes are left to the stream proxy.
+func makeIngresses(name, namespace string, labels map[string]string, routes []Route) []*networkingv1.Ingress {
+	var httpRoutes, grpcRoutes []Route
+	for _, route := range routes {
+		switch route.Protocol {
+		case endpoints.ProtocolHTTP:
+			httpRoutes = append(httpRoutes, route)
+		case endpoints.ProtocolGRPC:
+			grpcRoutes = append(grpcRoutes, route)
+		}
+	}
+
+	ingresses := []*networkingv1.Ingress{}
+	if len(httpRoutes) > 0 {
+		ingresses = append(ingresses, makeIngress(name, name, namespace, labels, httpRoutes))
+	}
+
+	if len(grpcRoutes) > 0 {
+		ingress := makeIngress(name+"-grpc", name, namespace, labels, grpcRoutes)
+
+		// annotations are often the same map as the labels, so never change it in place
+		annotations := make(map[string]string, len(labels)+1)
+		for k, v := range labels {
+			annotations[k] = v
+		}
+		annotations[backendProtocolAnnotation] = "GRPC"
+		ingress.Annotations = annotations
+
+		ingresses = append(ingresses, ingress)
+	}
+
+	return ingresses
+}
+
+const backendProtocolAnnotation = "nginx.ingress.kubernetes.io/backend-protocol"
+
+// makeIngress returns an ingress with routes to service, one rule per hostname.
+// ingress-nginx merges rules of the same host across ingresses, which is how
+// several services share a hostname under different paths.
+func makeIngress(name, service, namespace string, labels map[string]string, routes []Route) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+
+	rules := []networkingv1.IngressRule{}
+	ruleIndex := map[string]int{}
+	for _, route := range routes {
+		i, ok := ruleIndex[route.Host]
+		if !ok {
+			i = len(rules)
+			ruleIndex[route.Host] = i
+			rules = append(rules, networkingv1.IngressRule{
+				Host: route.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{},
+				},
+			})
+		}
+
+		rules[i].HTTP.Paths = append(rules[i].HTTP.Paths, networkingv1.HTTPIngressPath{
+			Path:     route.Path,
+			PathType: &pathType,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: service,
+					Port: networkingv1.ServiceBackendPort{
+						Number: int32(route.Port),
+					},
+				},
+			},
+		})
+	}
+
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: labels,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: pointer.String("nginx"),
+			Rules:            rules,
+		},
+	}
+}
@@ -0,0 +1,211 @@
+package transformer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/kubernetes/kompose/pkg/kobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ergomake/ergomake/internal/endpoints"
+	"github.com/ergomake/ergomake/internal/ergopack"
+)
+
+func TestParseRouteLabels(t *testing.T) {
+	t.Parallel()
+
+	specs, err := parseRouteLabels(map[string]string{"other": "label"})
+	require.NoError(t, err)
+	assert.Nil(t, specs)
+
+	specs, err = parseRouteLabels(map[string]string{
+		"dev.ergomake.route.b-debug": "port=9229, host=api-debug",
+		"dev.ergomake.route.a-api":   "port=8080,path=/api,host=web",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []routeSpec{
+		{Port: "8080", Path: "/api", Host: "web"},
+		{Port: "9229", Host: "api-debug"},
+	}, specs)
+
+	for _, value := range []string{"port=abc", "8080", "port=80,path=api", "port=80,weight=2"} {
+		_, err := parseRouteLabels(map[string]string{"dev.ergomake.route.x": value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestGitCompose_resolveRoutes(t *testing.T) {
+	t.Parallel()
+
+	c := &gitCompose{owner: "owner", repo: "repo", branch: "main"}
+	host := func(name string) string {
+		return fmt.Sprintf("%s-owner-repo-main.%s", name, clusterDomain)
+	}
+
+	composeRoutes := c.resolveRoutes("web", composeRouteSpecs(kobject.ServiceConfig{
+		Name: "web",
+		Port: []kobject.Ports{{HostPort: 3000, ContainerPort: 3000}, {HostPort: 9229, ContainerPort: 9229}, {ContainerPort: 5432}},
+	}))
+	assert.Equal(t, []Route{
+		{Host: host("web"), Path: "/", Port: 3000, Protocol: endpoints.ProtocolHTTP},
+		{Host: host("web-9229"), Path: "/", Port: 9229, Protocol: endpoints.ProtocolHTTP},
+	}, composeRoutes)
+	assert.Equal(t, host("web"), primaryURL(composeRoutes))
+
+	apiRoutes := c.resolveRoutes("api", ergopackRouteSpecs(ergopack.ErgopackApp{
+		Routes: []ergopack.ErgopackRoute{{Port: "8080", Path: "/api", Host: "web"}},
+	}))
+	assert.Equal(t, []Route{{Host: host("web"), Path: "/api", Port: 8080, Protocol: endpoints.ProtocolHTTP}}, apiRoutes)
+	assert.Equal(t, "", primaryURL(apiRoutes))
+
+	api := EnvironmentService{Routes: apiRoutes}
+	assert.Equal(t, []string{host("web")}, api.Hosts())
+}
+
+func TestMakeIngress(t *testing.T) {
+	t.Parallel()
+
+	ingress := makeIngress("web", "web", "ns", map[string]string{"app": "web"}, []Route{
+		{Host: "a.example.com", Path: "/", Port: 3000, Protocol: endpoints.ProtocolHTTP},
+		{Host: "b.example.com", Path: "/", Port: 9229, Protocol: endpoints.ProtocolHTTP},
+		{Host: "a.example.com", Path: "/metrics", Port: 9090, Protocol: endpoints.ProtocolHTTP},
+	})
+
+	require.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "a.example.com", ingress.Spec.Rules[0].Host)
+	require.Len(t, ingress.Spec.Rules[0].HTTP.Paths, 2)
+	assert.Equal(t, "/metrics", ingress.Spec.Rules[0].HTTP.Paths[1].Path)
+	assert.Equal(t, int32(9090), ingress.Spec.Rules[0].HTTP.Paths[1].Backend.Service.Port.Number)
+	assert.Equal(t, "b.example.com", ingress.Spec.Rules[1].Host)
+	assert.Equal(t, "ns", ingress.Namespace)
+}
+
+func TestMakeIngresses(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"app": "api"}
+	ingresses := makeIngresses("api", "ns", labels, []Route{
+		{Host: "api.example.com", Path: "/", Port: 8080, Protocol: endpoints.ProtocolHTTP},
+		{Host: "api-grpc.example.com", Path: "/", Port: 50051, Protocol: endpoints.ProtocolGRPC},
+		{Port: 5432, Protocol: endpoints.ProtocolTCP},
+	})
+
+	require.Len(t, ingresses, 2)
+	assert.Equal(t, "api", ingresses[0].Name)
+	assert.Equal(t, "api-grpc", ingresses[1].Name)
+	assert.Equal(t, "api", ingresses[1].Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, "GRPC", ingresses[1].Annotations[backendProtocolAnnotation])
+	assert.NotContains(t, labels, backendProtocolAnnotation)
+	assert.NotContains(t, ingresses[0].Annotations, backendProtocolAnnotation)
+}
+
+func TestGitCompose_resolveRoutesProtocols(t *testing.T) {
+	t.Parallel()
+
+	specs, err := parseRouteLabels(map[string]string{
+		"dev.ergomake.route.db":  "port=5432,protocol=tcp",
+		"dev.ergomake.route.rpc": "port=50051,protocol=gRPC,host=rpc",
+	})
+	require.NoError(t, err)
+
+	c := &gitCompose{owner: "owner", repo: "repo", branch: "main"}
+	routes := c.resolveRoutes("api", specs)
+	assert.Equal(t, []Route{
+		{Port: 5432, Protocol: endpoints.ProtocolTCP},
+		{Host: fmt.Sprintf("rpc-owner-repo-main.%s", clusterDomain), Path: "/", Port: 50051, Protocol: endpoints.ProtocolGRPC},
+	}, routes)
+	assert.Equal(t, "", primaryURL(routes))
+
+	for _, value := range []string{"port=53,protocol=sctp", "port=53,protocol=udp,path=/dns", "port=53,protocol=tcp,host=db"} {
+		_, err := parseRouteLabels(map[string]string{"dev.ergomake.route.x": value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestFindDuplicateURLs_Routes(t *testing.T) {
+	t.Parallel()
+
+	env := &Environment{Services: map[string]EnvironmentService{
+		"web": {Url: "web.example.com", Routes: []Route{{Host: "web.example.com", Path: "/", Port: 3000, Protocol: endpoints.ProtocolHTTP}}},
+		"api": {Routes: []Route{{Host: "web.example.com", Path: "/api", Port: 8080, Protocol: endpoints.ProtocolHTTP}}},
+	}}
+	assert.Nil(t, findDuplicateURLs(env))
+
+	env.Services["docs"] = EnvironmentService{Routes: []Route{{Host: "web.example.com", Path: "/api", Port: 80, Protocol: endpoints.ProtocolHTTP}}}
+	validationErr := findDuplicateURLs(env)
+	require.NotNil(t, validationErr)
+	assert.Contains(t, validationErr.Message, "web.example.com/api")
+}
+
+func TestGitCompose_validateProjectInvalidRoutes(t *testing.T) {
+	tt := []struct {
+		name    string
+		file    string
+		content string
+		line    int
+	}{
+		{
+			name: "compose label",
+			file: "compose.yaml",
+			content: `
+services:
+  web:
+    image: nginx
+    labels:
+      dev.ergomake.route.api: "port=http"
+`,
+			line: 5,
+		},
+		{
+			name: "compose label list",
+			file: "compose.yaml",
+			content: `
+services:
+  web:
+    image: nginx
+    labels:
+      - "dev.ergomake.route.api=port=80,path=api"
+`,
+			line: 5,
+		},
+		{
+			name: "ergopack",
+			file: ".ergomake/ergopack.yaml",
+			content: `
+apps:
+  web:
+    image: nginx
+    publicPort: "80"
+    routes:
+      - port: "70000"
+`,
+			line: 6,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir, err := ioutil.TempDir("", "validate_project_test")
+			require.NoError(t, err)
+			defer os.RemoveAll(tmpDir)
+
+			require.NoError(t, os.MkdirAll(path.Dir(path.Join(tmpDir, tc.file)), 0755))
+			err = ioutil.WriteFile(path.Join(tmpDir, tc.file), []byte(tc.content), 0644)
+			require.NoError(t, err)
+
+			gc := &gitCompose{projectPath: tmpDir}
+
+			vErr, err := gc.validateProject()
+			require.NoError(t, err)
+			require.NotNil(t, vErr)
+
+			assert.Equal(t, "invalid-route", vErr.T)
+			assert.Equal(t, tc.file, vErr.Path)
+			assert.Equal(t, tc.line, vErr.Line)
+		})
+	}
+}
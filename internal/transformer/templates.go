@@ -0,0 +1,230 @@
+package transformer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cbroglie/mustache"
+	"github.com/pkg/errors"
+
+	"github.com/ergomake/ergomake/internal/envvars"
+)
+
+// makeTemplateContext returns what templates are rendered with. Values of
+// variables, of compose service environment and of ergopack app env are
+// mustache templates rendered when the environment is deployed, eg:
+//
+//	API_URL: https://{{services.api.url}}
+//	DATABASE_HOST: {{services.db.internalHost}}
+//
+// The template context has:
+//
+//	owner                         owner of the repository
+//	repo                          name of the repository
+//	branch                        branch being deployed
+//	sha                           commit being deployed
+//	prNumber                      pull request number, empty for permanent branches
+//	environmentType               pull-request or permanent-branch
+//	namespace                     namespace of the environment in the cluster
+//	services.<name>.url           public host of the service, empty when it isn't public
+//	services.<name>.internalHost  DNS name of the service inside the cluster
+//	services.<name>.image         image the service runs
+//
+// apps is an alias of services. Referencing anything else, as well as using
+// sections or partials, is a validation error of the project. Values read from
+// secret stores are never rendered.
+func (c *gitCompose) makeTemplateContext(namespace string) map[string]interface{} {
+	prNumber := ""
+	if c.prNumber != nil {
+		prNumber = strconv.Itoa(*c.prNumber)
+	}
+
+	services := map[string]interface{}{}
+	for name, service := range c.environment.Services {
+		services[name] = map[string]interface{}{
+			"url":          service.Url,
+			"internalHost": fmt.Sprintf("%s.%s.svc.cluster.local", name, namespace),
+			"image":        service.Image,
+		}
+	}
+
+	return map[string]interface{}{
+		"owner":           c.owner,
+		"repo":            c.repo,
+		"branch":          c.branch,
+		"sha":             c.sha,
+		"prNumber":        prNumber,
+		"environmentType": string(c.envVarsTarget(c.branch, "").EnvironmentType),
+		"namespace":       namespace,
+		"services":        services,
+		"apps":            services,
+	}
+}
+
+// renderTemplate renders value with templateContext, failing when value
+// references something templateContext doesn't have
+func renderTemplate(value string, templateContext map[string]interface{}) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	tmpl, err := mustache.ParseStringRaw(value, true)
+	if err != nil {
+		return "", errors.Wrap(err, "malformed template")
+	}
+
+	for _, tag := range tmpl.Tags() {
+		if tag.Type() != mustache.Variable {
+			return "", errors.Errorf("`%s` is not supported, only references such as {{services.api.url}} are", tag.Name())
+		}
+
+		if !hasReference(templateContext, tag.Name()) {
+			return "", errors.Errorf("`%s` is not a known reference", tag.Name())
+		}
+	}
+
+	return tmpl.Render(templateContext)
+}
+
+func hasReference(templateContext map[string]interface{}, name string) bool {
+	var current interface{} = templateContext
+	for _, part := range strings.Split(name, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		current, ok = m[part]
+		if !ok {
+			return false
+		}
+	}
+
+	_, isMap := current.(map[string]interface{})
+	return !isMap
+}
+
+// renderEnvTemplates renders the env of the services of the project in place
+// and checks that the variables that apply to them can be rendered too
+func (c *gitCompose) renderEnvTemplates(ctx context.Context) (*ProjectValidationError, error) {
+	names := make([]string, 0, len(c.environment.Services))
+	for name := range c.environment.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		service := c.environment.Services[name]
+
+		keys := make([]string, 0, len(service.Env))
+		for k := range service.Env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			value, err := renderTemplate(service.Env[k], c.templateContext)
+			if err != nil {
+				return c.envTemplateValidationError(name, k, err), nil
+			}
+			service.Env[k] = value
+		}
+	}
+
+	if c.isCompose {
+		for _, name := range names {
+			service, ok := c.komposeObject.ServiceConfigs[name]
+			if !ok {
+				continue
+			}
+
+			for i, v := range service.Environment {
+				value, err := renderTemplate(v.Value, c.templateContext)
+				if err != nil {
+					return c.envTemplateValidationError(name, v.Name, err), nil
+				}
+				service.Environment[i].Value = value
+			}
+		}
+	}
+
+	for _, name := range names {
+		repo := c.repo
+		if c.isCompose {
+			repo, _ = c.computeRepoAndBuildPath(c.environment.Services[name].Build, c.repo)
+		}
+
+		_, err := c.listEnvVars(ctx, repo, c.branch, name)
+		var templateErr *envVarTemplateError
+		if errors.As(err, &templateErr) {
+			message := fmt.Sprintf("Variable `%s` of service `%s` has an invalid template: %s", templateErr.envVar.Name, name, templateErr.err)
+			if templateErr.envVar.Group != "" {
+				message = fmt.Sprintf("Variable `%s` of group `%s` used by service `%s` has an invalid template: %s",
+					templateErr.envVar.Name, templateErr.envVar.Group, name, templateErr.err)
+			}
+
+			return &ProjectValidationError{T: "invalid-env-template", Message: message}, nil
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to list env vars of service %s", name)
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *gitCompose) envTemplateValidationError(service, name string, err error) *ProjectValidationError {
+	return &ProjectValidationError{
+		T:       "invalid-env-template",
+		Message: fmt.Sprintf("Env var `%s` of service `%s` has an invalid template: %s", name, service, err),
+		Path:    c.environment.ConfigPath,
+		Line:    c.environment.ServiceLine(service),
+	}
+}
+
+type envVarTemplateError struct {
+	envVar envvars.EnvVar
+	err    error
+}
+
+func (e *envVarTemplateError) Error() string {
+	return fmt.Sprintf("fail to render template of env var %s: %s", e.envVar.Name, e.err)
+}
+
+// listEnvVars returns the variables that apply to service with their
+// templates rendered, results are kept since they are needed both when
+// validating the project and when deploying it
+func (c *gitCompose) listEnvVars(ctx context.Context, repo, branch, service string) ([]envvars.EnvVar, error) {
+	key := fmt.Sprintf("%s@%s/%s", repo, branch, service)
+	if vars, ok := c.envVars[key]; ok {
+		return vars, nil
+	}
+
+	vars, err := c.envVarsProvider.ListEffective(ctx, c.owner, repo, c.envVarsTarget(branch, service))
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to list env vars by repo")
+	}
+
+	for i, v := range vars {
+		if v.External {
+			continue
+		}
+
+		value, err := renderTemplate(v.Value, c.templateContext)
+		if err != nil {
+			return nil, &envVarTemplateError{v, err}
+		}
+		vars[i].Value = value
+	}
+
+	if c.envVars == nil {
+		c.envVars = map[string][]envvars.EnvVar{}
+	}
+	c.envVars[key] = vars
+
+	return vars, nil
+}
@@ -0,0 +1,143 @@
+package transformer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+
+	"github.com/ergomake/ergomake/internal/envvars"
+	envvarsMocks "github.com/ergomake/ergomake/mocks/envvars"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	t.Parallel()
+
+	c := &gitCompose{
+		owner:    "acme",
+		repo:     "shop",
+		branch:   "feature",
+		sha:      "abc123",
+		prNumber: pointer.Int(7),
+		environment: &Environment{Services: map[string]EnvironmentService{
+			"api": {Url: "api-acme-shop-7.env.ergomake.test", Image: "api:latest"},
+			"db":  {Image: "postgres"},
+		}},
+	}
+	templateContext := c.makeTemplateContext("ns")
+
+	tt := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "plain value", value: "a&b", want: "a&b"},
+		{name: "public url", value: "https://{{services.api.url}}/v1", want: "https://api-acme-shop-7.env.ergomake.test/v1"},
+		{name: "apps alias", value: "{{apps.api.url}}", want: "api-acme-shop-7.env.ergomake.test"},
+		{name: "internal host", value: "postgres://{{services.db.internalHost}}:5432", want: "postgres://db.ns.svc.cluster.local:5432"},
+		{name: "environment", value: "{{owner}}/{{repo}}@{{branch}}#{{prNumber}} {{sha}} {{environmentType}}", want: "acme/shop@feature#7 abc123 pull-request"},
+		{name: "unknown service", value: "{{services.web.url}}", wantErr: "`services.web.url` is not a known reference"},
+		{name: "unknown field", value: "{{services.api.port}}", wantErr: "`services.api.port` is not a known reference"},
+		{name: "object reference", value: "{{services.api}}", wantErr: "`services.api` is not a known reference"},
+		{name: "sections", value: "{{#services}}x{{/services}}", wantErr: "`services` is not supported"},
+		{name: "partials", value: "{{> /etc/passwd}}", wantErr: "is not supported"},
+		{name: "malformed", value: "{{services.api.url", wantErr: "malformed template"},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := renderTemplate(tc.value, templateContext)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGitCompose_renderEnvTemplates(t *testing.T) {
+	t.Parallel()
+
+	newGitCompose := func(t *testing.T, vars []envvars.EnvVar) *gitCompose {
+		provider := envvarsMocks.NewEnvVarsProvider(t)
+		provider.EXPECT().ListEffective(mock.Anything, "acme", "shop", envvars.Target{
+			Branch:          "main",
+			Service:         "api",
+			EnvironmentType: envvars.EnvironmentTypePermanentBranch,
+		}).Return(vars, nil).Maybe()
+
+		c := &gitCompose{
+			envVarsProvider: provider,
+			owner:           "acme",
+			repo:            "shop",
+			branch:          "main",
+			environment: &Environment{
+				Services: map[string]EnvironmentService{
+					"api": {Url: "api.env.ergomake.test", Env: map[string]string{"SELF": "https://{{services.api.url}}"}},
+				},
+				ConfigPath: ".ergomake/ergopack.yaml",
+			},
+		}
+		c.templateContext = c.makeTemplateContext("ns")
+
+		return c
+	}
+
+	t.Run("renders env and variables", func(t *testing.T) {
+		t.Parallel()
+
+		c := newGitCompose(t, []envvars.EnvVar{
+			{Name: "CALLBACK", Value: "https://{{services.api.url}}/callback"},
+			{Name: "PASSWORD", Value: "{{not-a-template}}", External: true},
+		})
+
+		validationErr, err := c.renderEnvTemplates(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, validationErr)
+		assert.Equal(t, "https://api.env.ergomake.test", c.environment.Services["api"].Env["SELF"])
+
+		vars, err := c.listEnvVars(context.Background(), "shop", "main", "api")
+		require.NoError(t, err)
+		assert.Equal(t, []envvars.EnvVar{
+			{Name: "CALLBACK", Value: "https://api.env.ergomake.test/callback"},
+			{Name: "PASSWORD", Value: "{{not-a-template}}", External: true},
+		}, vars)
+	})
+
+	t.Run("unknown reference in env", func(t *testing.T) {
+		t.Parallel()
+
+		c := newGitCompose(t, nil)
+		c.environment.Services["api"].Env["OTHER"] = "{{services.web.url}}"
+
+		validationErr, err := c.renderEnvTemplates(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, validationErr)
+		assert.Equal(t, "invalid-env-template", validationErr.T)
+		assert.Equal(t, ".ergomake/ergopack.yaml", validationErr.Path)
+		assert.Contains(t, validationErr.Message, "`OTHER` of service `api`")
+	})
+
+	t.Run("unknown reference in group variable", func(t *testing.T) {
+		t.Parallel()
+
+		c := newGitCompose(t, []envvars.EnvVar{{Name: "URL", Value: "{{services.web.url}}", Group: "shared"}})
+
+		validationErr, err := c.renderEnvTemplates(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, validationErr)
+		assert.Equal(t, "invalid-env-template", validationErr.T)
+		assert.Contains(t, validationErr.Message, "`URL` of group `shared` used by service `api`")
+	})
+}
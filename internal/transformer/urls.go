@@ -0,0 +1,160 @@
+package transformer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+// validateURLs makes sure no two services end up at the same url, either inside
+// this environment or against live environments of other branches, pull
+// requests or repos. Urls are checked again when they are claimed, see saveServices.
+func (c *gitCompose) validateURLs(ctx context.Context) (*ProjectValidationError, error) {
+	if validationErr := findDuplicateURLs(c.environment); validationErr != nil {
+		return validationErr, nil
+	}
+
+	conflict, err := c.findURLConflict(c.db.WithContext(ctx), environmentHosts(c.environment))
+	if err != nil || conflict == nil {
+		return nil, err
+	}
+
+	return conflict.validationError(), nil
+}
+
+type urlConflict struct {
+	Url    string
+	Owner  string
+	Repo   string
+	Branch string
+}
+
+func (conflict *urlConflict) validationError() *ProjectValidationError {
+	return &ProjectValidationError{
+		T: "url-collision",
+		Message: fmt.Sprintf(
+			"The url `%s` is already taken by the environment of branch `%s` at `%s/%s`. Rename the service or the branch to get a different url.",
+			conflict.Url,
+			conflict.Branch,
+			conflict.Owner,
+			conflict.Repo,
+		),
+	}
+}
+
+// findURLConflict returns a live service of another environment that is
+// reachable at one of hosts. Only earlier deployments of the same branch and
+// pull request are left out, a pull request whose number matches the name of
+// a branch gets the same url as the environment of that branch.
+func (c *gitCompose) findURLConflict(tx *gorm.DB, hosts []string) (*urlConflict, error) {
+	if len(hosts) == 0 {
+		return nil, nil
+	}
+
+	var conflicts []urlConflict
+	err := tx.Table("services s").
+		Select("s.url, e.owner, e.repo, e.branch").
+		Joins("INNER JOIN environments e ON e.id = s.environment_id").
+		Where("s.url IN ? OR s.hosts && ?", hosts, pq.StringArray(hosts)).
+		Where("s.deleted_at IS NULL AND e.deleted_at IS NULL").
+		Where("e.status <> ?", database.EnvStale).
+		Where(
+			"NOT (e.owner = ? AND e.repo = ? AND e.branch = ? AND e.pull_request IS NOT DISTINCT FROM ?)",
+			c.owner,
+			c.repo,
+			c.branch,
+			c.prNumber,
+		).
+		Limit(1).
+		Scan(&conflicts).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to check for url collisions")
+	}
+
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	return &conflicts[0], nil
+}
+
+// lockHosts serializes transactions claiming any of hosts until they end,
+// hosts are locked in order so that two claims can't deadlock
+func lockHosts(tx *gorm.DB, hosts []string) error {
+	sorted := append([]string{}, hosts...)
+	sort.Strings(sorted)
+
+	for _, host := range sorted {
+		err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "service-host:"+host).Error
+		if err != nil {
+			return errors.Wrapf(err, "fail to lock host %s", host)
+		}
+	}
+
+	return nil
+}
+
+func environmentHosts(env *Environment) []string {
+	hosts := []string{}
+	for _, service := range env.Services {
+		hosts = append(hosts, service.Hosts()...)
+	}
+
+	return hosts
+}
+
+func findDuplicateURLs(env *Environment) *ProjectValidationError {
+	names := make([]string, 0, len(env.Services))
+	for name := range env.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := map[string]string{}
+	for _, name := range names {
+		service := env.Services[name]
+
+		// the same service may route several ports to one host, but never the same path
+		locations := map[string]struct{}{}
+		for _, route := range service.Routes {
+			if route.Protocol.IsStream() {
+				continue
+			}
+
+			location := route.Host + route.Path
+			if _, ok := locations[location]; ok {
+				return &ProjectValidationError{
+					T:       "url-collision",
+					Message: fmt.Sprintf("Service `%s` has more than one route to `%s`.", name, location),
+				}
+			}
+			locations[location] = struct{}{}
+		}
+		if len(service.Routes) == 0 && service.Url != "" {
+			locations[service.Url+"/"] = struct{}{}
+		}
+
+		for location := range locations {
+			if other, ok := seen[location]; ok {
+				return &ProjectValidationError{
+					T: "url-collision",
+					Message: fmt.Sprintf(
+						"Services `%s` and `%s` would both be exposed at `%s`. Rename one of them or change its route to get a different url.",
+						other,
+						name,
+						location,
+					),
+				}
+			}
+			seen[location] = name
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package transformer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+
+	"github.com/ergomake/ergomake/e2e/testutils"
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+func TestFindDuplicateURLs(t *testing.T) {
+	t.Parallel()
+
+	env := &Environment{Services: map[string]EnvironmentService{
+		"web":    {Url: "web-owner-repo-1.env.ergomake.test"},
+		"worker": {},
+		"db":     {},
+	}}
+	assert.Nil(t, findDuplicateURLs(env))
+
+	env.Services["web_"] = EnvironmentService{Url: "web-owner-repo-1.env.ergomake.test"}
+	validationErr := findDuplicateURLs(env)
+	require.NotNil(t, validationErr)
+	assert.Equal(t, "url-collision", validationErr.T)
+	assert.Contains(t, validationErr.Message, "`web` and `web_`")
+}
+
+func TestGitCompose_findURLConflict(t *testing.T) {
+	db := testutils.CreateRandomDB(t)
+
+	host := "web-owner-repo-7.env.ergomake.test"
+	createEnv := func(branch string, prNumber *int) {
+		env := database.NewEnvironment(uuid.New(), "owner", "owner", "repo", branch, prNumber, "author", database.EnvSuccess)
+		require.NoError(t, db.Create(&env).Error)
+		require.NoError(t, db.Create(&database.Service{
+			ID:            uuid.NewString(),
+			Name:          "web",
+			EnvironmentID: env.ID,
+			Url:           host,
+			Hosts:         []string{host},
+		}).Error)
+	}
+
+	// an earlier deployment of the same pull request is being replaced
+	createEnv("7", pointer.Int(7))
+	gc := &gitCompose{db: db, owner: "owner", repo: "repo", branch: "7", prNumber: pointer.Int(7)}
+	conflict, err := gc.findURLConflict(db.DB, []string{host})
+	require.NoError(t, err)
+	assert.Nil(t, conflict)
+
+	// the branch of the pull request also has an environment of its own at the same url
+	createEnv("7", nil)
+	conflict, err = gc.findURLConflict(db.DB, []string{host})
+	require.NoError(t, err)
+	require.NotNil(t, conflict)
+	assert.Equal(t, host, conflict.Url)
+}
+
+func TestGitCompose_TransformURLClaimedAfterPrepare(t *testing.T) {
+	db := testutils.CreateRandomDB(t)
+
+	host := "web-owner-repo-7.env.ergomake.test"
+	other := database.NewEnvironment(uuid.New(), "owner", "owner", "repo", "7", nil, "author", database.EnvSuccess)
+	require.NoError(t, db.Create(&other).Error)
+	require.NoError(t, db.Create(&database.Service{
+		ID:            uuid.NewString(),
+		Name:          "web",
+		EnvironmentID: other.ID,
+		Url:           host,
+		Hosts:         []string{host},
+	}).Error)
+
+	id := uuid.New()
+	dbEnv := database.NewEnvironment(id, "owner", "owner", "repo", "7", pointer.Int(7), "author", database.EnvPending)
+	require.NoError(t, db.Create(&dbEnv).Error)
+
+	gc := &gitCompose{
+		db:            db,
+		owner:         "owner",
+		repo:          "repo",
+		branch:        "7",
+		prNumber:      pointer.Int(7),
+		prepared:      true,
+		dbEnvironment: dbEnv,
+		environment: &Environment{Services: map[string]EnvironmentService{
+			"web": {ID: uuid.NewString(), Url: host},
+		}},
+	}
+
+	result, err := gc.Transform(context.Background(), id)
+	require.NoError(t, err)
+	require.NotNil(t, result.ValidationError)
+	assert.Contains(t, result.ValidationError.Message, host)
+
+	saved, err := db.FindEnvironmentByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, database.EnvDegraded, saved.Status)
+}
@@ -7,15 +7,22 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
-
 	"gopkg.in/yaml.v3"
+
+	"github.com/ergomake/ergomake/internal/ergopack"
 )
 
 type ProjectValidationError struct {
 	T       string `json:"type"`
 	Message string `json:"message"`
+	Path    string `json:"path,omitempty"`
+	Line    int    `json:"line,omitempty"`
 }
 
 var projectValidationErrorComposeNotFound = ProjectValidationError{
@@ -88,7 +95,43 @@ func findErgopackPath(projectPath string) (string, error) {
 }
 
 func validateErgopack(projectPath string, ergopackPath string) (*ProjectValidationError, error) {
-	// TODO
+	relativePath, err := filepath.Rel(projectPath, ergopackPath)
+	if err != nil {
+		relativePath = ergopackPath
+	}
+
+	content, err := ioutil.ReadFile(ergopackPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to read ergopack at %s", ergopackPath)
+	}
+
+	var pack ergopack.Ergopack
+	err = yaml.Unmarshal(content, &pack)
+	if err != nil {
+		// syntax errors are reported when the ergopack is loaded
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(pack.Apps))
+	for name := range pack.Apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for i, spec := range ergopackRouteSpecs(pack.Apps[name]) {
+			err := spec.validate()
+			if err != nil {
+				return &ProjectValidationError{
+					T:       "invalid-route",
+					Message: fmt.Sprintf("Route %d of app `%s` is invalid: %s.", i+1, name, err.Error()),
+					Path:    relativePath,
+					Line:    FindYAMLKeyLine(content, "apps", name, "routes"),
+				}, nil
+			}
+		}
+	}
+
 	return nil, nil
 }
 
@@ -138,22 +181,39 @@ func validateCompose(projectPath, composePath string) (*ProjectValidationError,
 		return &ProjectValidationError{
 			T:       "invalid-compose",
 			Message: fmt.Sprintf("Compose file has syntax error\n```\n%s: %s\n```", relativePath, err.Error()),
+			Path:    relativePath,
+			Line:    yamlErrorLine(err),
 		}, nil
 	}
 
 	asMap, ok := v.(map[string]interface{})
 	if !ok {
-		return &ProjectValidationError{T: "invalid-compose", Message: "Compose has no `services` defined."}, nil
+		return &ProjectValidationError{
+			T:       "invalid-compose",
+			Message: "Compose has no `services` defined.",
+			Path:    relativePath,
+			Line:    1,
+		}, nil
 	}
 
 	rawSvcs, ok := asMap["services"]
 	if !ok {
-		return &ProjectValidationError{T: "invalid-compose", Message: "Compose has no `services` defined."}, nil
+		return &ProjectValidationError{
+			T:       "invalid-compose",
+			Message: "Compose has no `services` defined.",
+			Path:    relativePath,
+			Line:    1,
+		}, nil
 	}
 
 	servicesMap, ok := rawSvcs.(map[string]interface{})
 	if !ok {
-		return &ProjectValidationError{T: "invalid-compose", Message: "Compose `services` has invalid type, expect a map."}, nil
+		return &ProjectValidationError{
+			T:       "invalid-compose",
+			Message: "Compose `services` has invalid type, expect a map.",
+			Path:    relativePath,
+			Line:    FindYAMLKeyLine(content, "services"),
+		}, nil
 	}
 
 	services := make(map[string]map[string]interface{})
@@ -162,18 +222,64 @@ func validateCompose(projectPath, composePath string) (*ProjectValidationError,
 		services[k] = service
 	}
 
-	validationErr, err := validateEnvFiles(composePath, services)
+	validationErr, err := validateEnvFiles(composePath, relativePath, content, services)
+	if err != nil || validationErr != nil {
+		return validationErr, errors.Wrap(err, "fail to validate env files")
+	}
 
-	return validationErr, errors.Wrap(err, "fail to validate env files")
+	return validateRouteLabels(relativePath, content, services), nil
 }
 
-func validateEnvFiles(composePath string, services map[string]map[string]interface{}) (*ProjectValidationError, error) {
+func validateRouteLabels(
+	relativePath string,
+	content []byte,
+	services map[string]map[string]interface{},
+) *ProjectValidationError {
+	for name, svc := range services {
+		line := FindYAMLKeyLine(content, "services", name, "labels")
+
+		// compose labels are either a map or a list of key=value
+		labels := map[string]string{}
+		switch rawLabels := svc["labels"].(type) {
+		case map[string]interface{}:
+			for k, v := range rawLabels {
+				labels[k] = fmt.Sprint(v)
+			}
+		case []interface{}:
+			for _, rawLabel := range rawLabels {
+				k, v, _ := strings.Cut(fmt.Sprint(rawLabel), "=")
+				labels[k] = v
+			}
+		}
+
+		_, err := parseRouteLabels(labels)
+		if err != nil {
+			return &ProjectValidationError{
+				T:       "invalid-route",
+				Message: fmt.Sprintf("Service `%s` has an invalid route: %s.", name, err.Error()),
+				Path:    relativePath,
+				Line:    line,
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateEnvFiles(
+	composePath string,
+	relativePath string,
+	content []byte,
+	services map[string]map[string]interface{},
+) (*ProjectValidationError, error) {
 	for name, svc := range services {
 		rawEnvFile, ok := svc["env_file"]
 		if !ok {
 			continue
 		}
 
+		line := FindYAMLKeyLine(content, "services", name, "env_file")
+
 		var envFiles []string
 		asStr, ok := rawEnvFile.(string)
 		if ok {
@@ -184,6 +290,8 @@ func validateEnvFiles(composePath string, services map[string]map[string]interfa
 				return &ProjectValidationError{
 					T:       "invalid-compose",
 					Message: fmt.Sprintf("`env_file` field of service `%s` has invalid type.", name),
+					Path:    relativePath,
+					Line:    line,
 				}, nil
 			}
 
@@ -193,6 +301,8 @@ func validateEnvFiles(composePath string, services map[string]map[string]interfa
 					return &ProjectValidationError{
 						T:       "invalid-compose",
 						Message: fmt.Sprintf("`env_file` field of service `%s` has invalid type", name),
+						Path:    relativePath,
+						Line:    line,
 					}, nil
 				}
 
@@ -208,6 +318,8 @@ func validateEnvFiles(composePath string, services map[string]map[string]interfa
 					return &ProjectValidationError{
 						T:       "invalid-compose",
 						Message: fmt.Sprintf("Env file `%s` for service `%s` not found.", envFile, name),
+						Path:    relativePath,
+						Line:    line,
 					}, nil
 				}
 
@@ -218,3 +330,56 @@ func validateEnvFiles(composePath string, services map[string]map[string]interfa
 
 	return nil, nil
 }
+
+var yamlErrorLineRegex = regexp.MustCompile(`line (\d+)`)
+
+// yamlErrorLine extracts the line number from a yaml syntax error,
+// it returns 0 when the error does not reference any line
+func yamlErrorLine(err error) int {
+	match := yamlErrorLineRegex.FindStringSubmatch(err.Error())
+	if len(match) != 2 {
+		return 0
+	}
+
+	line, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+
+	return line
+}
+
+// FindYAMLKeyLine walks content following the given keys and returns the line
+// where the deepest key that could be found is defined, it returns 0 when content
+// can't be parsed or not even the first key exists
+func FindYAMLKeyLine(content []byte, keys ...string) int {
+	var doc yaml.Node
+	err := yaml.Unmarshal(content, &doc)
+	if err != nil || len(doc.Content) == 0 {
+		return 0
+	}
+
+	line := 0
+	node := doc.Content[0]
+	for _, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return line
+		}
+
+		found := false
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				line = node.Content[i].Line
+				node = node.Content[i+1]
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return line
+		}
+	}
+
+	return line
+}
@@ -88,16 +88,20 @@ services:
 	require.NoError(t, err)
 
 	assert.Equal(t, "invalid-compose", vErr.T)
+	assert.Equal(t, "compose.yaml", vErr.Path)
+	assert.NotZero(t, vErr.Line)
 }
 
 func TestGitCompose_validateProjectInvalidCompose(t *testing.T) {
 	tt := []struct {
 		name    string
 		compose string
+		line    int
 	}{
 		{
 			name:    "compose is not a map",
 			compose: "'this is just a string'",
+			line:    1,
 		},
 		{
 			name: "invalid services",
@@ -107,6 +111,7 @@ services:
  - 'a list?'
  - 'that is invalid'
 `,
+			line: 3,
 		},
 		{
 			name: "invalid env_file",
@@ -117,6 +122,7 @@ services:
     env_file: 
       a_map: 'is_not_supported'
 `,
+			line: 5,
 		},
 		{
 			name: "non existing env_file",
@@ -127,6 +133,7 @@ services:
     env_file: 
       - 'this_file_does_not_exists.env'
 `,
+			line: 5,
 		},
 	}
 
@@ -151,6 +158,8 @@ services:
 			require.NoError(t, err)
 
 			assert.Equal(t, "invalid-compose", vErr.T)
+			assert.Equal(t, "compose.yaml", vErr.Path)
+			assert.Equal(t, tc.line, vErr.Line)
 		})
 	}
 }
@@ -0,0 +1,264 @@
+package variablegroups
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/ergomake/ergomake/internal/crypto"
+	"github.com/ergomake/ergomake/internal/database"
+)
+
+type variableGroup struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Owner     string
+	Name      string
+}
+
+type variableGroupVariable struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	GroupID   uuid.UUID
+	Name      string
+	Value     string
+}
+
+type variableGroupRepo struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CreatedAt time.Time
+	GroupID   uuid.UUID
+	Repo      string
+}
+
+type dbGroupsProvider struct {
+	db     *database.DB
+	secret string
+}
+
+// NewDBGroupsProvider stores variable groups in the database, values are encrypted with secret
+func NewDBGroupsProvider(db *database.DB, secret string) *dbGroupsProvider {
+	return &dbGroupsProvider{db, secret}
+}
+
+func (gp *dbGroupsProvider) List(ctx context.Context, owner string) ([]Group, error) {
+	var dbGroups []variableGroup
+	err := gp.db.Table("variable_groups").
+		Where(map[string]interface{}{"owner": owner}).
+		Order("name").
+		Find(&dbGroups).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list variable groups of owner %s", owner)
+	}
+
+	return gp.load(dbGroups)
+}
+
+func (gp *dbGroupsProvider) Get(ctx context.Context, owner, name string) (*Group, error) {
+	var dbGroup variableGroup
+	err := gp.db.Table("variable_groups").First(&dbGroup, map[string]interface{}{"owner": owner, "name": name}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrGroupNotFound
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to find variable group %s of owner %s", name, owner)
+	}
+
+	groups, err := gp.load([]variableGroup{dbGroup})
+	if err != nil {
+		return nil, err
+	}
+
+	return &groups[0], nil
+}
+
+func (gp *dbGroupsProvider) Upsert(ctx context.Context, owner, name string, variables []Variable) (*Group, error) {
+	dbVariables := make([]variableGroupVariable, 0, len(variables))
+	for _, v := range variables {
+		value, err := crypto.Encrypt(gp.secret, v.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to encrypt value of variable %s", v.Name)
+		}
+
+		dbVariables = append(dbVariables, variableGroupVariable{Name: v.Name, Value: value})
+	}
+
+	err := gp.db.Transaction(func(tx *gorm.DB) error {
+		var dbGroup variableGroup
+		err := tx.Table("variable_groups").
+			Where(map[string]interface{}{"owner": owner, "name": name}).
+			Assign(map[string]interface{}{"updated_at": time.Now()}).
+			FirstOrCreate(&dbGroup).Error
+		if err != nil {
+			return errors.Wrap(err, "fail to upsert variable group")
+		}
+
+		err = tx.Table("variable_group_variables").
+			Where(map[string]interface{}{"group_id": dbGroup.ID}).
+			Delete(&variableGroupVariable{}).Error
+		if err != nil {
+			return errors.Wrap(err, "fail to delete old variables")
+		}
+
+		if len(dbVariables) == 0 {
+			return nil
+		}
+
+		for i := range dbVariables {
+			dbVariables[i].GroupID = dbGroup.ID
+		}
+
+		err = tx.Table("variable_group_variables").Create(&dbVariables).Error
+		return errors.Wrap(err, "fail to create variables")
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to upsert variable group %s of owner %s", name, owner)
+	}
+
+	return gp.Get(ctx, owner, name)
+}
+
+func (gp *dbGroupsProvider) Delete(ctx context.Context, owner, name string) error {
+	res := gp.db.Table("variable_groups").
+		Where(map[string]interface{}{"owner": owner, "name": name}).
+		Delete(&variableGroup{})
+	if res.Error != nil {
+		return errors.Wrapf(res.Error, "fail to delete variable group %s of owner %s", name, owner)
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrGroupNotFound
+	}
+
+	return nil
+}
+
+func (gp *dbGroupsProvider) ListByRepo(ctx context.Context, owner, repo string) ([]Group, error) {
+	var dbGroups []variableGroup
+	err := gp.db.Table("variable_groups").
+		Select("variable_groups.*").
+		Joins("JOIN variable_group_repos ON variable_group_repos.group_id = variable_groups.id").
+		Where("variable_groups.owner = ? AND variable_group_repos.repo = ?", owner, repo).
+		Order("variable_groups.name").
+		Find(&dbGroups).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to list variable groups of repo %s/%s", owner, repo)
+	}
+
+	return gp.load(dbGroups)
+}
+
+func (gp *dbGroupsProvider) SetRepoGroups(ctx context.Context, owner, repo string, names []string) error {
+	return gp.db.Transaction(func(tx *gorm.DB) error {
+		var dbGroups []variableGroup
+		if len(names) > 0 {
+			err := tx.Table("variable_groups").
+				Where("owner = ? AND name IN ?", owner, names).
+				Find(&dbGroups).Error
+			if err != nil {
+				return errors.Wrapf(err, "fail to find variable groups of owner %s", owner)
+			}
+		}
+
+		found := map[string]struct{}{}
+		for _, g := range dbGroups {
+			found[g.Name] = struct{}{}
+		}
+		for _, name := range names {
+			if _, ok := found[name]; !ok {
+				return errors.Wrapf(ErrGroupNotFound, "variable group %s", name)
+			}
+		}
+
+		err := tx.Table("variable_group_repos").
+			Where("repo = ? AND group_id IN (?)", repo, tx.Table("variable_groups").Select("id").Where("owner = ?", owner)).
+			Delete(&variableGroupRepo{}).Error
+		if err != nil {
+			return errors.Wrapf(err, "fail to detach variable groups from repo %s/%s", owner, repo)
+		}
+
+		if len(dbGroups) == 0 {
+			return nil
+		}
+
+		attachments := make([]variableGroupRepo, 0, len(dbGroups))
+		for _, g := range dbGroups {
+			attachments = append(attachments, variableGroupRepo{GroupID: g.ID, Repo: repo})
+		}
+
+		err = tx.Table("variable_group_repos").Create(&attachments).Error
+		return errors.Wrapf(err, "fail to attach variable groups to repo %s/%s", owner, repo)
+	})
+}
+
+// load fills the variables and repos of groups
+func (gp *dbGroupsProvider) load(dbGroups []variableGroup) ([]Group, error) {
+	groups := make([]Group, 0, len(dbGroups))
+	if len(dbGroups) == 0 {
+		return groups, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(dbGroups))
+	for _, g := range dbGroups {
+		ids = append(ids, g.ID)
+	}
+
+	var dbVariables []variableGroupVariable
+	err := gp.db.Table("variable_group_variables").
+		Where("group_id IN ?", ids).
+		Order("name").
+		Find(&dbVariables).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to list variables of groups")
+	}
+
+	var dbRepos []variableGroupRepo
+	err = gp.db.Table("variable_group_repos").
+		Where("group_id IN ?", ids).
+		Order("repo").
+		Find(&dbRepos).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to list repos of groups")
+	}
+
+	variables := map[uuid.UUID][]Variable{}
+	for _, v := range dbVariables {
+		value, err := crypto.Decrypt(gp.secret, v.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fail to decrypt value of variable %s", v.ID)
+		}
+
+		variables[v.GroupID] = append(variables[v.GroupID], Variable{Name: v.Name, Value: value})
+	}
+
+	repos := map[uuid.UUID][]string{}
+	for _, r := range dbRepos {
+		repos[r.GroupID] = append(repos[r.GroupID], r.Repo)
+	}
+
+	for _, g := range dbGroups {
+		group := Group{
+			ID:        g.ID,
+			Owner:     g.Owner,
+			Name:      g.Name,
+			Variables: variables[g.ID],
+			Repos:     repos[g.ID],
+		}
+		if group.Variables == nil {
+			group.Variables = []Variable{}
+		}
+		if group.Repos == nil {
+			group.Repos = []string{}
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,45 @@
+package variablegroups
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+var ErrGroupNotFound = errors.New("variable group not found")
+
+var nameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,254}$`)
+
+// IsValidName tells whether name can be used for a group
+func IsValidName(name string) bool {
+	return nameRegex.MatchString(name)
+}
+
+type Variable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Group is a set of variables shared by the repos of an owner that attach it,
+// variables of the repos themselves take precedence over the ones of groups.
+type Group struct {
+	ID        uuid.UUID  `json:"id"`
+	Owner     string     `json:"owner"`
+	Name      string     `json:"name"`
+	Variables []Variable `json:"variables"`
+	Repos     []string   `json:"repos"`
+}
+
+type GroupsProvider interface {
+	List(ctx context.Context, owner string) ([]Group, error)
+	Get(ctx context.Context, owner, name string) (*Group, error)
+	// Upsert replaces the variables of the group, creating it when needed
+	Upsert(ctx context.Context, owner, name string, variables []Variable) (*Group, error)
+	Delete(ctx context.Context, owner, name string) error
+	// ListByRepo returns the groups attached to repo sorted by name
+	ListByRepo(ctx context.Context, owner, repo string) ([]Group, error)
+	// SetRepoGroups attaches the named groups to repo and detaches all others
+	SetRepoGroups(ctx context.Context, owner, repo string, names []string) error
+}
@@ -0,0 +1,20 @@
+package variablegroups
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"payments", "shared-keys", "prod.aws_1"} {
+		assert.True(t, IsValidName(name), name)
+	}
+
+	for _, name := range []string{"", "-payments", "with space", "a/b", strings.Repeat("a", 256)} {
+		assert.False(t, IsValidName(name), name)
+	}
+}
@@ -104,6 +104,18 @@ func WatchEnvironments(
 					log.Err(err).Msg("fail to terminate limited environment for relaunch")
 				}
 
+				// without the author of the pull request, relaunches of pull requests
+				// from forks are held until approved
+				prAuthor := ""
+				if pr != nil {
+					pullRequest, err := ghApp.GetPullRequest(ctx, env.Owner, env.Repo, *pr)
+					if err != nil {
+						log.Err(err).Msg("fail to get pull request of limited environment for relaunch")
+					} else {
+						prAuthor = pullRequest.GetUser().GetLogin()
+					}
+				}
+
 				launchReq := ghlauncher.LaunchEnvironmentRequest{
 					Owner:       env.Owner,
 					BranchOwner: env.BranchOwner,
@@ -112,6 +124,7 @@ func WatchEnvironments(
 					SHA:         sha,
 					PrNumber:    pr,
 					Author:      env.Author,
+					PrAuthor:    prAuthor,
 					IsPrivate:   isPrivate,
 				}
 				go func() {
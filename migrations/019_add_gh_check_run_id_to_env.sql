@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE environments ADD COLUMN gh_check_run_id BIGINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE environments DROP COLUMN gh_check_run_id;
@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE comment_settings (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    owner VARCHAR(255) NOT NULL,
+    repo VARCHAR(255) NOT NULL,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    template TEXT NOT NULL DEFAULT '',
+    UNIQUE(owner, repo)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS comment_settings;
@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE notification_channels (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    owner VARCHAR(255) NOT NULL,
+    kind VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX idx_notification_channels_owner ON notification_channels(owner);
+
+CREATE TABLE notification_deliveries (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    channel_id UUID NOT NULL REFERENCES notification_channels(id),
+    event VARCHAR(255) NOT NULL,
+    environment_id UUID NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX idx_notification_deliveries_channel_id ON notification_deliveries(channel_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS notification_deliveries;
+DROP TABLE IF EXISTS notification_channels;
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE api_tokens (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    owner VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    hash VARCHAR(64) NOT NULL UNIQUE,
+    hint VARCHAR(16) NOT NULL,
+    scopes JSONB NOT NULL DEFAULT '[]',
+    last_used_at TIMESTAMPTZ NULL
+);
+CREATE INDEX idx_api_tokens_owner ON api_tokens(owner);
+
+-- +migrate Down
+DROP TABLE IF EXISTS api_tokens;
@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TABLE role_assignments (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    owner VARCHAR(255) NOT NULL,
+    kind VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    role VARCHAR(255) NOT NULL
+);
+CREATE UNIQUE INDEX idx_role_assignments_owner_kind_subject ON role_assignments(owner, kind, subject) WHERE deleted_at IS NULL;
+
+CREATE TABLE rbac_settings (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    owner VARCHAR(255) NOT NULL UNIQUE,
+    default_role VARCHAR(255) NOT NULL DEFAULT 'admin',
+    use_repo_permissions BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS rbac_settings;
+DROP TABLE IF EXISTS role_assignments;
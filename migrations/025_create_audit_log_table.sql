@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE audit_log (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    actor VARCHAR(255) NOT NULL,
+    owner VARCHAR(255) NOT NULL,
+    repo VARCHAR(255) NOT NULL DEFAULT '',
+    action VARCHAR(255) NOT NULL,
+    target TEXT NOT NULL DEFAULT '',
+    diff JSONB NULL
+);
+CREATE INDEX idx_audit_log_owner_created_at ON audit_log(owner, created_at DESC, id DESC);
+
+-- the audit log is append-only
+CREATE RULE audit_log_no_update AS ON UPDATE TO audit_log DO INSTEAD NOTHING;
+CREATE RULE audit_log_no_delete AS ON DELETE TO audit_log DO INSTEAD NOTHING;
+
+-- +migrate Down
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,31 @@
+-- +migrate Up
+CREATE TABLE preview_access_settings (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    owner VARCHAR(255) NOT NULL,
+    repo VARCHAR(255) NOT NULL,
+    mode VARCHAR(255) NOT NULL DEFAULT 'public',
+    username VARCHAR(255) NOT NULL DEFAULT '',
+    password_hash VARCHAR(255) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX idx_preview_access_settings_owner_repo ON preview_access_settings(owner, repo);
+
+CREATE TABLE preview_bypass_tokens (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    owner VARCHAR(255) NOT NULL,
+    repo VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    hash VARCHAR(64) NOT NULL UNIQUE,
+    hint VARCHAR(16) NOT NULL,
+    last_used_at TIMESTAMPTZ NULL
+);
+CREATE INDEX idx_preview_bypass_tokens_owner_repo ON preview_bypass_tokens(owner, repo);
+
+-- +migrate Down
+DROP TABLE IF EXISTS preview_bypass_tokens;
+DROP TABLE IF EXISTS preview_access_settings;
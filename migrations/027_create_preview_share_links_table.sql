@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE preview_share_links (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    environment_id UUID NOT NULL REFERENCES environments(id) ON DELETE CASCADE,
+    owner VARCHAR(255) NOT NULL,
+    repo VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    max_uses INTEGER NULL,
+    uses INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_preview_share_links_environment_id ON preview_share_links(environment_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS preview_share_links;
@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE custom_domains (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    owner VARCHAR(255) NOT NULL,
+    base_domain VARCHAR(255) NOT NULL,
+    url_template VARCHAR(255) NOT NULL,
+    verification_token VARCHAR(255) NOT NULL,
+    verified_at TIMESTAMPTZ NULL,
+    tls_mode VARCHAR(255) NOT NULL DEFAULT 'none',
+    cluster_issuer VARCHAR(255) NOT NULL DEFAULT '',
+    tls_cert TEXT NOT NULL DEFAULT '',
+    tls_key TEXT NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX idx_custom_domains_owner ON custom_domains(owner) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_custom_domains_base_domain ON custom_domains(base_domain) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS custom_domains;
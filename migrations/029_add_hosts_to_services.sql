@@ -0,0 +1,13 @@
+-- +migrate Up
+
+ALTER TABLE services
+ADD COLUMN hosts text[];
+
+CREATE INDEX idx_services_hosts ON services USING GIN (hosts);
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_services_hosts;
+
+ALTER TABLE services
+DROP COLUMN hosts;
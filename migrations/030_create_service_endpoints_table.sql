@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE service_endpoints (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    environment_id UUID NOT NULL REFERENCES environments(id) ON DELETE CASCADE,
+    service VARCHAR(255) NOT NULL,
+    protocol VARCHAR(255) NOT NULL CHECK (protocol IN ('grpc', 'tcp', 'udp')),
+    target_port INTEGER NOT NULL,
+    host VARCHAR(255) NOT NULL,
+    public_port INTEGER NOT NULL,
+    credential TEXT NOT NULL
+);
+CREATE INDEX idx_service_endpoints_environment_id ON service_endpoints(environment_id);
+CREATE UNIQUE INDEX idx_service_endpoints_stream_port ON service_endpoints(protocol, public_port) WHERE protocol IN ('tcp', 'udp');
+
+-- +migrate Down
+DROP TABLE IF EXISTS service_endpoints;
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE secret_stores (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    owner VARCHAR(255) NOT NULL,
+    kind VARCHAR(255) NOT NULL CHECK (kind IN ('vault', 'aws-secrets-manager')),
+    address VARCHAR(255) NOT NULL DEFAULT '',
+    region VARCHAR(255) NOT NULL DEFAULT '',
+    credentials TEXT NOT NULL,
+    path VARCHAR(255) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX idx_secret_stores_owner ON secret_stores(owner) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS secret_stores;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+ALTER TABLE env_vars ADD COLUMN service VARCHAR(255);
+ALTER TABLE env_vars ADD COLUMN environment_type VARCHAR(255)
+    CHECK (environment_type IN ('pull-request', 'permanent-branch'));
+
+-- NULL scopes never conflict in a unique constraint, so they are coalesced
+ALTER TABLE env_vars DROP CONSTRAINT env_vars_owner_repo_name_key;
+CREATE UNIQUE INDEX env_vars_owner_repo_name_scope_key ON env_vars(
+    owner,
+    repo,
+    name,
+    COALESCE(branch, ''),
+    COALESCE(service, ''),
+    COALESCE(environment_type, '')
+);
+
+-- +migrate Down
+
+DROP INDEX env_vars_owner_repo_name_scope_key;
+ALTER TABLE env_vars ADD CONSTRAINT env_vars_owner_repo_name_key UNIQUE (owner, repo, name, branch);
+ALTER TABLE env_vars DROP COLUMN environment_type;
+ALTER TABLE env_vars DROP COLUMN service;
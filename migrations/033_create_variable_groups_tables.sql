@@ -0,0 +1,33 @@
+-- +migrate Up
+CREATE TABLE variable_groups (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    owner VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    UNIQUE (owner, name)
+);
+
+CREATE TABLE variable_group_variables (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    group_id UUID NOT NULL REFERENCES variable_groups(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    value TEXT NOT NULL,
+    UNIQUE (group_id, name)
+);
+
+CREATE TABLE variable_group_repos (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    group_id UUID NOT NULL REFERENCES variable_groups(id) ON DELETE CASCADE,
+    repo VARCHAR(255) NOT NULL,
+    UNIQUE (group_id, repo)
+);
+CREATE INDEX idx_variable_group_repos_repo ON variable_group_repos(repo);
+
+-- +migrate Down
+DROP TABLE IF EXISTS variable_group_repos;
+DROP TABLE IF EXISTS variable_group_variables;
+DROP TABLE IF EXISTS variable_groups;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE env_var_versions (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    owner VARCHAR(255) NOT NULL,
+    repo VARCHAR(255) NOT NULL,
+    version BIGINT NOT NULL DEFAULT 0,
+    UNIQUE (owner, repo)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS env_var_versions;
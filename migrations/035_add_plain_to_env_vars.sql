@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE env_vars ADD COLUMN plain BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE env_vars DROP COLUMN plain;
@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TABLE fork_policy_settings (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ NULL,
+    owner VARCHAR(255) NOT NULL,
+    repo VARCHAR(255) NOT NULL,
+    secrets VARCHAR(255) NOT NULL DEFAULT 'never'
+);
+CREATE UNIQUE INDEX idx_fork_policy_settings_owner_repo ON fork_policy_settings(owner, repo);
+
+CREATE TABLE fork_approvals (
+    id UUID DEFAULT uuid_generate_v4() NOT NULL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    owner VARCHAR(255) NOT NULL,
+    repo VARCHAR(255) NOT NULL,
+    pr_number INTEGER NOT NULL,
+    sha VARCHAR(255) NOT NULL,
+    approved_by VARCHAR(255) NOT NULL
+);
+CREATE UNIQUE INDEX idx_fork_approvals_pr_sha ON fork_approvals(owner, repo, pr_number, sha);
+
+-- +migrate Down
+DROP TABLE IF EXISTS fork_approvals;
+DROP TABLE IF EXISTS fork_policy_settings;
@@ -0,0 +1,19 @@
+-- +migrate Up
+ALTER TABLE environments DROP CONSTRAINT environments_status_check;
+
+ALTER TABLE environments
+ADD CONSTRAINT environments_status_check
+CHECK (status IN ('pending', 'building', 'success', 'degraded', 'limited', 'stale', 'awaiting-approval'));
+
+ALTER TABLE fork_policy_settings ADD COLUMN require_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE fork_policy_settings DROP COLUMN IF EXISTS require_approval;
+
+UPDATE environments SET status = 'degraded' WHERE status = 'awaiting-approval';
+
+ALTER TABLE environments DROP CONSTRAINT environments_status_check;
+
+ALTER TABLE environments
+ADD CONSTRAINT environments_status_check
+CHECK (status IN ('pending', 'building', 'success', 'degraded', 'limited', 'stale'));
@@ -0,0 +1,7 @@
+-- +migrate Up
+-- tokens made before creators were recorded can't be tied to a role and stop
+-- passing role checks, they need to be recreated
+ALTER TABLE api_tokens ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE api_tokens DROP COLUMN IF EXISTS created_by;
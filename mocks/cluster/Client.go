@@ -809,6 +809,61 @@ func (_c *Client_GetJobLogs_Call) RunAndReturn(run func(context.Context, *batchv
 	return _c
 }
 
+// GetJobPodStatus provides a mock function with given fields: ctx, job
+func (_m *Client) GetJobPodStatus(ctx context.Context, job *batchv1.Job) (*v1.PodStatus, error) {
+	ret := _m.Called(ctx, job)
+
+	var r0 *v1.PodStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *batchv1.Job) (*v1.PodStatus, error)); ok {
+		return rf(ctx, job)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *batchv1.Job) *v1.PodStatus); ok {
+		r0 = rf(ctx, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.PodStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *batchv1.Job) error); ok {
+		r1 = rf(ctx, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetJobPodStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobPodStatus'
+type Client_GetJobPodStatus_Call struct {
+	*mock.Call
+}
+
+// GetJobPodStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *batchv1.Job
+func (_e *Client_Expecter) GetJobPodStatus(ctx interface{}, job interface{}) *Client_GetJobPodStatus_Call {
+	return &Client_GetJobPodStatus_Call{Call: _e.mock.On("GetJobPodStatus", ctx, job)}
+}
+
+func (_c *Client_GetJobPodStatus_Call) Run(run func(ctx context.Context, job *batchv1.Job)) *Client_GetJobPodStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*batchv1.Job))
+	})
+	return _c
+}
+
+func (_c *Client_GetJobPodStatus_Call) Return(_a0 *v1.PodStatus, _a1 error) *Client_GetJobPodStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetJobPodStatus_Call) RunAndReturn(run func(context.Context, *batchv1.Job) (*v1.PodStatus, error)) *Client_GetJobPodStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPreviewNamespaces provides a mock function with given fields: ctx
 func (_m *Client) GetPreviewNamespaces(ctx context.Context) ([]v1.Namespace, error) {
 	ret := _m.Called(ctx)
@@ -863,6 +918,62 @@ func (_c *Client_GetPreviewNamespaces_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// ListIngresses provides a mock function with given fields: ctx, namespace, matchLabels
+func (_m *Client) ListIngresses(ctx context.Context, namespace string, matchLabels map[string]string) ([]*networkingv1.Ingress, error) {
+	ret := _m.Called(ctx, namespace, matchLabels)
+
+	var r0 []*networkingv1.Ingress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) ([]*networkingv1.Ingress, error)); ok {
+		return rf(ctx, namespace, matchLabels)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) []*networkingv1.Ingress); ok {
+		r0 = rf(ctx, namespace, matchLabels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*networkingv1.Ingress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]string) error); ok {
+		r1 = rf(ctx, namespace, matchLabels)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ListIngresses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngresses'
+type Client_ListIngresses_Call struct {
+	*mock.Call
+}
+
+// ListIngresses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - matchLabels map[string]string
+func (_e *Client_Expecter) ListIngresses(ctx interface{}, namespace interface{}, matchLabels interface{}) *Client_ListIngresses_Call {
+	return &Client_ListIngresses_Call{Call: _e.mock.On("ListIngresses", ctx, namespace, matchLabels)}
+}
+
+func (_c *Client_ListIngresses_Call) Run(run func(ctx context.Context, namespace string, matchLabels map[string]string)) *Client_ListIngresses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(map[string]string))
+	})
+	return _c
+}
+
+func (_c *Client_ListIngresses_Call) Return(_a0 []*networkingv1.Ingress, _a1 error) *Client_ListIngresses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ListIngresses_Call) RunAndReturn(run func(context.Context, string, map[string]string) ([]*networkingv1.Ingress, error)) *Client_ListIngresses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListJobs provides a mock function with given fields: ctx, namespace
 func (_m *Client) ListJobs(ctx context.Context, namespace string) ([]*batchv1.Job, error) {
 	ret := _m.Called(ctx, namespace)
@@ -918,6 +1029,51 @@ func (_c *Client_ListJobs_Call) RunAndReturn(run func(context.Context, string) (
 	return _c
 }
 
+// PatchConfigMapData provides a mock function with given fields: ctx, namespace, name, data
+func (_m *Client) PatchConfigMapData(ctx context.Context, namespace string, name string, data map[string]string) error {
+	ret := _m.Called(ctx, namespace, name, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, map[string]string) error); ok {
+		r0 = rf(ctx, namespace, name, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_PatchConfigMapData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchConfigMapData'
+type Client_PatchConfigMapData_Call struct {
+	*mock.Call
+}
+
+// PatchConfigMapData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - name string
+//   - data map[string]string
+func (_e *Client_Expecter) PatchConfigMapData(ctx interface{}, namespace interface{}, name interface{}, data interface{}) *Client_PatchConfigMapData_Call {
+	return &Client_PatchConfigMapData_Call{Call: _e.mock.On("PatchConfigMapData", ctx, namespace, name, data)}
+}
+
+func (_c *Client_PatchConfigMapData_Call) Run(run func(ctx context.Context, namespace string, name string, data map[string]string)) *Client_PatchConfigMapData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(map[string]string))
+	})
+	return _c
+}
+
+func (_c *Client_PatchConfigMapData_Call) Return(_a0 error) *Client_PatchConfigMapData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_PatchConfigMapData_Call) RunAndReturn(run func(context.Context, string, string, map[string]string) error) *Client_PatchConfigMapData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ScaleDeployment provides a mock function with given fields: ctx, namespace, deploymentName, replicas
 func (_m *Client) ScaleDeployment(ctx context.Context, namespace string, deploymentName string, replicas int32) error {
 	ret := _m.Called(ctx, namespace, deploymentName, replicas)
@@ -69,6 +69,61 @@ func (_c *EnvironmentsProvider_DeleteEnvironment_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// GetEnvironment provides a mock function with given fields: ctx, id
+func (_m *EnvironmentsProvider) GetEnvironment(ctx context.Context, id uuid.UUID) (*database.Environment, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *database.Environment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*database.Environment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *database.Environment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*database.Environment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvironmentsProvider_GetEnvironment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEnvironment'
+type EnvironmentsProvider_GetEnvironment_Call struct {
+	*mock.Call
+}
+
+// GetEnvironment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *EnvironmentsProvider_Expecter) GetEnvironment(ctx interface{}, id interface{}) *EnvironmentsProvider_GetEnvironment_Call {
+	return &EnvironmentsProvider_GetEnvironment_Call{Call: _e.mock.On("GetEnvironment", ctx, id)}
+}
+
+func (_c *EnvironmentsProvider_GetEnvironment_Call) Run(run func(ctx context.Context, id uuid.UUID)) *EnvironmentsProvider_GetEnvironment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *EnvironmentsProvider_GetEnvironment_Call) Return(_a0 *database.Environment, _a1 error) *EnvironmentsProvider_GetEnvironment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EnvironmentsProvider_GetEnvironment_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*database.Environment, error)) *EnvironmentsProvider_GetEnvironment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEnvironmentFromHost provides a mock function with given fields: ctx, host
 func (_m *EnvironmentsProvider) GetEnvironmentFromHost(ctx context.Context, host string) (*database.Environment, error) {
 	ret := _m.Called(ctx, host)
@@ -234,6 +289,62 @@ func (_c *EnvironmentsProvider_ListEnvironmentsByBranch_Call) RunAndReturn(run f
 	return _c
 }
 
+// ListEnvironmentsByRepo provides a mock function with given fields: ctx, owner, repo
+func (_m *EnvironmentsProvider) ListEnvironmentsByRepo(ctx context.Context, owner string, repo string) ([]*database.Environment, error) {
+	ret := _m.Called(ctx, owner, repo)
+
+	var r0 []*database.Environment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*database.Environment, error)); ok {
+		return rf(ctx, owner, repo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*database.Environment); ok {
+		r0 = rf(ctx, owner, repo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*database.Environment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, owner, repo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvironmentsProvider_ListEnvironmentsByRepo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEnvironmentsByRepo'
+type EnvironmentsProvider_ListEnvironmentsByRepo_Call struct {
+	*mock.Call
+}
+
+// ListEnvironmentsByRepo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+func (_e *EnvironmentsProvider_Expecter) ListEnvironmentsByRepo(ctx interface{}, owner interface{}, repo interface{}) *EnvironmentsProvider_ListEnvironmentsByRepo_Call {
+	return &EnvironmentsProvider_ListEnvironmentsByRepo_Call{Call: _e.mock.On("ListEnvironmentsByRepo", ctx, owner, repo)}
+}
+
+func (_c *EnvironmentsProvider_ListEnvironmentsByRepo_Call) Run(run func(ctx context.Context, owner string, repo string)) *EnvironmentsProvider_ListEnvironmentsByRepo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *EnvironmentsProvider_ListEnvironmentsByRepo_Call) Return(_a0 []*database.Environment, _a1 error) *EnvironmentsProvider_ListEnvironmentsByRepo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EnvironmentsProvider_ListEnvironmentsByRepo_Call) RunAndReturn(run func(context.Context, string, string) ([]*database.Environment, error)) *EnvironmentsProvider_ListEnvironmentsByRepo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListSuccessEnvironments provides a mock function with given fields: ctx
 func (_m *EnvironmentsProvider) ListSuccessEnvironments(ctx context.Context) ([]*database.Environment, error) {
 	ret := _m.Called(ctx)
@@ -22,13 +22,13 @@ func (_m *EnvVarsProvider) EXPECT() *EnvVarsProvider_Expecter {
 	return &EnvVarsProvider_Expecter{mock: &_m.Mock}
 }
 
-// Delete provides a mock function with given fields: ctx, owner, repo, name, branch
-func (_m *EnvVarsProvider) Delete(ctx context.Context, owner string, repo string, name string, branch *string) error {
-	ret := _m.Called(ctx, owner, repo, name, branch)
+// Delete provides a mock function with given fields: ctx, owner, repo, name, scope
+func (_m *EnvVarsProvider) Delete(ctx context.Context, owner string, repo string, name string, scope envvars.Scope) error {
+	ret := _m.Called(ctx, owner, repo, name, scope)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *string) error); ok {
-		r0 = rf(ctx, owner, repo, name, branch)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, envvars.Scope) error); ok {
+		r0 = rf(ctx, owner, repo, name, scope)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -46,14 +46,14 @@ type EnvVarsProvider_Delete_Call struct {
 //   - owner string
 //   - repo string
 //   - name string
-//   - branch *string
-func (_e *EnvVarsProvider_Expecter) Delete(ctx interface{}, owner interface{}, repo interface{}, name interface{}, branch interface{}) *EnvVarsProvider_Delete_Call {
-	return &EnvVarsProvider_Delete_Call{Call: _e.mock.On("Delete", ctx, owner, repo, name, branch)}
+//   - scope envvars.Scope
+func (_e *EnvVarsProvider_Expecter) Delete(ctx interface{}, owner interface{}, repo interface{}, name interface{}, scope interface{}) *EnvVarsProvider_Delete_Call {
+	return &EnvVarsProvider_Delete_Call{Call: _e.mock.On("Delete", ctx, owner, repo, name, scope)}
 }
 
-func (_c *EnvVarsProvider_Delete_Call) Run(run func(ctx context.Context, owner string, repo string, name string, branch *string)) *EnvVarsProvider_Delete_Call {
+func (_c *EnvVarsProvider_Delete_Call) Run(run func(ctx context.Context, owner string, repo string, name string, scope envvars.Scope)) *EnvVarsProvider_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(*string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(envvars.Scope))
 	})
 	return _c
 }
@@ -63,7 +63,7 @@ func (_c *EnvVarsProvider_Delete_Call) Return(_a0 error) *EnvVarsProvider_Delete
 	return _c
 }
 
-func (_c *EnvVarsProvider_Delete_Call) RunAndReturn(run func(context.Context, string, string, string, *string) error) *EnvVarsProvider_Delete_Call {
+func (_c *EnvVarsProvider_Delete_Call) RunAndReturn(run func(context.Context, string, string, string, envvars.Scope) error) *EnvVarsProvider_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -124,25 +124,25 @@ func (_c *EnvVarsProvider_ListByRepo_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
-// ListByRepoBranch provides a mock function with given fields: ctx, owner, repo, branch
-func (_m *EnvVarsProvider) ListByRepoBranch(ctx context.Context, owner string, repo string, branch string) ([]envvars.EnvVar, error) {
-	ret := _m.Called(ctx, owner, repo, branch)
+// ListEffective provides a mock function with given fields: ctx, owner, repo, target
+func (_m *EnvVarsProvider) ListEffective(ctx context.Context, owner string, repo string, target envvars.Target) ([]envvars.EnvVar, error) {
+	ret := _m.Called(ctx, owner, repo, target)
 
 	var r0 []envvars.EnvVar
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]envvars.EnvVar, error)); ok {
-		return rf(ctx, owner, repo, branch)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, envvars.Target) ([]envvars.EnvVar, error)); ok {
+		return rf(ctx, owner, repo, target)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []envvars.EnvVar); ok {
-		r0 = rf(ctx, owner, repo, branch)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, envvars.Target) []envvars.EnvVar); ok {
+		r0 = rf(ctx, owner, repo, target)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]envvars.EnvVar)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, owner, repo, branch)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, envvars.Target) error); ok {
+		r1 = rf(ctx, owner, repo, target)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -150,44 +150,161 @@ func (_m *EnvVarsProvider) ListByRepoBranch(ctx context.Context, owner string, r
 	return r0, r1
 }
 
-// EnvVarsProvider_ListByRepoBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByRepoBranch'
-type EnvVarsProvider_ListByRepoBranch_Call struct {
+// EnvVarsProvider_ListEffective_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEffective'
+type EnvVarsProvider_ListEffective_Call struct {
 	*mock.Call
 }
 
-// ListByRepoBranch is a helper method to define mock.On call
+// ListEffective is a helper method to define mock.On call
 //   - ctx context.Context
 //   - owner string
 //   - repo string
-//   - branch string
-func (_e *EnvVarsProvider_Expecter) ListByRepoBranch(ctx interface{}, owner interface{}, repo interface{}, branch interface{}) *EnvVarsProvider_ListByRepoBranch_Call {
-	return &EnvVarsProvider_ListByRepoBranch_Call{Call: _e.mock.On("ListByRepoBranch", ctx, owner, repo, branch)}
+//   - target envvars.Target
+func (_e *EnvVarsProvider_Expecter) ListEffective(ctx interface{}, owner interface{}, repo interface{}, target interface{}) *EnvVarsProvider_ListEffective_Call {
+	return &EnvVarsProvider_ListEffective_Call{Call: _e.mock.On("ListEffective", ctx, owner, repo, target)}
 }
 
-func (_c *EnvVarsProvider_ListByRepoBranch_Call) Run(run func(ctx context.Context, owner string, repo string, branch string)) *EnvVarsProvider_ListByRepoBranch_Call {
+func (_c *EnvVarsProvider_ListEffective_Call) Run(run func(ctx context.Context, owner string, repo string, target envvars.Target)) *EnvVarsProvider_ListEffective_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(envvars.Target))
 	})
 	return _c
 }
 
-func (_c *EnvVarsProvider_ListByRepoBranch_Call) Return(_a0 []envvars.EnvVar, _a1 error) *EnvVarsProvider_ListByRepoBranch_Call {
+func (_c *EnvVarsProvider_ListEffective_Call) Return(_a0 []envvars.EnvVar, _a1 error) *EnvVarsProvider_ListEffective_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *EnvVarsProvider_ListByRepoBranch_Call) RunAndReturn(run func(context.Context, string, string, string) ([]envvars.EnvVar, error)) *EnvVarsProvider_ListByRepoBranch_Call {
+func (_c *EnvVarsProvider_ListEffective_Call) RunAndReturn(run func(context.Context, string, string, envvars.Target) ([]envvars.EnvVar, error)) *EnvVarsProvider_ListEffective_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Upsert provides a mock function with given fields: ctx, owner, repo, name, value, branch
-func (_m *EnvVarsProvider) Upsert(ctx context.Context, owner string, repo string, name string, value string, branch *string) error {
-	ret := _m.Called(ctx, owner, repo, name, value, branch)
+// Patch provides a mock function with given fields: ctx, owner, repo, upsert, remove, version
+func (_m *EnvVarsProvider) Patch(ctx context.Context, owner string, repo string, upsert []envvars.EnvVar, remove []envvars.EnvVar, version *int64) (*envvars.ChangeResult, error) {
+	ret := _m.Called(ctx, owner, repo, upsert, remove, version)
+
+	var r0 *envvars.ChangeResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []envvars.EnvVar, []envvars.EnvVar, *int64) (*envvars.ChangeResult, error)); ok {
+		return rf(ctx, owner, repo, upsert, remove, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []envvars.EnvVar, []envvars.EnvVar, *int64) *envvars.ChangeResult); ok {
+		r0 = rf(ctx, owner, repo, upsert, remove, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*envvars.ChangeResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []envvars.EnvVar, []envvars.EnvVar, *int64) error); ok {
+		r1 = rf(ctx, owner, repo, upsert, remove, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvVarsProvider_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type EnvVarsProvider_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - upsert []envvars.EnvVar
+//   - remove []envvars.EnvVar
+//   - version *int64
+func (_e *EnvVarsProvider_Expecter) Patch(ctx interface{}, owner interface{}, repo interface{}, upsert interface{}, remove interface{}, version interface{}) *EnvVarsProvider_Patch_Call {
+	return &EnvVarsProvider_Patch_Call{Call: _e.mock.On("Patch", ctx, owner, repo, upsert, remove, version)}
+}
+
+func (_c *EnvVarsProvider_Patch_Call) Run(run func(ctx context.Context, owner string, repo string, upsert []envvars.EnvVar, remove []envvars.EnvVar, version *int64)) *EnvVarsProvider_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]envvars.EnvVar), args[4].([]envvars.EnvVar), args[5].(*int64))
+	})
+	return _c
+}
+
+func (_c *EnvVarsProvider_Patch_Call) Return(_a0 *envvars.ChangeResult, _a1 error) *EnvVarsProvider_Patch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EnvVarsProvider_Patch_Call) RunAndReturn(run func(context.Context, string, string, []envvars.EnvVar, []envvars.EnvVar, *int64) (*envvars.ChangeResult, error)) *EnvVarsProvider_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Replace provides a mock function with given fields: ctx, owner, repo, vars, version
+func (_m *EnvVarsProvider) Replace(ctx context.Context, owner string, repo string, vars []envvars.EnvVar, version *int64) (*envvars.ChangeResult, error) {
+	ret := _m.Called(ctx, owner, repo, vars, version)
+
+	var r0 *envvars.ChangeResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []envvars.EnvVar, *int64) (*envvars.ChangeResult, error)); ok {
+		return rf(ctx, owner, repo, vars, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []envvars.EnvVar, *int64) *envvars.ChangeResult); ok {
+		r0 = rf(ctx, owner, repo, vars, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*envvars.ChangeResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []envvars.EnvVar, *int64) error); ok {
+		r1 = rf(ctx, owner, repo, vars, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvVarsProvider_Replace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Replace'
+type EnvVarsProvider_Replace_Call struct {
+	*mock.Call
+}
+
+// Replace is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - vars []envvars.EnvVar
+//   - version *int64
+func (_e *EnvVarsProvider_Expecter) Replace(ctx interface{}, owner interface{}, repo interface{}, vars interface{}, version interface{}) *EnvVarsProvider_Replace_Call {
+	return &EnvVarsProvider_Replace_Call{Call: _e.mock.On("Replace", ctx, owner, repo, vars, version)}
+}
+
+func (_c *EnvVarsProvider_Replace_Call) Run(run func(ctx context.Context, owner string, repo string, vars []envvars.EnvVar, version *int64)) *EnvVarsProvider_Replace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]envvars.EnvVar), args[4].(*int64))
+	})
+	return _c
+}
+
+func (_c *EnvVarsProvider_Replace_Call) Return(_a0 *envvars.ChangeResult, _a1 error) *EnvVarsProvider_Replace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EnvVarsProvider_Replace_Call) RunAndReturn(run func(context.Context, string, string, []envvars.EnvVar, *int64) (*envvars.ChangeResult, error)) *EnvVarsProvider_Replace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: ctx, owner, repo, name, value, scope
+func (_m *EnvVarsProvider) Upsert(ctx context.Context, owner string, repo string, name string, value string, scope envvars.Scope) error {
+	ret := _m.Called(ctx, owner, repo, name, value, scope)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, *string) error); ok {
-		r0 = rf(ctx, owner, repo, name, value, branch)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, envvars.Scope) error); ok {
+		r0 = rf(ctx, owner, repo, name, value, scope)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -206,14 +323,14 @@ type EnvVarsProvider_Upsert_Call struct {
 //   - repo string
 //   - name string
 //   - value string
-//   - branch *string
-func (_e *EnvVarsProvider_Expecter) Upsert(ctx interface{}, owner interface{}, repo interface{}, name interface{}, value interface{}, branch interface{}) *EnvVarsProvider_Upsert_Call {
-	return &EnvVarsProvider_Upsert_Call{Call: _e.mock.On("Upsert", ctx, owner, repo, name, value, branch)}
+//   - scope envvars.Scope
+func (_e *EnvVarsProvider_Expecter) Upsert(ctx interface{}, owner interface{}, repo interface{}, name interface{}, value interface{}, scope interface{}) *EnvVarsProvider_Upsert_Call {
+	return &EnvVarsProvider_Upsert_Call{Call: _e.mock.On("Upsert", ctx, owner, repo, name, value, scope)}
 }
 
-func (_c *EnvVarsProvider_Upsert_Call) Run(run func(ctx context.Context, owner string, repo string, name string, value string, branch *string)) *EnvVarsProvider_Upsert_Call {
+func (_c *EnvVarsProvider_Upsert_Call) Run(run func(ctx context.Context, owner string, repo string, name string, value string, scope envvars.Scope)) *EnvVarsProvider_Upsert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(*string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(envvars.Scope))
 	})
 	return _c
 }
@@ -223,7 +340,61 @@ func (_c *EnvVarsProvider_Upsert_Call) Return(_a0 error) *EnvVarsProvider_Upsert
 	return _c
 }
 
-func (_c *EnvVarsProvider_Upsert_Call) RunAndReturn(run func(context.Context, string, string, string, string, *string) error) *EnvVarsProvider_Upsert_Call {
+func (_c *EnvVarsProvider_Upsert_Call) RunAndReturn(run func(context.Context, string, string, string, string, envvars.Scope) error) *EnvVarsProvider_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Version provides a mock function with given fields: ctx, owner, repo
+func (_m *EnvVarsProvider) Version(ctx context.Context, owner string, repo string) (int64, error) {
+	ret := _m.Called(ctx, owner, repo)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, owner, repo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, owner, repo)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, owner, repo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvVarsProvider_Version_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Version'
+type EnvVarsProvider_Version_Call struct {
+	*mock.Call
+}
+
+// Version is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+func (_e *EnvVarsProvider_Expecter) Version(ctx interface{}, owner interface{}, repo interface{}) *EnvVarsProvider_Version_Call {
+	return &EnvVarsProvider_Version_Call{Call: _e.mock.On("Version", ctx, owner, repo)}
+}
+
+func (_c *EnvVarsProvider_Version_Call) Run(run func(ctx context.Context, owner string, repo string)) *EnvVarsProvider_Version_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *EnvVarsProvider_Version_Call) Return(_a0 int64, _a1 error) *EnvVarsProvider_Version_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EnvVarsProvider_Version_Call) RunAndReturn(run func(context.Context, string, string) (int64, error)) *EnvVarsProvider_Version_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -70,6 +70,63 @@ func (_c *GHAppClient_CloneRepo_Call) RunAndReturn(run func(context.Context, str
 	return _c
 }
 
+// CreateCheckRun provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *GHAppClient) CreateCheckRun(ctx context.Context, owner string, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+
+	var r0 *github.CheckRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, github.CreateCheckRunOptions) (*github.CheckRun, error)); ok {
+		return rf(ctx, owner, repo, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, github.CreateCheckRunOptions) *github.CheckRun); ok {
+		r0 = rf(ctx, owner, repo, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.CheckRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, github.CreateCheckRunOptions) error); ok {
+		r1 = rf(ctx, owner, repo, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GHAppClient_CreateCheckRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCheckRun'
+type GHAppClient_CreateCheckRun_Call struct {
+	*mock.Call
+}
+
+// CreateCheckRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - opts github.CreateCheckRunOptions
+func (_e *GHAppClient_Expecter) CreateCheckRun(ctx interface{}, owner interface{}, repo interface{}, opts interface{}) *GHAppClient_CreateCheckRun_Call {
+	return &GHAppClient_CreateCheckRun_Call{Call: _e.mock.On("CreateCheckRun", ctx, owner, repo, opts)}
+}
+
+func (_c *GHAppClient_CreateCheckRun_Call) Run(run func(ctx context.Context, owner string, repo string, opts github.CreateCheckRunOptions)) *GHAppClient_CreateCheckRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(github.CreateCheckRunOptions))
+	})
+	return _c
+}
+
+func (_c *GHAppClient_CreateCheckRun_Call) Return(_a0 *github.CheckRun, _a1 error) *GHAppClient_CreateCheckRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GHAppClient_CreateCheckRun_Call) RunAndReturn(run func(context.Context, string, string, github.CreateCheckRunOptions) (*github.CheckRun, error)) *GHAppClient_CreateCheckRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateCommitStatus provides a mock function with given fields: ctx, owner, repo, sha, state, targetURL
 func (_m *GHAppClient) CreateCommitStatus(ctx context.Context, owner string, repo string, sha string, state string, targetURL *string) error {
 	ret := _m.Called(ctx, owner, repo, sha, state, targetURL)
@@ -117,6 +174,109 @@ func (_c *GHAppClient_CreateCommitStatus_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// CreateDeployment provides a mock function with given fields: ctx, owner, repo, req
+func (_m *GHAppClient) CreateDeployment(ctx context.Context, owner string, repo string, req *github.DeploymentRequest) (*github.Deployment, error) {
+	ret := _m.Called(ctx, owner, repo, req)
+
+	var r0 *github.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.DeploymentRequest) (*github.Deployment, error)); ok {
+		return rf(ctx, owner, repo, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.DeploymentRequest) *github.Deployment); ok {
+		r0 = rf(ctx, owner, repo, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *github.DeploymentRequest) error); ok {
+		r1 = rf(ctx, owner, repo, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GHAppClient_CreateDeployment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDeployment'
+type GHAppClient_CreateDeployment_Call struct {
+	*mock.Call
+}
+
+// CreateDeployment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - req *github.DeploymentRequest
+func (_e *GHAppClient_Expecter) CreateDeployment(ctx interface{}, owner interface{}, repo interface{}, req interface{}) *GHAppClient_CreateDeployment_Call {
+	return &GHAppClient_CreateDeployment_Call{Call: _e.mock.On("CreateDeployment", ctx, owner, repo, req)}
+}
+
+func (_c *GHAppClient_CreateDeployment_Call) Run(run func(ctx context.Context, owner string, repo string, req *github.DeploymentRequest)) *GHAppClient_CreateDeployment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*github.DeploymentRequest))
+	})
+	return _c
+}
+
+func (_c *GHAppClient_CreateDeployment_Call) Return(_a0 *github.Deployment, _a1 error) *GHAppClient_CreateDeployment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GHAppClient_CreateDeployment_Call) RunAndReturn(run func(context.Context, string, string, *github.DeploymentRequest) (*github.Deployment, error)) *GHAppClient_CreateDeployment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDeploymentStatus provides a mock function with given fields: ctx, owner, repo, deploymentID, req
+func (_m *GHAppClient) CreateDeploymentStatus(ctx context.Context, owner string, repo string, deploymentID int64, req *github.DeploymentStatusRequest) error {
+	ret := _m.Called(ctx, owner, repo, deploymentID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, *github.DeploymentStatusRequest) error); ok {
+		r0 = rf(ctx, owner, repo, deploymentID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GHAppClient_CreateDeploymentStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDeploymentStatus'
+type GHAppClient_CreateDeploymentStatus_Call struct {
+	*mock.Call
+}
+
+// CreateDeploymentStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - deploymentID int64
+//   - req *github.DeploymentStatusRequest
+func (_e *GHAppClient_Expecter) CreateDeploymentStatus(ctx interface{}, owner interface{}, repo interface{}, deploymentID interface{}, req interface{}) *GHAppClient_CreateDeploymentStatus_Call {
+	return &GHAppClient_CreateDeploymentStatus_Call{Call: _e.mock.On("CreateDeploymentStatus", ctx, owner, repo, deploymentID, req)}
+}
+
+func (_c *GHAppClient_CreateDeploymentStatus_Call) Run(run func(ctx context.Context, owner string, repo string, deploymentID int64, req *github.DeploymentStatusRequest)) *GHAppClient_CreateDeploymentStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64), args[4].(*github.DeploymentStatusRequest))
+	})
+	return _c
+}
+
+func (_c *GHAppClient_CreateDeploymentStatus_Call) Return(_a0 error) *GHAppClient_CreateDeploymentStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GHAppClient_CreateDeploymentStatus_Call) RunAndReturn(run func(context.Context, string, string, int64, *github.DeploymentStatusRequest) error) *GHAppClient_CreateDeploymentStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreatePullRequest provides a mock function with given fields: ctx, owner, repo, branchPrefix, changes, title, description
 func (_m *GHAppClient) CreatePullRequest(ctx context.Context, owner string, repo string, branchPrefix string, changes map[string]string, title string, description string) (*github.PullRequest, error) {
 	ret := _m.Called(ctx, owner, repo, branchPrefix, changes, title, description)
@@ -481,6 +641,62 @@ func (_c *GHAppClient_GetDefaultBranch_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// GetFileContent provides a mock function with given fields: ctx, owner, repo, ref, path
+func (_m *GHAppClient) GetFileContent(ctx context.Context, owner string, repo string, ref string, path string) (string, error) {
+	ret := _m.Called(ctx, owner, repo, ref, path)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (string, error)); ok {
+		return rf(ctx, owner, repo, ref, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) string); ok {
+		r0 = rf(ctx, owner, repo, ref, path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, owner, repo, ref, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GHAppClient_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
+type GHAppClient_GetFileContent_Call struct {
+	*mock.Call
+}
+
+// GetFileContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - ref string
+//   - path string
+func (_e *GHAppClient_Expecter) GetFileContent(ctx interface{}, owner interface{}, repo interface{}, ref interface{}, path interface{}) *GHAppClient_GetFileContent_Call {
+	return &GHAppClient_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, owner, repo, ref, path)}
+}
+
+func (_c *GHAppClient_GetFileContent_Call) Run(run func(ctx context.Context, owner string, repo string, ref string, path string)) *GHAppClient_GetFileContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *GHAppClient_GetFileContent_Call) Return(_a0 string, _a1 error) *GHAppClient_GetFileContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GHAppClient_GetFileContent_Call) RunAndReturn(run func(context.Context, string, string, string, string) (string, error)) *GHAppClient_GetFileContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetInstallation provides a mock function with given fields: ctx, installationID
 func (_m *GHAppClient) GetInstallation(ctx context.Context, installationID int64) (*github.Installation, error) {
 	ret := _m.Called(ctx, installationID)
@@ -536,6 +752,172 @@ func (_c *GHAppClient_GetInstallation_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// GetPullRequest provides a mock function with given fields: ctx, owner, repo, prNumber
+func (_m *GHAppClient) GetPullRequest(ctx context.Context, owner string, repo string, prNumber int) (*github.PullRequest, error) {
+	ret := _m.Called(ctx, owner, repo, prNumber)
+
+	var r0 *github.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) (*github.PullRequest, error)); ok {
+		return rf(ctx, owner, repo, prNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) *github.PullRequest); ok {
+		r0 = rf(ctx, owner, repo, prNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, owner, repo, prNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GHAppClient_GetPullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPullRequest'
+type GHAppClient_GetPullRequest_Call struct {
+	*mock.Call
+}
+
+// GetPullRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - prNumber int
+func (_e *GHAppClient_Expecter) GetPullRequest(ctx interface{}, owner interface{}, repo interface{}, prNumber interface{}) *GHAppClient_GetPullRequest_Call {
+	return &GHAppClient_GetPullRequest_Call{Call: _e.mock.On("GetPullRequest", ctx, owner, repo, prNumber)}
+}
+
+func (_c *GHAppClient_GetPullRequest_Call) Run(run func(ctx context.Context, owner string, repo string, prNumber int)) *GHAppClient_GetPullRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *GHAppClient_GetPullRequest_Call) Return(_a0 *github.PullRequest, _a1 error) *GHAppClient_GetPullRequest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GHAppClient_GetPullRequest_Call) RunAndReturn(run func(context.Context, string, string, int) (*github.PullRequest, error)) *GHAppClient_GetPullRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepoPermissionLevel provides a mock function with given fields: ctx, owner, repo, login
+func (_m *GHAppClient) GetRepoPermissionLevel(ctx context.Context, owner string, repo string, login string) (string, error) {
+	ret := _m.Called(ctx, owner, repo, login)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, owner, repo, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, owner, repo, login)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, owner, repo, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GHAppClient_GetRepoPermissionLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepoPermissionLevel'
+type GHAppClient_GetRepoPermissionLevel_Call struct {
+	*mock.Call
+}
+
+// GetRepoPermissionLevel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - login string
+func (_e *GHAppClient_Expecter) GetRepoPermissionLevel(ctx interface{}, owner interface{}, repo interface{}, login interface{}) *GHAppClient_GetRepoPermissionLevel_Call {
+	return &GHAppClient_GetRepoPermissionLevel_Call{Call: _e.mock.On("GetRepoPermissionLevel", ctx, owner, repo, login)}
+}
+
+func (_c *GHAppClient_GetRepoPermissionLevel_Call) Run(run func(ctx context.Context, owner string, repo string, login string)) *GHAppClient_GetRepoPermissionLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *GHAppClient_GetRepoPermissionLevel_Call) Return(_a0 string, _a1 error) *GHAppClient_GetRepoPermissionLevel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GHAppClient_GetRepoPermissionLevel_Call) RunAndReturn(run func(context.Context, string, string, string) (string, error)) *GHAppClient_GetRepoPermissionLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsOrgMember provides a mock function with given fields: ctx, org, login
+func (_m *GHAppClient) IsOrgMember(ctx context.Context, org string, login string) (bool, error) {
+	ret := _m.Called(ctx, org, login)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, org, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, org, login)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, org, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GHAppClient_IsOrgMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsOrgMember'
+type GHAppClient_IsOrgMember_Call struct {
+	*mock.Call
+}
+
+// IsOrgMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org string
+//   - login string
+func (_e *GHAppClient_Expecter) IsOrgMember(ctx interface{}, org interface{}, login interface{}) *GHAppClient_IsOrgMember_Call {
+	return &GHAppClient_IsOrgMember_Call{Call: _e.mock.On("IsOrgMember", ctx, org, login)}
+}
+
+func (_c *GHAppClient_IsOrgMember_Call) Run(run func(ctx context.Context, org string, login string)) *GHAppClient_IsOrgMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *GHAppClient_IsOrgMember_Call) Return(_a0 bool, _a1 error) *GHAppClient_IsOrgMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GHAppClient_IsOrgMember_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *GHAppClient_IsOrgMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsOwnerInstalled provides a mock function with given fields: ctx, owner
 func (_m *GHAppClient) IsOwnerInstalled(ctx context.Context, owner string) (bool, error) {
 	ret := _m.Called(ctx, owner)
@@ -643,6 +1025,61 @@ func (_c *GHAppClient_IsRepoPrivate_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// IsTeamMember provides a mock function with given fields: ctx, org, teamSlug, login
+func (_m *GHAppClient) IsTeamMember(ctx context.Context, org string, teamSlug string, login string) (bool, error) {
+	ret := _m.Called(ctx, org, teamSlug, login)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
+		return rf(ctx, org, teamSlug, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(ctx, org, teamSlug, login)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, org, teamSlug, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GHAppClient_IsTeamMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsTeamMember'
+type GHAppClient_IsTeamMember_Call struct {
+	*mock.Call
+}
+
+// IsTeamMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org string
+//   - teamSlug string
+//   - login string
+func (_e *GHAppClient_Expecter) IsTeamMember(ctx interface{}, org interface{}, teamSlug interface{}, login interface{}) *GHAppClient_IsTeamMember_Call {
+	return &GHAppClient_IsTeamMember_Call{Call: _e.mock.On("IsTeamMember", ctx, org, teamSlug, login)}
+}
+
+func (_c *GHAppClient_IsTeamMember_Call) Run(run func(ctx context.Context, org string, teamSlug string, login string)) *GHAppClient_IsTeamMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *GHAppClient_IsTeamMember_Call) Return(_a0 bool, _a1 error) *GHAppClient_IsTeamMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GHAppClient_IsTeamMember_Call) RunAndReturn(run func(context.Context, string, string, string) (bool, error)) *GHAppClient_IsTeamMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListBranches provides a mock function with given fields: ctx, owner, repo
 func (_m *GHAppClient) ListBranches(ctx context.Context, owner string, repo string) ([]string, error) {
 	ret := _m.Called(ctx, owner, repo)
@@ -808,6 +1245,110 @@ func (_c *GHAppClient_ListOwnerInstalledRepos_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// RemoveLabel provides a mock function with given fields: ctx, owner, repo, prNumber, label
+func (_m *GHAppClient) RemoveLabel(ctx context.Context, owner string, repo string, prNumber int, label string) error {
+	ret := _m.Called(ctx, owner, repo, prNumber, label)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, string) error); ok {
+		r0 = rf(ctx, owner, repo, prNumber, label)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GHAppClient_RemoveLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveLabel'
+type GHAppClient_RemoveLabel_Call struct {
+	*mock.Call
+}
+
+// RemoveLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - prNumber int
+//   - label string
+func (_e *GHAppClient_Expecter) RemoveLabel(ctx interface{}, owner interface{}, repo interface{}, prNumber interface{}, label interface{}) *GHAppClient_RemoveLabel_Call {
+	return &GHAppClient_RemoveLabel_Call{Call: _e.mock.On("RemoveLabel", ctx, owner, repo, prNumber, label)}
+}
+
+func (_c *GHAppClient_RemoveLabel_Call) Run(run func(ctx context.Context, owner string, repo string, prNumber int, label string)) *GHAppClient_RemoveLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *GHAppClient_RemoveLabel_Call) Return(_a0 error) *GHAppClient_RemoveLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GHAppClient_RemoveLabel_Call) RunAndReturn(run func(context.Context, string, string, int, string) error) *GHAppClient_RemoveLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCheckRun provides a mock function with given fields: ctx, owner, repo, checkRunID, opts
+func (_m *GHAppClient) UpdateCheckRun(ctx context.Context, owner string, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, error) {
+	ret := _m.Called(ctx, owner, repo, checkRunID, opts)
+
+	var r0 *github.CheckRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, github.UpdateCheckRunOptions) (*github.CheckRun, error)); ok {
+		return rf(ctx, owner, repo, checkRunID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, github.UpdateCheckRunOptions) *github.CheckRun); ok {
+		r0 = rf(ctx, owner, repo, checkRunID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.CheckRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, github.UpdateCheckRunOptions) error); ok {
+		r1 = rf(ctx, owner, repo, checkRunID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GHAppClient_UpdateCheckRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCheckRun'
+type GHAppClient_UpdateCheckRun_Call struct {
+	*mock.Call
+}
+
+// UpdateCheckRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - checkRunID int64
+//   - opts github.UpdateCheckRunOptions
+func (_e *GHAppClient_Expecter) UpdateCheckRun(ctx interface{}, owner interface{}, repo interface{}, checkRunID interface{}, opts interface{}) *GHAppClient_UpdateCheckRun_Call {
+	return &GHAppClient_UpdateCheckRun_Call{Call: _e.mock.On("UpdateCheckRun", ctx, owner, repo, checkRunID, opts)}
+}
+
+func (_c *GHAppClient_UpdateCheckRun_Call) Run(run func(ctx context.Context, owner string, repo string, checkRunID int64, opts github.UpdateCheckRunOptions)) *GHAppClient_UpdateCheckRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64), args[4].(github.UpdateCheckRunOptions))
+	})
+	return _c
+}
+
+func (_c *GHAppClient_UpdateCheckRun_Call) Return(_a0 *github.CheckRun, _a1 error) *GHAppClient_UpdateCheckRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GHAppClient_UpdateCheckRun_Call) RunAndReturn(run func(context.Context, string, string, int64, github.UpdateCheckRunOptions) (*github.CheckRun, error)) *GHAppClient_UpdateCheckRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpsertComment provides a mock function with given fields: ctx, owner, repo, prNumber, commentID, comment
 func (_m *GHAppClient) UpsertComment(ctx context.Context, owner string, repo string, prNumber int, commentID int64, comment string) (*github.IssueComment, error) {
 	ret := _m.Called(ctx, owner, repo, prNumber, commentID, comment)
@@ -86,6 +86,60 @@ func (_c *GHOAuthClient_GetUser_Call) RunAndReturn(run func(context.Context) (*g
 	return _c
 }
 
+// IsOrgMember provides a mock function with given fields: ctx, org, user
+func (_m *GHOAuthClient) IsOrgMember(ctx context.Context, org string, user string) (bool, error) {
+	ret := _m.Called(ctx, org, user)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, org, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, org, user)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, org, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GHOAuthClient_IsOrgMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsOrgMember'
+type GHOAuthClient_IsOrgMember_Call struct {
+	*mock.Call
+}
+
+// IsOrgMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org string
+//   - user string
+func (_e *GHOAuthClient_Expecter) IsOrgMember(ctx interface{}, org interface{}, user interface{}) *GHOAuthClient_IsOrgMember_Call {
+	return &GHOAuthClient_IsOrgMember_Call{Call: _e.mock.On("IsOrgMember", ctx, org, user)}
+}
+
+func (_c *GHOAuthClient_IsOrgMember_Call) Run(run func(ctx context.Context, org string, user string)) *GHOAuthClient_IsOrgMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *GHOAuthClient_IsOrgMember_Call) Return(_a0 bool, _a1 error) *GHOAuthClient_IsOrgMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GHOAuthClient_IsOrgMember_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *GHOAuthClient_IsOrgMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListOrganizations provides a mock function with given fields: ctx
 func (_m *GHOAuthClient) ListOrganizations(ctx context.Context) ([]*github.Organization, *github.Response, error) {
 	ret := _m.Called(ctx)